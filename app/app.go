@@ -0,0 +1,150 @@
+package app
+
+import (
+	"context"  // Mengimport package context untuk pemeriksaan dependency
+	"log/slog" // Mengimport package slog untuk mencatat kegagalan saat menutup aplikasi
+	"time"     // Mengimport package time untuk durasi
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/config"     // Mengimport konfigurasi aplikasi
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/controller" // Mengimport controller aplikasi
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/mail"       // Mengimport pengirim email
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/metrics"    // Mengimport metrik Prometheus
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/ratelimit"  // Mengimport penyimpanan rate limit
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/repository" // Mengimport repository aplikasi
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/search"     // Mengimport index pencarian buku
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service"    // Mengimport service aplikasi
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/tracing"    // Mengimport tracing OpenTelemetry
+	"gorm.io/gorm"                                              // Mengimport ORM GORM untuk manipulasi database
+)
+
+// tracingShutdownTimeout adalah batas waktu mengirim span yang tersisa saat aplikasi ditutup
+const tracingShutdownTimeout = 5 * time.Second
+
+// revocationPurgeInterval adalah jeda antar pembersihan catatan token dicabut yang sudah kedaluwarsa
+const revocationPurgeInterval = 10 * time.Minute
+
+// App menyimpan semua dependency aplikasi yang dipakai bersama oleh server HTTP dan perintah CLI
+type App struct {
+	Config  *config.Config   // Konfigurasi efektif aplikasi
+	Metrics *metrics.Metrics // Metrik Prometheus yang diekspos lewat /metrics
+
+	shutdownTracing func(context.Context) error // Mengirim span yang tersisa saat aplikasi ditutup
+
+	DB                      *gorm.DB                           // Koneksi database
+	UserRepository          repository.UserRepository          // Repository user
+	BookRepository          repository.BookRepository          // Repository buku
+	RefreshTokenRepository  repository.RefreshTokenRepository  // Repository refresh token
+	RevocationStore         repository.RevocationStore         // Penyimpanan daftar token yang dicabut
+	PasswordResetRepository repository.PasswordResetRepository // Repository token reset password
+	MFARepository           repository.MFARepository           // Repository data 2FA
+	APIKeyRepository        repository.APIKeyRepository        // Repository API key
+	IdentityRepository      repository.IdentityRepository      // Repository identitas dari identity provider luar
+	OAuthRepository         repository.OAuthRepository         // Repository client, authorization code, dan refresh token OAuth2
+	SessionRepository       repository.SessionRepository       // Repository sesi login
+	BookIndex               search.BookIndex                   // Index pencarian buku
+	RateLimitStore          ratelimit.Store                    // Penyimpanan token bucket rate limit
+	Mailer                  mail.Mailer                        // Pengirim email
+
+	JWTService           service.JWTService               // Service JWT
+	RefreshTokenService  service.RefreshTokenService      // Service refresh token
+	RevocationService    service.TokenRevocationService   // Service pencabutan token
+	UserService          service.UserService              // Service user
+	BookService          service.BookService              // Service buku
+	AuthService          service.AuthService              // Service auth
+	HealthService        service.HealthService            // Service pemeriksaan dependency untuk probe readiness
+	PasswordResetService service.PasswordResetService     // Service reset password
+	VerificationService  service.EmailVerificationService // Service verifikasi email
+	MFAService           service.MFAService               // Service 2FA
+	APIKeyService        service.APIKeyService            // Service API key
+	SSOService           service.SSOService               // Service login dengan identity provider luar
+	OAuthService         service.OAuthService             // Service authorization server OAuth2
+	SessionService       service.SessionService           // Service sesi login
+
+	AuthController    controller.AuthController    // Controller auth
+	UserController    controller.UserController    // Controller user
+	BookController    controller.BookController    // Controller buku
+	AdminController   controller.AdminController   // Controller admin
+	MFAController     controller.MFAController     // Controller 2FA
+	APIKeyController  controller.APIKeyController  // Controller API key
+	JWKSController    controller.JWKSController    // Controller JWKS
+	OAuthController   controller.OAuthController   // Controller authorization server OAuth2
+	SessionController controller.SessionController // Controller sesi login
+	HealthController  controller.HealthController  // Controller probe liveness dan readiness
+}
+
+// New membuat koneksi database dan menyusun semua repository, service, dan controller dari cfg.
+// Sama seperti fungsi Setup pada package config, New panic jika dependency gagal dibuat.
+func New(cfg *config.Config) *App {
+	a := &App{Config: cfg, Metrics: metrics.New()}
+	a.shutdownTracing = config.SetupTracing(cfg.Tracing)                      // Memasang exporter trace sebelum ada span yang dibuat
+	a.DB = config.SetupDatabaseConnection(cfg.Database)                       // Membuat koneksi database
+	if err := a.Metrics.InstrumentDB(a.DB, cfg.Database.Driver); err != nil { // Mencatat durasi query dan statistik connection pool
+		panic("Failed to instrument database: " + err.Error())
+	}
+	if err := tracing.InstrumentDB(a.DB); err != nil { // Membuat span untuk setiap query
+		panic("Failed to instrument database: " + err.Error())
+	}
+	passwordHasher := config.SetupPasswordHasher(cfg.Password)                                                                                                                                      // Membuat hasher password
+	passwordPolicy := cfg.Password.PasswordPolicy()                                                                                                                                                 // Membuat kebijakan password baru
+	a.UserRepository = repository.NewUserRepository(a.DB, passwordHasher)                                                                                                                           // Membuat repository user
+	a.BookRepository = repository.NewBookRepository(a.DB)                                                                                                                                           // Membuat repository buku
+	a.RefreshTokenRepository = repository.NewRefreshTokenRepository(a.DB)                                                                                                                           // Membuat repository refresh token
+	a.PasswordResetRepository = repository.NewPasswordResetRepository(a.DB)                                                                                                                         // Membuat repository token reset password
+	a.MFARepository = repository.NewMFARepository(a.DB)                                                                                                                                             // Membuat repository data 2FA
+	a.APIKeyRepository = repository.NewAPIKeyRepository(a.DB)                                                                                                                                       // Membuat repository API key
+	a.IdentityRepository = repository.NewIdentityRepository(a.DB)                                                                                                                                   // Membuat repository identitas dari identity provider luar
+	a.OAuthRepository = repository.NewOAuthRepository(a.DB)                                                                                                                                         // Membuat repository OAuth2
+	a.SessionRepository = repository.NewSessionRepository(a.DB)                                                                                                                                     // Membuat repository sesi login
+	a.RevocationStore = config.SetupRevocationStore(a.DB, cfg.Revocation, time.Duration(cfg.JWT.AccessTokenTTL))                                                                                    // Membuat penyimpanan daftar token yang dicabut
+	a.BookIndex = config.SetupBookIndex(a.DB, cfg.Search)                                                                                                                                           // Membuat index pencarian buku
+	a.Mailer = config.SetupMailer(cfg.Mail)                                                                                                                                                         // Membuat pengirim email
+	a.RateLimitStore = config.SetupRateLimitStore(cfg.RateLimit)                                                                                                                                    // Membuat penyimpanan rate limit
+	a.JWTService = config.SetupJWTService(cfg.JWT)                                                                                                                                                  // Membuat service JWT
+	a.RefreshTokenService = service.NewRefreshTokenService(a.RefreshTokenRepository, time.Duration(cfg.JWT.RefreshTokenTTL))                                                                        // Membuat service refresh token
+	a.RevocationService = service.NewTokenRevocationService(a.RevocationStore, a.RefreshTokenRepository)                                                                                            // Membuat service pencabutan token
+	a.SessionService = service.NewSessionService(a.SessionRepository, a.RefreshTokenRepository)                                                                                                     // Membuat service sesi login
+	a.VerificationService = config.SetupEmailVerificationService(cfg.Verify, cfg.JWT, a.UserRepository, a.Mailer)                                                                                   // Membuat service verifikasi email
+	a.UserService = service.NewTracedUserService(service.NewUserService(a.UserRepository, a.RevocationService, a.VerificationService, passwordHasher, passwordPolicy, cfg.Lockout.LockoutPolicy())) // Membuat service user
+	a.BookService = service.NewTracedBookService(service.NewBookService(a.BookRepository, a.BookIndex))                                                                                             // Membuat service buku
+	a.AuthService = service.NewTracedAuthService(service.NewAuthService(a.UserRepository, passwordHasher, passwordPolicy, cfg.Lockout.LockoutPolicy()))                                             // Membuat service auth
+	a.MFAService = config.SetupMFAService(cfg.MFA, cfg.JWT, cfg.Lockout, a.UserRepository, a.MFARepository)                                                                                         // Membuat service 2FA
+	a.APIKeyService = service.NewAPIKeyService(a.APIKeyRepository, a.UserRepository)                                                                                                                // Membuat service API key
+	a.SSOService = config.SetupSSOService(cfg.SSO, cfg.JWT, a.UserRepository, a.IdentityRepository)                                                                                                 // Membuat service login dengan identity provider luar
+	a.OAuthService = service.NewOAuthService(a.OAuthRepository, a.UserRepository, a.JWTService, a.RevocationService, time.Duration(cfg.OAuth.CodeTTL), time.Duration(cfg.OAuth.RefreshTokenTTL))    // Membuat service authorization server OAuth2
+	a.PasswordResetService = service.NewPasswordResetService(a.UserRepository, a.PasswordResetRepository, a.UserService, passwordPolicy, a.Mailer, time.Duration(cfg.Reset.TTL), cfg.Reset.URL)     // Membuat service reset password
+	a.HealthService = service.NewHealthService(map[string]service.HealthCheck{                                                                                                                      // Membuat service pemeriksaan dependency
+		"database": a.pingDatabase,
+		"search":   a.BookIndex.Ping,
+	})
+	a.AuthController = controller.NewAuthController(a.AuthService, a.JWTService, a.RefreshTokenService, a.RevocationService, a.PasswordResetService, a.VerificationService, a.MFAService, a.SSOService, a.SessionService, cfg.SSO.CallbackURL, a.Metrics) // Membuat controller auth
+	a.UserController = controller.NewUserController(a.UserService)                                                                                                                                                                                        // Membuat controller user
+	a.BookController = controller.NewBookController(a.BookService)                                                                                                                                                                                        // Membuat controller buku
+	a.AdminController = controller.NewAdminController(a.UserService, a.BookService, a.MFAService)                                                                                                                                                         // Membuat controller admin
+	a.MFAController = controller.NewMFAController(a.MFAService)                                                                                                                                                                                           // Membuat controller 2FA
+	a.APIKeyController = controller.NewAPIKeyController(a.APIKeyService)                                                                                                                                                                                  // Membuat controller API key
+	a.JWKSController = controller.NewJWKSController(a.JWTService)                                                                                                                                                                                         // Membuat controller JWKS
+	a.OAuthController = controller.NewOAuthController(a.OAuthService)                                                                                                                                                                                     // Membuat controller authorization server OAuth2
+	a.SessionController = controller.NewSessionController(a.SessionService)                                                                                                                                                                               // Membuat controller sesi login
+	a.HealthController = controller.NewHealthController(a.HealthService)                                                                                                                                                                                  // Membuat controller health check
+	return a
+}
+
+// pingDatabase memeriksa bahwa koneksi database masih bisa dipakai
+func (a *App) pingDatabase(ctx context.Context) error {
+	sqlDB, err := a.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// Close menutup index pencarian dan koneksi database, lalu mengirim span yang tersisa
+func (a *App) Close() {
+	a.BookIndex.Close()                  // Menutup index pencarian
+	config.CloseDatabaseConnection(a.DB) // Menutup koneksi database
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+	if err := a.shutdownTracing(ctx); err != nil {
+		slog.Error("failed to flush traces", "error", err)
+	}
+}
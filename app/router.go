@@ -0,0 +1,118 @@
+package app
+
+import (
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity"                        // Mengimport entitas aplikasi untuk daftar role
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/middleware"                    // Mengimport middleware aplikasi
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service"                       // Mengimport service aplikasi untuk daftar aksi yang bisa dibatasi
+	"github.com/gin-gonic/gin"                                                     // Mengimport framework gin untuk routing HTTP
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin" // Mengimport middleware OpenTelemetry untuk gin
+)
+
+// Router membuat router Gin dengan semua endpoint aplikasi
+func (a *App) Router() *gin.Engine {
+	r := gin.New()                                                              // Membuat router Gin tanpa middleware bawaan
+	if err := r.SetTrustedProxies(a.Config.Server.TrustedProxies); err != nil { // IP client dari X-Forwarded-For hanya dipercaya dari proxy yang terdaftar
+		panic("Failed to set trusted proxies: " + err.Error())
+	}
+	r.Use(otelgin.Middleware(a.Config.Tracing.ServiceName))                                                             // Melanjutkan trace dari header traceparent dan membuat span untuk setiap request
+	r.Use(middleware.RequestID(), middleware.RequestLogger(), middleware.Metrics(a.Metrics), middleware.ErrorHandler()) // Memberi request ID, mencatat request dan metriknya, lalu mengubah error maupun panic menjadi respons JSON
+
+	ipLimit := middleware.RateLimit(a.RateLimitStore, "ip", a.Config.RateLimit.IPLimit(), middleware.ByIP)              // Rate limit untuk setiap IP
+	authorize := middleware.AuthorizeJWT(a.JWTService, a.RevocationService, a.SessionService, a.Metrics)                // Middleware validasi token JWT
+	delegated := middleware.AuthorizeDelegatedJWT(a.JWTService, a.RevocationService, a.SessionService, a.Metrics)       // Middleware validasi token JWT yang juga menerima access token client OAuth2
+	authenticate := middleware.Authenticate(delegated, a.APIKeyService, a.Metrics)                                      // Middleware yang menerima API key dari header X-API-Key, token JWT, atau access token client OAuth2
+	scope := middleware.RequireScope                                                                                    // Menolak API key dan access token client OAuth2 tanpa scope yang dibutuhkan, token login tidak dibatasi scope
+	userLimit := middleware.RateLimit(a.RateLimitStore, "user", a.Config.RateLimit.UserLimit(), middleware.ByUser)      // Rate limit untuk setiap user, dipasang setelah authorize
+	loginLimit := middleware.RateLimit(a.RateLimitStore, "login", a.Config.RateLimit.LoginLimit(), middleware.ByIP)     // Rate limit yang lebih ketat untuk percobaan login
+	emailLimit := middleware.RateLimit(a.RateLimitStore, "email", a.Config.RateLimit.LoginLimit(), middleware.ByIP)     // Limit yang sama dengan login untuk endpoint yang mengirim atau memakai token dari email, dengan bucket terpisah
+	signupLimit := middleware.RateLimit(a.RateLimitStore, "register", a.Config.RateLimit.LoginLimit(), middleware.ByIP) // Limit yang sama dengan login untuk register yang menghash password dan mengirim email, dengan bucket terpisah
+	refreshLimit := middleware.RateLimit(a.RateLimitStore, "refresh", a.Config.RateLimit.LoginLimit(), middleware.ByIP) // Limit yang sama dengan login untuk penukaran refresh token, dengan bucket terpisah
+	// verified menolak aksi yang dilarang bagi user yang belum memverifikasi email, dipasang setelah authorize
+	unverified := a.Config.Verify.UnverifiedPolicy()
+	verified := func(action string) gin.HandlerFunc {
+		return middleware.RequireVerifiedEmail(a.VerificationService, unverified, action)
+	}
+	requireMFA := middleware.RequireMFA(a.MFAService, a.Config.MFA.RequiredRoles) // Role yang wajib memakai 2FA harus mengaktifkannya sebelum memakai endpoint buku dan admin
+
+	r.GET("/healthz", a.HealthController.Live)             // Endpoint probe liveness
+	r.GET("/readyz", a.HealthController.Ready)             // Endpoint probe readiness, memeriksa database dan index pencarian
+	r.GET("/metrics", gin.WrapH(a.Metrics.Handler()))      // Endpoint metrik untuk Prometheus
+	r.GET("/.well-known/jwks.json", a.JWKSController.Keys) // Endpoint kunci publik untuk verifikasi token oleh service lain
+
+	api := r.Group("api", ipLimit) // Semua endpoint API dibatasi per IP, probe dan metrik tidak
+
+	authRoutes := api.Group("auth") // Membuat grup endpoint untuk auth
+	{
+		authRoutes.POST("/login", loginLimit, a.AuthController.Login)                                                  // Endpoint login
+		authRoutes.POST("/login/mfa", loginLimit, a.AuthController.LoginMFA)                                           // Endpoint langkah kedua login untuk user dengan 2FA
+		authRoutes.POST("/register", signupLimit, a.AuthController.Register)                                           // Endpoint register
+		authRoutes.POST("/refresh", refreshLimit, a.AuthController.Refresh)                                            // Endpoint refresh token
+		authRoutes.POST("/forgot-password", emailLimit, a.AuthController.ForgotPassword)                               // Endpoint pengiriman email reset password, dibatasi agar tidak dipakai untuk spam
+		authRoutes.POST("/reset-password", emailLimit, a.AuthController.ResetPassword)                                 // Endpoint reset password dengan token dari email
+		authRoutes.GET("/verify-email", emailLimit, a.AuthController.VerifyEmail)                                      // Endpoint verifikasi email dari link yang dikirim
+		authRoutes.POST("/resend-verification", authorize, userLimit, emailLimit, a.AuthController.ResendVerification) // Endpoint pengiriman ulang link verifikasi email
+		authRoutes.POST("/logout", authorize, userLimit, a.AuthController.Logout)                                      // Endpoint logout sesi saat ini
+		authRoutes.POST("/logout-all", authorize, userLimit, a.AuthController.LogoutAll)                               // Endpoint logout dari semua sesi
+		authRoutes.GET("/sso", a.AuthController.SSOProviders)                                                          // Endpoint daftar identity provider untuk login
+		authRoutes.GET("/sso/:provider/login", loginLimit, a.AuthController.SSOLogin)                                  // Endpoint memulai login dengan identity provider
+		authRoutes.GET("/sso/:provider/callback", loginLimit, a.AuthController.SSOCallback)                            // Endpoint callback dari identity provider
+	}
+
+	oauthRoutes := api.Group("oauth") // Membuat grup endpoint authorization server OAuth2
+	{
+		oauthRoutes.GET("/authorize", authorize, userLimit, a.OAuthController.Consent) // Endpoint data halaman persetujuan untuk frontend
+		oauthRoutes.POST("/authorize", authorize, userLimit, a.OAuthController.Decide) // Endpoint keputusan user di halaman persetujuan
+		oauthRoutes.POST("/token", a.OAuthController.Token)                            // Endpoint penukaran authorization code dan refresh token oleh client
+		oauthRoutes.POST("/introspect", a.OAuthController.Introspect)                  // Endpoint introspection token oleh client
+		oauthRoutes.POST("/revoke", a.OAuthController.Revoke)                          // Endpoint pencabutan token oleh client
+	}
+
+	userRoutes := api.Group("user", authenticate, userLimit) // Membuat grup endpoint untuk user dengan token JWT atau API key
+	{
+		userRoutes.GET("/profile", scope(service.ScopeProfileRead), a.UserController.Profile)                                        // Endpoint profil user
+		userRoutes.PUT("/profile", scope(service.ScopeProfileWrite), verified(service.ActionProfileUpdate), a.UserController.Update) // Endpoint update profil user
+	}
+
+	accountRoutes := api.Group("user", authorize, userLimit) // Membuat grup endpoint pengaturan keamanan akun yang hanya bisa dipakai dengan token JWT, bukan API key
+	{
+		accountRoutes.GET("/mfa", a.MFAController.Status)                               // Endpoint status 2FA
+		accountRoutes.PUT("/password", a.UserController.ChangePassword)                 // Endpoint ganti password dengan password saat ini
+		accountRoutes.PUT("/email", a.UserController.ChangeEmail)                       // Endpoint ganti email dengan password saat ini
+		accountRoutes.POST("/mfa/enroll", a.MFAController.Enroll)                       // Endpoint memulai enrollment 2FA
+		accountRoutes.POST("/mfa/confirm", a.MFAController.Confirm)                     // Endpoint mengaktifkan 2FA dengan kode pertama
+		accountRoutes.POST("/mfa/disable", a.MFAController.Disable)                     // Endpoint mematikan 2FA
+		accountRoutes.GET("/api-keys", a.APIKeyController.List)                         // Endpoint daftar API key milik user
+		accountRoutes.POST("/api-keys", a.APIKeyController.Create)                      // Endpoint membuat API key
+		accountRoutes.DELETE("/api-keys/:id", a.APIKeyController.Revoke)                // Endpoint mencabut API key
+		accountRoutes.GET("/oauth/grants", a.OAuthController.Grants)                    // Endpoint daftar aplikasi yang memiliki akses ke akun user
+		accountRoutes.DELETE("/oauth/grants/:client_id", a.OAuthController.RevokeGrant) // Endpoint mencabut akses aplikasi ke akun user
+		accountRoutes.GET("/sessions", a.SessionController.List)                        // Endpoint daftar perangkat tempat user login
+		accountRoutes.DELETE("/sessions/:id", a.SessionController.Revoke)               // Endpoint mengakhiri sesi di salah satu perangkat
+	}
+
+	bookRoutes := api.Group("books", authenticate, userLimit, requireMFA) // Membuat grup endpoint untuk buku dengan token JWT atau API key
+	{
+		bookRoutes.GET("/", scope(service.ScopeBooksRead), a.BookController.All)                                               // Endpoint untuk mendapatkan daftar buku per halaman
+		bookRoutes.GET("/search", scope(service.ScopeBooksRead), a.BookController.Search)                                      // Endpoint untuk mencari buku
+		bookRoutes.POST("/", scope(service.ScopeBooksWrite), verified(service.ActionBookCreate), a.BookController.Insert)      // Endpoint untuk menyimpan buku baru
+		bookRoutes.GET("/:id", scope(service.ScopeBooksRead), a.BookController.FindByID)                                       // Endpoint untuk mencari buku berdasarkan ID
+		bookRoutes.PUT("/:id", scope(service.ScopeBooksWrite), verified(service.ActionBookUpdate), a.BookController.Update)    // Endpoint untuk mengupdate buku berdasarkan ID
+		bookRoutes.DELETE("/:id", scope(service.ScopeBooksWrite), verified(service.ActionBookDelete), a.BookController.Delete) // Endpoint untuk menghapus buku berdasarkan ID
+	}
+
+	adminRoutes := api.Group("admin", authorize, userLimit, middleware.RequireRole(entity.RoleAdmin), requireMFA) // Membuat grup endpoint khusus admin
+	{
+		adminRoutes.GET("/users", a.AdminController.Users)                              // Endpoint untuk mendapatkan semua user
+		adminRoutes.PUT("/users/:id/role", a.AdminController.SetRole)                   // Endpoint untuk mengubah role user
+		adminRoutes.PUT("/users/:id/suspension", a.AdminController.Suspend)             // Endpoint untuk memblokir atau membuka blokir user
+		adminRoutes.PUT("/users/:id/unlock", a.AdminController.Unlock)                  // Endpoint untuk membuka kunci akun setelah login gagal berulang kali
+		adminRoutes.DELETE("/users/:id/mfa", a.AdminController.ResetMFA)                // Endpoint untuk mematikan 2FA user yang kehilangan authenticator
+		adminRoutes.PUT("/books/:id", a.AdminController.UpdateBook)                     // Endpoint untuk mengubah buku milik siapa pun
+		adminRoutes.DELETE("/books/:id", a.AdminController.DeleteBook)                  // Endpoint untuk menghapus buku milik siapa pun
+		adminRoutes.GET("/oauth/clients", a.OAuthController.Clients)                    // Endpoint untuk mendapatkan semua client OAuth2
+		adminRoutes.POST("/oauth/clients", a.OAuthController.CreateClient)              // Endpoint untuk mendaftarkan client OAuth2
+		adminRoutes.DELETE("/oauth/clients/:client_id", a.OAuthController.DeleteClient) // Endpoint untuk menghapus client OAuth2 beserta semua tokennya
+	}
+
+	return r
+}
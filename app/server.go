@@ -0,0 +1,84 @@
+package app
+
+import (
+	"context"  // Mengimport package context untuk sinyal berhenti dan batas waktu shutdown
+	"errors"   // Mengimport package errors untuk mengenali server yang sudah ditutup
+	"log/slog" // Mengimport package slog untuk mencatat proses start dan shutdown
+	"net/http" // Mengimport package net/http untuk server HTTP
+	"time"     // Mengimport package time untuk durasi
+)
+
+// Server membuat http.Server dengan router aplikasi dan batas waktu dari konfigurasi
+func (a *App) Server() *http.Server {
+	cfg := a.Config.Server
+	return &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           a.Router(),
+		ReadTimeout:       time.Duration(cfg.ReadTimeout),
+		ReadHeaderTimeout: time.Duration(cfg.ReadHeaderTimeout),
+		WriteTimeout:      time.Duration(cfg.WriteTimeout),
+		IdleTimeout:       time.Duration(cfg.IdleTimeout),
+	}
+}
+
+// Serve menjalankan server HTTP sampai ctx dibatalkan, misalnya oleh SIGTERM. Setelah itu
+// readiness langsung gagal, request yang sedang berjalan ditunggu paling lama ShutdownTimeout,
+// lalu Serve kembali sehingga pemanggil bisa menutup koneksi database dengan Close.
+func (a *App) Serve(ctx context.Context) error {
+	srv := a.Server()
+	go a.purgeRevokedTokens(ctx) // Membersihkan daftar token yang dicabut di background, bukan di setiap logout
+	serveErr := make(chan error, 1)
+	go func() {
+		slog.Info("server listening", "addr", srv.Addr)
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr: // Server gagal start, misalnya port sudah dipakai
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down, waiting for in-flight requests", "timeout", a.Config.Server.ShutdownTimeout.String())
+	a.HealthService.Drain() // Probe readiness gagal agar load balancer berhenti mengirim request baru
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(a.Config.Server.ShutdownTimeout))
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil { // Berhenti menerima koneksi baru dan menunggu request yang sedang berjalan
+		return err
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	slog.Info("server stopped")
+	return nil
+}
+
+// purgeRevokedTokens menghapus catatan token dicabut dan catatan access token OAuth2 yang sudah kedaluwarsa
+// setiap revocationPurgeInterval sampai ctx dibatalkan
+func (a *App) purgeRevokedTokens(ctx context.Context) {
+	ticker := time.NewTicker(revocationPurgeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			purged, err := a.RevocationStore.PurgeExpired(ctx, now)
+			if err != nil {
+				slog.Error("failed to purge expired revoked tokens", "error", err)
+				continue
+			}
+			if purged > 0 {
+				slog.Debug("purged expired revoked tokens", "count", purged)
+			}
+			purged, err = a.OAuthRepository.PurgeExpiredAccessTokens(ctx, now)
+			if err != nil {
+				slog.Error("failed to purge expired oauth access tokens", "error", err)
+				continue
+			}
+			if purged > 0 {
+				slog.Debug("purged expired oauth access tokens", "count", purged)
+			}
+		}
+	}
+}
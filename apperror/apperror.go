@@ -0,0 +1,168 @@
+package apperror
+
+import (
+	"errors"   // Mengimport package errors untuk pengecekan rantai error
+	"net/http" // Mengimport package http untuk status code
+	"time"     // Mengimport package time untuk waktu tunggu sebelum mencoba lagi
+)
+
+// Kind adalah jenis error domain yang menentukan bagaimana error ditampilkan ke client
+type Kind string
+
+// Daftar jenis error domain
+const (
+	KindInternal     Kind = "internal"     // KindInternal adalah error yang tidak terduga, misalnya database tidak bisa dihubungi
+	KindInvalid      Kind = "invalid"      // KindInvalid adalah input dari client yang tidak valid
+	KindUnauthorized Kind = "unauthorized" // KindUnauthorized adalah kredensial atau token yang tidak valid
+	KindForbidden    Kind = "forbidden"    // KindForbidden adalah user tidak memiliki izin untuk aksi tersebut
+	KindNotFound     Kind = "not_found"    // KindNotFound adalah data yang dicari tidak ada
+	KindConflict     Kind = "conflict"     // KindConflict adalah data bertabrakan dengan data lain, misalnya email duplikat
+	KindTooMany      Kind = "too_many"     // KindTooMany adalah request terlalu sering, misalnya terkena rate limit atau akun terkunci sementara
+)
+
+// Error adalah error domain yang dikembalikan oleh repository dan service
+type Error struct {
+	Kind    Kind   // Kind adalah jenis error
+	Code    string // Code adalah kode error yang bisa dibaca mesin, default-nya sama dengan Kind
+	Message string // Message adalah pesan error yang aman ditampilkan ke client
+	Err     error  // Err adalah error asli penyebabnya (opsional)
+
+	RetryAfter time.Duration // RetryAfter adalah waktu tunggu sebelum client boleh mencoba lagi (opsional), dikirim sebagai header Retry-After
+}
+
+// Sentinel untuk memeriksa jenis error dengan errors.Is, misalnya errors.Is(err, apperror.ErrNotFound)
+var (
+	ErrInvalid      = &Error{Kind: KindInvalid}
+	ErrUnauthorized = &Error{Kind: KindUnauthorized}
+	ErrForbidden    = &Error{Kind: KindForbidden}
+	ErrNotFound     = &Error{Kind: KindNotFound}
+	ErrConflict     = &Error{Kind: KindConflict}
+	ErrTooMany      = &Error{Kind: KindTooMany}
+)
+
+// Error mengembalikan pesan error
+func (e *Error) Error() string {
+	message := e.Message
+	if message == "" {
+		message = string(e.Kind)
+	}
+	if e.Err != nil {
+		return message + ": " + e.Err.Error()
+	}
+	return message
+}
+
+// Unwrap mengembalikan error penyebab agar bisa diperiksa dengan errors.Is dan errors.As
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is membuat error cocok dengan sentinel yang jenisnya sama (sentinel tidak memiliki Message)
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Message == "" && t.Code == "" && t.Err == nil && t.RetryAfter == 0 && t.Kind == e.Kind
+}
+
+// WithCode mengembalikan salinan error dengan kode error yang lebih spesifik
+func (e *Error) WithCode(code string) *Error {
+	c := *e
+	c.Code = code
+	return &c
+}
+
+// WithRetryAfter mengembalikan salinan error dengan waktu tunggu sebelum client boleh mencoba lagi
+func (e *Error) WithRetryAfter(d time.Duration) *Error {
+	c := *e
+	c.RetryAfter = d
+	return &c
+}
+
+// Internal membuat error untuk kegagalan tak terduga, pesan err tidak ditampilkan ke client
+func Internal(message string, err error) *Error {
+	return &Error{Kind: KindInternal, Message: message, Err: err}
+}
+
+// Wrap membuat error domain dengan jenis tertentu yang membungkus error penyebabnya
+func Wrap(kind Kind, message string, err error) *Error {
+	return &Error{Kind: kind, Message: message, Err: err}
+}
+
+// Invalid membuat error untuk input yang tidak valid
+func Invalid(message string) *Error {
+	return &Error{Kind: KindInvalid, Message: message}
+}
+
+// Unauthorized membuat error untuk kredensial atau token yang tidak valid
+func Unauthorized(message string) *Error {
+	return &Error{Kind: KindUnauthorized, Message: message}
+}
+
+// Forbidden membuat error untuk aksi yang tidak diizinkan
+func Forbidden(message string) *Error {
+	return &Error{Kind: KindForbidden, Message: message}
+}
+
+// NotFound membuat error untuk data yang tidak ditemukan
+func NotFound(message string) *Error {
+	return &Error{Kind: KindNotFound, Message: message}
+}
+
+// Conflict membuat error untuk data yang bertabrakan dengan data lain
+func Conflict(message string) *Error {
+	return &Error{Kind: KindConflict, Message: message}
+}
+
+// TooMany membuat error untuk request yang terlalu sering
+func TooMany(message string) *Error {
+	return &Error{Kind: KindTooMany, Message: message}
+}
+
+// KindOf mengembalikan jenis error domain dari err, atau KindInternal jika err bukan error domain
+func KindOf(err error) Kind {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Kind
+	}
+	return KindInternal
+}
+
+// CodeOf mengembalikan kode error yang bisa dibaca mesin dari err
+func CodeOf(err error) string {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		if appErr.Code != "" {
+			return appErr.Code
+		}
+		return string(appErr.Kind)
+	}
+	return string(KindInternal)
+}
+
+// RetryAfterOf mengembalikan waktu tunggu dari err, atau 0 jika tidak ada
+func RetryAfterOf(err error) time.Duration {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.RetryAfter
+	}
+	return 0
+}
+
+// HTTPStatus mengembalikan status HTTP yang sesuai dengan jenis error
+func HTTPStatus(err error) int {
+	switch KindOf(err) {
+	case KindInvalid:
+		return http.StatusBadRequest
+	case KindUnauthorized:
+		return http.StatusUnauthorized
+	case KindForbidden:
+		return http.StatusForbidden
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindConflict:
+		return http.StatusConflict
+	case KindTooMany:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
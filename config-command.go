@@ -0,0 +1,16 @@
+package main
+
+import (
+	"fmt" // Mengimport package fmt untuk mencetak konfigurasi
+)
+
+// runConfigCheck menjalankan perintah "config check": konfigurasi dibaca dan divalidasi
+// tanpa membuka koneksi database, lalu dicetak tanpa nilai rahasia
+func runConfigCheck(args []string) error {
+	cfg, err := loadConfig("config check", nil, args, false)
+	if err != nil {
+		return err
+	}
+	fmt.Print(cfg.Redacted())
+	return nil
+}
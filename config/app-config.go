@@ -0,0 +1,878 @@
+package config
+
+import (
+	"errors"        // Import package errors untuk menggabungkan error validasi
+	"flag"          // Import package flag untuk membaca argumen command-line
+	"fmt"           // Import package fmt untuk formatting pesan
+	"io/fs"         // Import package fs untuk mengenali file yang tidak ada
+	"log/slog"      // Import package slog untuk mencatat konfigurasi efektif
+	"net/url"       // Import package url untuk memvalidasi URL
+	"os"            // Import package os untuk membaca variabel lingkungan dan file
+	"path/filepath" // Import package filepath untuk membaca ekstensi file konfigurasi
+	"regexp"        // Import package regexp untuk memvalidasi nama provider SSO
+	"strconv"       // Import package strconv untuk konversi angka
+	"strings"       // Import package strings untuk manipulasi string
+	"time"          // Import package time untuk durasi
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity"   // Import package entity untuk memvalidasi daftar role
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/logging"  // Import package logging untuk memvalidasi level log
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/password" // Import package password untuk memvalidasi algoritma hash
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service"  // Import package service untuk memvalidasi kebijakan user yang belum terverifikasi
+	"github.com/joho/godotenv"                                // Import library untuk mengelola variabel lingkungan dari file .env
+	"github.com/pelletier/go-toml/v2"                         // Import library untuk membaca file konfigurasi TOML
+	"golang.org/x/crypto/bcrypt"                              // Import package bcrypt untuk memvalidasi cost bcrypt
+	"gopkg.in/yaml.v3"                                        // Import library untuk membaca file konfigurasi YAML
+)
+
+// Config adalah seluruh konfigurasi aplikasi.
+// Urutan prioritas: flag command-line > variabel lingkungan > file konfigurasi > nilai default.
+type Config struct {
+	args []string // Argumen posisi yang tersisa setelah flag dibaca
+
+	Server     ServerConfig     `yaml:"server" toml:"server"`
+	Database   DatabaseConfig   `yaml:"database" toml:"database"`
+	JWT        JWTConfig        `yaml:"jwt" toml:"jwt"`
+	Search     SearchConfig     `yaml:"search" toml:"search"`
+	Revocation RevocationConfig `yaml:"revocation" toml:"revocation"`
+	Log        LogConfig        `yaml:"log" toml:"log"`
+	Tracing    TracingConfig    `yaml:"tracing" toml:"tracing"`
+	RateLimit  RateLimitConfig  `yaml:"rate_limit" toml:"rate_limit"`
+	Lockout    LockoutConfig    `yaml:"lockout" toml:"lockout"`
+	Password   PasswordConfig   `yaml:"password" toml:"password"`
+	Mail       MailConfig       `yaml:"mail" toml:"mail"`
+	Reset      ResetConfig      `yaml:"password_reset" toml:"password_reset"`
+	Verify     VerifyConfig     `yaml:"email_verification" toml:"email_verification"`
+	MFA        MFAConfig        `yaml:"mfa" toml:"mfa"`
+	SSO        SSOConfig        `yaml:"sso" toml:"sso"`
+	OAuth      OAuthConfig      `yaml:"oauth" toml:"oauth"`
+}
+
+// ServerConfig adalah konfigurasi server HTTP
+type ServerConfig struct {
+	Addr              string   `yaml:"addr" toml:"addr"`                               // Alamat yang didengarkan server, misalnya ":9090"
+	ReadTimeout       Duration `yaml:"read_timeout" toml:"read_timeout"`               // Batas waktu membaca seluruh request termasuk body, 0 berarti tanpa batas
+	ReadHeaderTimeout Duration `yaml:"read_header_timeout" toml:"read_header_timeout"` // Batas waktu membaca header request
+	WriteTimeout      Duration `yaml:"write_timeout" toml:"write_timeout"`             // Batas waktu menulis respons, 0 berarti tanpa batas
+	IdleTimeout       Duration `yaml:"idle_timeout" toml:"idle_timeout"`               // Batas waktu koneksi keep-alive menganggur
+	ShutdownTimeout   Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout"`       // Batas waktu menunggu request yang sedang berjalan saat server dihentikan
+	TrustedProxies    []string `yaml:"trusted_proxies" toml:"trusted_proxies"`         // IP atau CIDR proxy yang header X-Forwarded-For-nya dipercaya, kosong berarti IP koneksi langsung yang dipakai
+}
+
+// Driver database yang didukung
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// DatabaseConfig adalah konfigurasi koneksi database
+type DatabaseConfig struct {
+	Driver   string `yaml:"driver" toml:"driver"`     // Driver database: mysql, postgres, atau sqlite
+	DSN      string `yaml:"dsn" toml:"dsn"`           // DSN lengkap, jika diisi menggantikan Host, Port, User, Password, Name, dan Params
+	Host     string `yaml:"host" toml:"host"`         // Host database
+	Port     int    `yaml:"port" toml:"port"`         // Port database, default sesuai driver
+	User     string `yaml:"user" toml:"user"`         // User database
+	Password string `yaml:"password" toml:"password"` // Password database, bisa dibaca dari file lewat DB_PASS_FILE
+	Name     string `yaml:"name" toml:"name"`         // Nama database, untuk sqlite berupa path file atau ":memory:"
+	Params   string `yaml:"params" toml:"params"`     // Opsi tambahan DSN, default sesuai driver
+
+	AutoMigrate bool `yaml:"auto_migrate" toml:"auto_migrate"` // Menjalankan migrasi yang belum diterapkan saat server start
+}
+
+// JWTConfig adalah konfigurasi penandatanganan token
+type JWTConfig struct {
+	Secret          string   `yaml:"secret" toml:"secret"`                       // Secret HS256, dipakai jika PrivateKeyFile kosong
+	PrivateKeyFile  string   `yaml:"private_key_file" toml:"private_key_file"`   // File PEM kunci privat RSA atau Ed25519
+	PublicKeyFiles  []string `yaml:"public_key_files" toml:"public_key_files"`   // File PEM kunci publik lama yang masih diterima selama masa rotasi
+	AccessTokenTTL  Duration `yaml:"access_token_ttl" toml:"access_token_ttl"`   // Masa berlaku access token
+	RefreshTokenTTL Duration `yaml:"refresh_token_ttl" toml:"refresh_token_ttl"` // Masa berlaku refresh token
+}
+
+// SearchConfig adalah konfigurasi index pencarian buku
+type SearchConfig struct {
+	Backend   string `yaml:"backend" toml:"backend"`       // "auto", "mysql" (FULLTEXT), "sql" (LIKE, semua database), atau "bleve"
+	IndexPath string `yaml:"index_path" toml:"index_path"` // Lokasi index bleve
+}
+
+// RevocationConfig adalah konfigurasi penyimpanan token yang dicabut
+type RevocationConfig struct {
+	Store string `yaml:"store" toml:"store"` // "database" atau "memory"
+}
+
+// LogConfig adalah konfigurasi log
+type LogConfig struct {
+	Level  string `yaml:"level" toml:"level"`   // Level minimum: debug, info, warn, atau error
+	Format string `yaml:"format" toml:"format"` // "json" untuk agregator log atau "text" untuk development
+}
+
+// TracingConfig adalah konfigurasi OpenTelemetry tracing
+type TracingConfig struct {
+	Exporter     string `yaml:"exporter" toml:"exporter"`           // "none", "stdout", atau "otlp"
+	OTLPEndpoint string `yaml:"otlp_endpoint" toml:"otlp_endpoint"` // Alamat collector OTLP/HTTP (host:port), kosong berarti OTEL_EXPORTER_OTLP_ENDPOINT atau localhost:4318
+	OTLPInsecure bool   `yaml:"otlp_insecure" toml:"otlp_insecure"` // Mengirim span ke collector tanpa TLS
+	ServiceName  string `yaml:"service_name" toml:"service_name"`   // Nama service pada span
+}
+
+// RateLimitConfig adalah konfigurasi rate limit token bucket. Nilai per menit 0 mematikan limit tersebut.
+type RateLimitConfig struct {
+	Store          string `yaml:"store" toml:"store"`                       // "memory", limit hanya berlaku per instance aplikasi
+	IPPerMinute    int    `yaml:"ip_per_minute" toml:"ip_per_minute"`       // Request per menit untuk setiap IP pada semua endpoint
+	IPBurst        int    `yaml:"ip_burst" toml:"ip_burst"`                 // Jumlah request bersamaan yang boleh dilakukan setiap IP
+	UserPerMinute  int    `yaml:"user_per_minute" toml:"user_per_minute"`   // Request per menit untuk setiap user yang login
+	UserBurst      int    `yaml:"user_burst" toml:"user_burst"`             // Jumlah request bersamaan yang boleh dilakukan setiap user
+	LoginPerMinute int    `yaml:"login_per_minute" toml:"login_per_minute"` // Percobaan login per menit untuk setiap IP
+	LoginBurst     int    `yaml:"login_burst" toml:"login_burst"`           // Jumlah percobaan login bersamaan untuk setiap IP
+}
+
+// LockoutConfig adalah konfigurasi penguncian akun setelah login gagal berulang kali
+type LockoutConfig struct {
+	Threshold int      `yaml:"threshold" toml:"threshold"`   // Jumlah login gagal berturut-turut sebelum akun dikunci, 0 mematikan penguncian
+	BaseDelay Duration `yaml:"base_delay" toml:"base_delay"` // Lama penguncian pertama, berlipat dua untuk setiap kegagalan berikutnya
+	MaxDelay  Duration `yaml:"max_delay" toml:"max_delay"`   // Lama penguncian maksimum
+}
+
+// PasswordConfig adalah konfigurasi hashing password dan kebijakan password baru
+type PasswordConfig struct {
+	Algorithm         string `yaml:"algorithm" toml:"algorithm"`                   // Algoritma untuk hash baru: "argon2id" atau "bcrypt"; hash dengan algoritma lain diganti saat login berikutnya
+	BcryptCost        int    `yaml:"bcrypt_cost" toml:"bcrypt_cost"`               // Cost bcrypt
+	Argon2Memory      int    `yaml:"argon2_memory" toml:"argon2_memory"`           // Memori argon2id dalam KiB
+	Argon2Iterations  int    `yaml:"argon2_iterations" toml:"argon2_iterations"`   // Jumlah iterasi argon2id
+	Argon2Parallelism int    `yaml:"argon2_parallelism" toml:"argon2_parallelism"` // Jumlah thread argon2id
+	MinLength         int    `yaml:"min_length" toml:"min_length"`                 // Panjang minimum password baru dalam karakter
+	MaxLength         int    `yaml:"max_length" toml:"max_length"`                 // Panjang maksimum password baru dalam karakter
+	MinClasses        int    `yaml:"min_classes" toml:"min_classes"`               // Jumlah minimum jenis karakter (huruf kecil, huruf besar, angka, simbol)
+	BreachedList      string `yaml:"breached_list" toml:"breached_list"`           // File berisi password bocor (satu per baris) yang ditolak selain daftar bawaan
+}
+
+// MailConfig adalah konfigurasi pengiriman email
+type MailConfig struct {
+	Driver       string `yaml:"driver" toml:"driver"`               // "smtp", "file" (menyimpan .eml), atau "log" (mencatat email di log)
+	From         string `yaml:"from" toml:"from"`                   // Alamat pengirim
+	Dir          string `yaml:"dir" toml:"dir"`                     // Folder tempat email disimpan untuk driver file
+	SMTPHost     string `yaml:"smtp_host" toml:"smtp_host"`         // Host server SMTP
+	SMTPPort     int    `yaml:"smtp_port" toml:"smtp_port"`         // Port server SMTP
+	SMTPUser     string `yaml:"smtp_user" toml:"smtp_user"`         // User SMTP, kosong berarti tanpa autentikasi
+	SMTPPassword string `yaml:"smtp_password" toml:"smtp_password"` // Password SMTP, bisa dibaca dari file lewat SMTP_PASS_FILE
+}
+
+// ResetConfig adalah konfigurasi reset password
+type ResetConfig struct {
+	TTL Duration `yaml:"ttl" toml:"ttl"` // Masa berlaku token reset password
+	URL string   `yaml:"url" toml:"url"` // Halaman reset password di frontend, token ditambahkan sebagai parameter "token"; kosong berarti email hanya berisi token
+}
+
+// VerifyConfig adalah konfigurasi verifikasi email
+type VerifyConfig struct {
+	Secret string   `yaml:"secret" toml:"secret"` // Secret untuk menandatangani link verifikasi, kosong berarti memakai JWT_SECRET
+	TTL    Duration `yaml:"ttl" toml:"ttl"`       // Masa berlaku link verifikasi
+	URL    string   `yaml:"url" toml:"url"`       // URL yang menerima token sebagai parameter "token", endpoint API atau halaman frontend
+	Deny   []string `yaml:"deny" toml:"deny"`     // Aksi yang tidak boleh dilakukan user yang belum memverifikasi email, misalnya "book:create"
+}
+
+// MFAConfig adalah konfigurasi autentikasi dua faktor (TOTP)
+type MFAConfig struct {
+	Issuer        string   `yaml:"issuer" toml:"issuer"`                 // Nama aplikasi yang ditampilkan di aplikasi authenticator
+	PendingTTL    Duration `yaml:"pending_ttl" toml:"pending_ttl"`       // Masa berlaku token login 2FA antara password dan kode
+	Secret        string   `yaml:"secret" toml:"secret"`                 // Secret untuk menandatangani token login 2FA, kosong berarti memakai JWT_SECRET
+	RequiredRoles []string `yaml:"required_roles" toml:"required_roles"` // Role yang wajib mengaktifkan 2FA sebelum memakai endpoint buku dan admin
+}
+
+// SSOConfig adalah konfigurasi login dengan identity provider luar (Google, GitHub, dan SSO OpenID Connect perusahaan).
+// Provider hanya aktif jika client ID-nya diisi.
+type SSOConfig struct {
+	CallbackURL        string   `yaml:"callback_url" toml:"callback_url"`                 // Awal URL callback, URL yang didaftarkan di provider adalah <CallbackURL>/<nama provider>/callback
+	StateSecret        string   `yaml:"state_secret" toml:"state_secret"`                 // Secret untuk menandatangani cookie state, kosong berarti memakai JWT_SECRET
+	StateTTL           Duration `yaml:"state_ttl" toml:"state_ttl"`                       // Batas waktu antara memulai login dan kembali dari provider
+	AllowSignup        bool     `yaml:"allow_signup" toml:"allow_signup"`                 // Apakah identitas yang belum terhubung boleh membuat user baru
+	GoogleClientID     string   `yaml:"google_client_id" toml:"google_client_id"`         // Client ID OAuth Google, kosong berarti login Google tidak aktif
+	GoogleClientSecret string   `yaml:"google_client_secret" toml:"google_client_secret"` // Client secret OAuth Google
+	GitHubClientID     string   `yaml:"github_client_id" toml:"github_client_id"`         // Client ID OAuth App GitHub, kosong berarti login GitHub tidak aktif
+	GitHubClientSecret string   `yaml:"github_client_secret" toml:"github_client_secret"` // Client secret OAuth App GitHub
+	OIDCName           string   `yaml:"oidc_name" toml:"oidc_name"`                       // Nama provider OIDC generik di URL, misalnya "sso" atau "okta"
+	OIDCIssuer         string   `yaml:"oidc_issuer" toml:"oidc_issuer"`                   // URL issuer provider OIDC generik, kosong berarti tidak aktif
+	OIDCClientID       string   `yaml:"oidc_client_id" toml:"oidc_client_id"`             // Client ID di provider OIDC generik
+	OIDCClientSecret   string   `yaml:"oidc_client_secret" toml:"oidc_client_secret"`     // Client secret di provider OIDC generik, boleh kosong untuk public client
+	OIDCScopes         []string `yaml:"oidc_scopes" toml:"oidc_scopes"`                   // Scope tambahan selain "openid" untuk provider OIDC generik
+	OIDCTrustEmail     bool     `yaml:"oidc_trust_email" toml:"oidc_trust_email"`         // Anggap email dari provider OIDC generik terverifikasi walaupun claim email_verified tidak dikirim
+}
+
+// OAuthConfig adalah konfigurasi authorization server OAuth2 untuk aplikasi pihak ketiga
+type OAuthConfig struct {
+	CodeTTL         Duration `yaml:"code_ttl" toml:"code_ttl"`                   // Masa berlaku authorization code, sebaiknya tidak lebih dari 10 menit (RFC 6749 bagian 4.1.2)
+	RefreshTokenTTL Duration `yaml:"refresh_token_ttl" toml:"refresh_token_ttl"` // Masa berlaku refresh token yang diterbitkan untuk client
+}
+
+// Duration adalah time.Duration yang ditulis sebagai teks (misalnya "15m") di file konfigurasi
+type Duration time.Duration
+
+// UnmarshalText membaca durasi dari teks seperti "15m" atau "720h"
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// String mengembalikan durasi dalam format teks
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// Default mengembalikan konfigurasi dengan nilai default
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Addr:              ":9090",
+			ReadTimeout:       Duration(30 * time.Second),
+			ReadHeaderTimeout: Duration(10 * time.Second),
+			WriteTimeout:      Duration(30 * time.Second),
+			IdleTimeout:       Duration(2 * time.Minute),
+			ShutdownTimeout:   Duration(30 * time.Second),
+		},
+		Database: DatabaseConfig{
+			Driver:      DriverMySQL,
+			Host:        "localhost",
+			AutoMigrate: true,
+		},
+		JWT: JWTConfig{
+			AccessTokenTTL:  Duration(15 * time.Minute),
+			RefreshTokenTTL: Duration(30 * 24 * time.Hour), // Default 30 hari
+		},
+		Search:     SearchConfig{Backend: "auto", IndexPath: "data/books.bleve"},
+		Revocation: RevocationConfig{Store: "database"},
+		Log:        LogConfig{Level: "info", Format: "json"},
+		Tracing:    TracingConfig{Exporter: "none", ServiceName: "bookstore"},
+		RateLimit: RateLimitConfig{
+			Store:          "memory",
+			IPPerMinute:    300,
+			IPBurst:        60,
+			UserPerMinute:  600,
+			UserBurst:      100,
+			LoginPerMinute: 10,
+			LoginBurst:     5,
+		},
+		Lockout: LockoutConfig{
+			Threshold: 5,
+			BaseDelay: Duration(time.Minute),
+			MaxDelay:  Duration(time.Hour),
+		},
+		Password: PasswordConfig{
+			Algorithm:         password.AlgorithmArgon2id,
+			BcryptCost:        12,
+			Argon2Memory:      19 * 1024, // Rekomendasi OWASP: 19 MiB, 2 iterasi, 1 thread
+			Argon2Iterations:  2,
+			Argon2Parallelism: 1,
+			MinLength:         10,
+			MaxLength:         128,
+			MinClasses:        2,
+		},
+		Mail:  MailConfig{Driver: "log", From: "no-reply@localhost", Dir: "data/mail", SMTPPort: 587},
+		Reset: ResetConfig{TTL: Duration(time.Hour)},
+		Verify: VerifyConfig{
+			TTL:  Duration(48 * time.Hour),
+			URL:  "http://localhost:9090/api/auth/verify-email",
+			Deny: []string{"book:create", "book:update", "book:delete"},
+		},
+		MFA: MFAConfig{
+			Issuer:        "Bookstore",
+			PendingTTL:    Duration(5 * time.Minute),
+			RequiredRoles: []string{entity.RoleAdmin, entity.RoleEditor},
+		},
+		SSO: SSOConfig{
+			CallbackURL: "http://localhost:9090/api/auth/sso",
+			StateTTL:    Duration(10 * time.Minute),
+			AllowSignup: true,
+			OIDCName:    "sso",
+			OIDCScopes:  []string{"email", "profile"},
+		},
+		OAuth: OAuthConfig{
+			CodeTTL:         Duration(5 * time.Minute),
+			RefreshTokenTTL: Duration(30 * 24 * time.Hour), // Default 30 hari
+		},
+	}
+}
+
+// setting menghubungkan satu nilai konfigurasi dengan variabel lingkungan dan flag-nya
+type setting struct {
+	env    string             // Nama variabel lingkungan, nama flag diturunkan dari sini
+	usage  string             // Keterangan untuk -help
+	secret bool               // Nilai rahasia disamarkan saat dicetak dan bisa dibaca dari file lewat <env>_FILE
+	set    func(string) error // Mengisi nilai dari teks
+	get    func() string      // Mengambil nilai sebagai teks
+}
+
+// settings mengembalikan daftar semua nilai konfigurasi yang bisa diatur dari luar
+func (c *Config) settings() []setting {
+	return []setting{
+		stringSetting("HTTP_ADDR", "address the HTTP server listens on", &c.Server.Addr),
+		durationSetting("HTTP_READ_TIMEOUT", "maximum duration for reading a whole request, 0 disables it", &c.Server.ReadTimeout),
+		durationSetting("HTTP_READ_HEADER_TIMEOUT", "maximum duration for reading request headers", &c.Server.ReadHeaderTimeout),
+		durationSetting("HTTP_WRITE_TIMEOUT", "maximum duration for writing a response, 0 disables it", &c.Server.WriteTimeout),
+		durationSetting("HTTP_IDLE_TIMEOUT", "maximum duration an idle keep-alive connection is kept open", &c.Server.IdleTimeout),
+		durationSetting("HTTP_SHUTDOWN_TIMEOUT", "maximum duration to wait for in-flight requests on shutdown", &c.Server.ShutdownTimeout),
+		listSetting("HTTP_TRUSTED_PROXIES", "comma-separated IPs or CIDRs of proxies allowed to set X-Forwarded-For", &c.Server.TrustedProxies),
+		stringSetting("DB_DRIVER", "database driver: mysql, postgres or sqlite", &c.Database.Driver),
+		secretSetting("DB_DSN", "full database DSN, overrides the other DB_* settings", &c.Database.DSN),
+		stringSetting("DB_HOST", "database host", &c.Database.Host),
+		intSetting("DB_PORT", "database port", &c.Database.Port),
+		stringSetting("DB_USER", "database user", &c.Database.User),
+		secretSetting("DB_PASS", "database password", &c.Database.Password),
+		stringSetting("DB_NAME", "database name, or the file path (or :memory:) for sqlite", &c.Database.Name),
+		stringSetting("DB_PARAMS", "extra DSN parameters", &c.Database.Params),
+		boolSetting("DB_AUTO_MIGRATE", "apply pending migrations when the server starts", &c.Database.AutoMigrate),
+		secretSetting("JWT_SECRET", "HS256 signing secret, used when no private key file is set", &c.JWT.Secret),
+		stringSetting("JWT_PRIVATE_KEY_FILE", "PEM file with the RSA or Ed25519 signing key", &c.JWT.PrivateKeyFile),
+		listSetting("JWT_PUBLIC_KEY_FILES", "comma-separated PEM files with previous public keys", &c.JWT.PublicKeyFiles),
+		durationSetting("ACCESS_TOKEN_TTL", "access token lifetime", &c.JWT.AccessTokenTTL),
+		durationSetting("REFRESH_TOKEN_TTL", "refresh token lifetime", &c.JWT.RefreshTokenTTL),
+		stringSetting("SEARCH_BACKEND", "book search backend: auto, mysql, sql or bleve", &c.Search.Backend),
+		stringSetting("SEARCH_INDEX_PATH", "directory of the bleve index", &c.Search.IndexPath),
+		stringSetting("REVOCATION_STORE", "revoked token store: database or memory", &c.Revocation.Store),
+		stringSetting("LOG_LEVEL", "minimum log level: debug, info, warn or error", &c.Log.Level),
+		stringSetting("LOG_FORMAT", "log format: json or text", &c.Log.Format),
+		stringSetting("TRACING_EXPORTER", "trace exporter: none, stdout or otlp", &c.Tracing.Exporter),
+		stringSetting("TRACING_OTLP_ENDPOINT", "host:port of the OTLP/HTTP collector", &c.Tracing.OTLPEndpoint),
+		boolSetting("TRACING_OTLP_INSECURE", "send spans to the OTLP collector without TLS", &c.Tracing.OTLPInsecure),
+		stringSetting("TRACING_SERVICE_NAME", "service name reported on spans", &c.Tracing.ServiceName),
+		stringSetting("RATE_LIMIT_STORE", "rate limit bucket store: memory", &c.RateLimit.Store),
+		intSetting("RATE_LIMIT_IP_PER_MINUTE", "requests per minute per client IP, 0 disables it", &c.RateLimit.IPPerMinute),
+		intSetting("RATE_LIMIT_IP_BURST", "request burst per client IP", &c.RateLimit.IPBurst),
+		intSetting("RATE_LIMIT_USER_PER_MINUTE", "requests per minute per authenticated user, 0 disables it", &c.RateLimit.UserPerMinute),
+		intSetting("RATE_LIMIT_USER_BURST", "request burst per authenticated user", &c.RateLimit.UserBurst),
+		intSetting("RATE_LIMIT_LOGIN_PER_MINUTE", "login attempts per minute per client IP, 0 disables it", &c.RateLimit.LoginPerMinute),
+		intSetting("RATE_LIMIT_LOGIN_BURST", "login attempt burst per client IP", &c.RateLimit.LoginBurst),
+		intSetting("LOGIN_LOCKOUT_THRESHOLD", "consecutive failed logins before an account is locked, 0 disables it", &c.Lockout.Threshold),
+		durationSetting("LOGIN_LOCKOUT_BASE_DELAY", "duration of the first lockout, doubled on every further failure", &c.Lockout.BaseDelay),
+		durationSetting("LOGIN_LOCKOUT_MAX_DELAY", "maximum lockout duration", &c.Lockout.MaxDelay),
+		stringSetting("PASSWORD_HASH_ALGORITHM", "algorithm for new password hashes: argon2id or bcrypt, other hashes are replaced on the next login", &c.Password.Algorithm),
+		intSetting("PASSWORD_BCRYPT_COST", "bcrypt cost", &c.Password.BcryptCost),
+		intSetting("PASSWORD_ARGON2_MEMORY", "argon2id memory in KiB", &c.Password.Argon2Memory),
+		intSetting("PASSWORD_ARGON2_ITERATIONS", "argon2id iterations", &c.Password.Argon2Iterations),
+		intSetting("PASSWORD_ARGON2_PARALLELISM", "argon2id threads", &c.Password.Argon2Parallelism),
+		intSetting("PASSWORD_MIN_LENGTH", "minimum length of new passwords in characters", &c.Password.MinLength),
+		intSetting("PASSWORD_MAX_LENGTH", "maximum length of new passwords in characters", &c.Password.MaxLength),
+		intSetting("PASSWORD_MIN_CHARACTER_CLASSES", "minimum number of character classes (lowercase, uppercase, digits, symbols) in new passwords", &c.Password.MinClasses),
+		stringSetting("PASSWORD_BREACHED_LIST", "file of breached passwords, one per line, rejected in addition to the built-in list", &c.Password.BreachedList),
+		stringSetting("MAIL_DRIVER", "mail driver: smtp, file or log", &c.Mail.Driver),
+		stringSetting("MAIL_FROM", "sender address of outgoing mail", &c.Mail.From),
+		stringSetting("MAIL_DIR", "directory where the file mail driver writes .eml files", &c.Mail.Dir),
+		stringSetting("SMTP_HOST", "SMTP server host", &c.Mail.SMTPHost),
+		intSetting("SMTP_PORT", "SMTP server port", &c.Mail.SMTPPort),
+		stringSetting("SMTP_USER", "SMTP user, empty disables authentication", &c.Mail.SMTPUser),
+		secretSetting("SMTP_PASS", "SMTP password", &c.Mail.SMTPPassword),
+		durationSetting("PASSWORD_RESET_TTL", "lifetime of password reset tokens", &c.Reset.TTL),
+		stringSetting("PASSWORD_RESET_URL", "frontend page that receives the reset token as the token query parameter", &c.Reset.URL),
+		secretSetting("EMAIL_VERIFICATION_SECRET", "secret signing email verification links, defaults to JWT_SECRET", &c.Verify.Secret),
+		durationSetting("EMAIL_VERIFICATION_TTL", "lifetime of email verification links", &c.Verify.TTL),
+		stringSetting("EMAIL_VERIFICATION_URL", "URL that receives the verification token as the token query parameter", &c.Verify.URL),
+		listSetting("UNVERIFIED_DENY", "comma-separated actions unverified users may not perform: "+strings.Join(service.KnownActions(), ", "), &c.Verify.Deny),
+		stringSetting("MFA_ISSUER", "issuer name shown in authenticator apps", &c.MFA.Issuer),
+		durationSetting("MFA_PENDING_TTL", "lifetime of the token exchanged for full tokens after a correct two-factor code", &c.MFA.PendingTTL),
+		secretSetting("MFA_TOKEN_SECRET", "secret signing two-factor login tokens, defaults to JWT_SECRET", &c.MFA.Secret),
+		listSetting("MFA_REQUIRED_ROLES", "comma-separated roles that must enable two-factor authentication before using book and admin endpoints", &c.MFA.RequiredRoles),
+		stringSetting("SSO_CALLBACK_URL", "base URL of the SSO callbacks, providers redirect to <base>/<provider>/callback", &c.SSO.CallbackURL),
+		secretSetting("SSO_STATE_SECRET", "secret signing the SSO state cookie, defaults to JWT_SECRET", &c.SSO.StateSecret),
+		durationSetting("SSO_STATE_TTL", "time allowed between starting an SSO login and returning from the provider", &c.SSO.StateTTL),
+		boolSetting("SSO_ALLOW_SIGNUP", "create a new account when an SSO identity matches no existing user", &c.SSO.AllowSignup),
+		stringSetting("GOOGLE_CLIENT_ID", "Google OAuth client ID, empty disables Google login", &c.SSO.GoogleClientID),
+		secretSetting("GOOGLE_CLIENT_SECRET", "Google OAuth client secret", &c.SSO.GoogleClientSecret),
+		stringSetting("GITHUB_CLIENT_ID", "GitHub OAuth app client ID, empty disables GitHub login", &c.SSO.GitHubClientID),
+		secretSetting("GITHUB_CLIENT_SECRET", "GitHub OAuth app client secret", &c.SSO.GitHubClientSecret),
+		stringSetting("OIDC_NAME", "provider name of the generic OpenID Connect login in URLs", &c.SSO.OIDCName),
+		stringSetting("OIDC_ISSUER", "issuer URL of the generic OpenID Connect provider, empty disables it", &c.SSO.OIDCIssuer),
+		stringSetting("OIDC_CLIENT_ID", "client ID at the generic OpenID Connect provider", &c.SSO.OIDCClientID),
+		secretSetting("OIDC_CLIENT_SECRET", "client secret at the generic OpenID Connect provider, empty for public clients", &c.SSO.OIDCClientSecret),
+		listSetting("OIDC_SCOPES", "comma-separated scopes requested from the generic OpenID Connect provider besides openid", &c.SSO.OIDCScopes),
+		boolSetting("OIDC_TRUST_EMAIL", "treat emails from the generic OpenID Connect provider as verified when it sends no email_verified claim", &c.SSO.OIDCTrustEmail),
+		durationSetting("OAUTH_CODE_TTL", "lifetime of OAuth2 authorization codes", &c.OAuth.CodeTTL),
+		durationSetting("OAUTH_REFRESH_TOKEN_TTL", "lifetime of refresh tokens issued to OAuth2 clients", &c.OAuth.RefreshTokenTTL),
+	}
+}
+
+// stringSetting membuat setting untuk nilai teks
+func stringSetting(env, usage string, target *string) setting {
+	return setting{
+		env:   env,
+		usage: usage,
+		set:   func(v string) error { *target = v; return nil },
+		get:   func() string { return *target },
+	}
+}
+
+// secretSetting membuat setting untuk nilai teks rahasia
+func secretSetting(env, usage string, target *string) setting {
+	s := stringSetting(env, usage, target)
+	s.secret = true
+	return s
+}
+
+// intSetting membuat setting untuk nilai angka
+func intSetting(env, usage string, target *int) setting {
+	return setting{
+		env:   env,
+		usage: usage,
+		set: func(v string) error {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return err
+			}
+			*target = n
+			return nil
+		},
+		get: func() string { return strconv.Itoa(*target) },
+	}
+}
+
+// boolSetting membuat setting untuk nilai true/false
+func boolSetting(env, usage string, target *bool) setting {
+	return setting{
+		env:   env,
+		usage: usage,
+		set: func(v string) error {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return err
+			}
+			*target = b
+			return nil
+		},
+		get: func() string { return strconv.FormatBool(*target) },
+	}
+}
+
+// durationSetting membuat setting untuk durasi
+func durationSetting(env, usage string, target *Duration) setting {
+	return setting{
+		env:   env,
+		usage: usage,
+		set:   func(v string) error { return target.UnmarshalText([]byte(v)) },
+		get:   func() string { return target.String() },
+	}
+}
+
+// listSetting membuat setting untuk daftar yang dipisah koma
+func listSetting(env, usage string, target *[]string) setting {
+	return setting{
+		env:   env,
+		usage: usage,
+		set: func(v string) error {
+			*target = nil
+			for _, item := range strings.Split(v, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					*target = append(*target, item)
+				}
+			}
+			return nil
+		},
+		get: func() string { return strings.Join(*target, ",") },
+	}
+}
+
+// flagName mengubah nama variabel lingkungan menjadi nama flag, misalnya DB_HOST menjadi db-host
+func flagName(env string) string {
+	return strings.ToLower(strings.ReplaceAll(env, "_", "-"))
+}
+
+// Load membaca konfigurasi dari nilai default, file konfigurasi (flag -config atau CONFIG_FILE),
+// file .env jika ada, variabel lingkungan, lalu flag command-line pada args.
+func Load(args []string) (*Config, error) {
+	return LoadFlags(flag.NewFlagSet("bookstore", flag.ContinueOnError), args)
+}
+
+// LoadFlags sama dengan Load tetapi memakai flags yang sudah berisi flag milik subcommand,
+// misalnya "--admin" pada "user create". Flag konfigurasi ditambahkan ke flags yang sama.
+func LoadFlags(flags *flag.FlagSet, args []string) (*Config, error) {
+	cfg := Default()
+	settings := cfg.settings()
+
+	// Flag dibaca lebih dulu untuk mengetahui file konfigurasi, tetapi nilainya baru diterapkan paling akhir
+	configFile := flags.String("config", os.Getenv("CONFIG_FILE"), "YAML or TOML configuration file")
+	flagValues := map[string]string{}
+	for _, s := range settings {
+		env := s.env
+		flags.Func(flagName(env), s.usage+" (env "+env+")", func(v string) error {
+			flagValues[env] = v
+			return nil
+		})
+	}
+	for { // Flag boleh ditulis sebelum maupun sesudah argumen posisi, misalnya "migrate up -db-driver sqlite"
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		if flags.NArg() == 0 {
+			break
+		}
+		cfg.args = append(cfg.args, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+
+	if *configFile != "" {
+		if err := loadFile(*configFile, &cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) { // File .env bersifat opsional, misalnya di container
+		return nil, fmt.Errorf("load .env: %w", err)
+	}
+	for _, s := range settings {
+		value, ok, err := lookupEnv(s)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			if err := s.set(value); err != nil {
+				return nil, fmt.Errorf("%s: %w", s.env, err)
+			}
+		}
+	}
+
+	for _, s := range settings {
+		if value, ok := flagValues[s.env]; ok {
+			if err := s.set(value); err != nil {
+				return nil, fmt.Errorf("-%s: %w", flagName(s.env), err)
+			}
+		}
+	}
+
+	cfg.applyDriverDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Args mengembalikan argumen posisi (bukan flag) yang diberikan pada command-line
+func (c *Config) Args() []string {
+	return c.args
+}
+
+// lookupEnv membaca nilai dari variabel lingkungan. Nilai rahasia juga bisa dibaca dari file
+// yang ditunjuk oleh <env>_FILE, misalnya DB_PASS_FILE untuk Docker atau Kubernetes secret.
+func lookupEnv(s setting) (string, bool, error) {
+	if s.secret {
+		if file := os.Getenv(s.env + "_FILE"); file != "" {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return "", false, fmt.Errorf("%s_FILE: %w", s.env, err)
+			}
+			return strings.TrimRight(string(data), "\r\n"), true, nil
+		}
+	}
+	value, ok := os.LookupEnv(s.env)
+	return value, ok, nil
+}
+
+// loadFile membaca file konfigurasi YAML atau TOML berdasarkan ekstensinya
+func loadFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, cfg)
+	case ".toml":
+		err = toml.Unmarshal(data, cfg)
+	default:
+		return fmt.Errorf("config file %s: unsupported format, expected .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// applyDriverDefaults mengisi nilai default yang bergantung pada driver database
+func (c *Config) applyDriverDefaults() {
+	db := &c.Database
+	switch db.Driver {
+	case DriverMySQL:
+		if db.Port == 0 {
+			db.Port = 3306
+		}
+		if db.Params == "" {
+			db.Params = "charset=utf8&parseTime=True&loc=Local"
+		}
+	case DriverPostgres:
+		if db.Port == 0 {
+			db.Port = 5432
+		}
+		if db.Params == "" {
+			db.Params = "sslmode=disable"
+		}
+	case DriverSQLite:
+		if db.Params == "" {
+			db.Params = "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+		}
+	}
+	if c.Search.Backend == "auto" { // FULLTEXT hanya tersedia di MySQL, database lain memakai LIKE
+		c.Search.Backend = "sql"
+		if db.Driver == DriverMySQL {
+			c.Search.Backend = "mysql"
+		}
+	}
+}
+
+// Validate memeriksa bahwa konfigurasi lengkap dan nilainya masuk akal
+func (c *Config) Validate() error {
+	var errs []error
+	if c.Server.Addr == "" {
+		errs = append(errs, errors.New("HTTP_ADDR must not be empty"))
+	}
+	if c.Server.ReadTimeout < 0 || c.Server.ReadHeaderTimeout < 0 || c.Server.WriteTimeout < 0 || c.Server.IdleTimeout < 0 {
+		errs = append(errs, errors.New("HTTP_*_TIMEOUT must not be negative"))
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("HTTP_SHUTDOWN_TIMEOUT must be positive"))
+	}
+	errs = append(errs, c.Database.validate()...)
+	if c.JWT.Secret == "" && c.JWT.PrivateKeyFile == "" { // Tanpa keduanya token tidak bisa ditandatangani dengan aman
+		errs = append(errs, errors.New("JWT_SECRET or JWT_PRIVATE_KEY_FILE must be set"))
+	}
+	if c.JWT.AccessTokenTTL <= 0 {
+		errs = append(errs, errors.New("ACCESS_TOKEN_TTL must be positive"))
+	}
+	if c.JWT.RefreshTokenTTL <= 0 {
+		errs = append(errs, errors.New("REFRESH_TOKEN_TTL must be positive"))
+	}
+	switch c.Search.Backend {
+	case "mysql":
+		if c.Database.Driver != DriverMySQL {
+			errs = append(errs, errors.New("SEARCH_BACKEND mysql requires DB_DRIVER mysql"))
+		}
+	case "sql", "bleve":
+	default:
+		errs = append(errs, fmt.Errorf("SEARCH_BACKEND %q must be auto, mysql, sql or bleve", c.Search.Backend))
+	}
+	if c.Search.Backend == "bleve" && c.Search.IndexPath == "" {
+		errs = append(errs, errors.New("SEARCH_INDEX_PATH must not be empty for the bleve backend"))
+	}
+	if c.Revocation.Store != "database" && c.Revocation.Store != "memory" {
+		errs = append(errs, fmt.Errorf("REVOCATION_STORE %q must be database or memory", c.Revocation.Store))
+	}
+	if _, err := logging.ParseLevel(c.Log.Level); err != nil {
+		errs = append(errs, fmt.Errorf("LOG_LEVEL %q must be debug, info, warn or error", c.Log.Level))
+	}
+	if c.Log.Format != "json" && c.Log.Format != "text" {
+		errs = append(errs, fmt.Errorf("LOG_FORMAT %q must be json or text", c.Log.Format))
+	}
+	switch c.Tracing.Exporter {
+	case "none", "stdout", "otlp":
+	default:
+		errs = append(errs, fmt.Errorf("TRACING_EXPORTER %q must be none, stdout or otlp", c.Tracing.Exporter))
+	}
+	if c.Tracing.Exporter != "none" && c.Tracing.ServiceName == "" {
+		errs = append(errs, errors.New("TRACING_SERVICE_NAME must not be empty"))
+	}
+	errs = append(errs, c.RateLimit.validate()...)
+	if c.Lockout.Threshold < 0 {
+		errs = append(errs, errors.New("LOGIN_LOCKOUT_THRESHOLD must not be negative"))
+	}
+	if c.Lockout.Threshold > 0 && (c.Lockout.BaseDelay <= 0 || c.Lockout.MaxDelay < c.Lockout.BaseDelay) {
+		errs = append(errs, errors.New("LOGIN_LOCKOUT_BASE_DELAY must be positive and not greater than LOGIN_LOCKOUT_MAX_DELAY"))
+	}
+	errs = append(errs, c.Password.validate()...)
+	errs = append(errs, c.Mail.validate()...)
+	errs = append(errs, c.SSO.validate()...)
+	if c.OAuth.CodeTTL <= 0 || c.OAuth.RefreshTokenTTL <= 0 {
+		errs = append(errs, errors.New("OAUTH_CODE_TTL and OAUTH_REFRESH_TOKEN_TTL must be positive"))
+	}
+	if c.Reset.TTL <= 0 {
+		errs = append(errs, errors.New("PASSWORD_RESET_TTL must be positive"))
+	}
+	if c.Reset.URL != "" {
+		if u, err := url.Parse(c.Reset.URL); err != nil || !u.IsAbs() {
+			errs = append(errs, fmt.Errorf("PASSWORD_RESET_URL %q must be an absolute URL", c.Reset.URL))
+		}
+	}
+	if c.Verify.TTL <= 0 {
+		errs = append(errs, errors.New("EMAIL_VERIFICATION_TTL must be positive"))
+	}
+	if u, err := url.Parse(c.Verify.URL); err != nil || !u.IsAbs() {
+		errs = append(errs, fmt.Errorf("EMAIL_VERIFICATION_URL %q must be an absolute URL", c.Verify.URL))
+	}
+	if _, err := service.NewUnverifiedPolicy(c.Verify.Deny); err != nil {
+		errs = append(errs, fmt.Errorf("UNVERIFIED_DENY: %w", err))
+	}
+	if c.MFA.Issuer == "" {
+		errs = append(errs, errors.New("MFA_ISSUER must not be empty"))
+	}
+	if c.MFA.PendingTTL <= 0 {
+		errs = append(errs, errors.New("MFA_PENDING_TTL must be positive"))
+	}
+	for _, role := range c.MFA.RequiredRoles {
+		if !entity.IsValidRole(role) {
+			errs = append(errs, fmt.Errorf("MFA_REQUIRED_ROLES: unknown role %q", role))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ssoNamePattern adalah format nama provider SSO yang aman dipakai di URL
+var ssoNamePattern = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// validate memeriksa konfigurasi SSO dan provider yang diaktifkan
+func (sso SSOConfig) validate() []error {
+	var errs []error
+	if u, err := url.Parse(sso.CallbackURL); err != nil || !u.IsAbs() {
+		errs = append(errs, fmt.Errorf("SSO_CALLBACK_URL %q must be an absolute URL", sso.CallbackURL))
+	}
+	if sso.StateTTL <= 0 {
+		errs = append(errs, errors.New("SSO_STATE_TTL must be positive"))
+	}
+	if sso.GoogleClientID != "" && sso.GoogleClientSecret == "" {
+		errs = append(errs, errors.New("GOOGLE_CLIENT_SECRET must not be empty when GOOGLE_CLIENT_ID is set"))
+	}
+	if sso.GitHubClientID != "" && sso.GitHubClientSecret == "" {
+		errs = append(errs, errors.New("GITHUB_CLIENT_SECRET must not be empty when GITHUB_CLIENT_ID is set"))
+	}
+	if sso.OIDCIssuer != "" {
+		if u, err := url.Parse(sso.OIDCIssuer); err != nil || !u.IsAbs() {
+			errs = append(errs, fmt.Errorf("OIDC_ISSUER %q must be an absolute URL", sso.OIDCIssuer))
+		}
+		if sso.OIDCClientID == "" {
+			errs = append(errs, errors.New("OIDC_CLIENT_ID must not be empty when OIDC_ISSUER is set"))
+		}
+		if !ssoNamePattern.MatchString(sso.OIDCName) || sso.OIDCName == "google" || sso.OIDCName == "github" {
+			errs = append(errs, fmt.Errorf("OIDC_NAME %q must be lowercase letters, digits or dashes and must not be google or github", sso.OIDCName))
+		}
+	}
+	return errs
+}
+
+// validate memeriksa algoritma hash dan kebijakan password
+func (pw PasswordConfig) validate() []error {
+	var errs []error
+	switch pw.Algorithm {
+	case password.AlgorithmArgon2id, password.AlgorithmBcrypt:
+	default:
+		errs = append(errs, fmt.Errorf("PASSWORD_HASH_ALGORITHM %q must be argon2id or bcrypt", pw.Algorithm))
+	}
+	if pw.BcryptCost < 10 || pw.BcryptCost > bcrypt.MaxCost {
+		errs = append(errs, fmt.Errorf("PASSWORD_BCRYPT_COST must be between 10 and %d", bcrypt.MaxCost))
+	}
+	if pw.Argon2Iterations < 1 || pw.Argon2Parallelism < 1 || pw.Argon2Parallelism > 255 {
+		errs = append(errs, errors.New("PASSWORD_ARGON2_ITERATIONS must be positive and PASSWORD_ARGON2_PARALLELISM between 1 and 255"))
+	}
+	if pw.Argon2Memory < 8*pw.Argon2Parallelism { // Argon2 membutuhkan minimal 8 KiB untuk setiap thread
+		errs = append(errs, errors.New("PASSWORD_ARGON2_MEMORY must be at least 8 KiB per thread"))
+	}
+	if pw.MinLength < 8 || pw.MaxLength < pw.MinLength {
+		errs = append(errs, errors.New("PASSWORD_MIN_LENGTH must be at least 8 and not greater than PASSWORD_MAX_LENGTH"))
+	}
+	if pw.MinClasses < 0 || pw.MinClasses > 4 {
+		errs = append(errs, errors.New("PASSWORD_MIN_CHARACTER_CLASSES must be between 0 and 4"))
+	}
+	return errs
+}
+
+// validate memeriksa konfigurasi email sesuai driver-nya
+func (m MailConfig) validate() []error {
+	var errs []error
+	if m.From == "" {
+		errs = append(errs, errors.New("MAIL_FROM must not be empty"))
+	}
+	switch m.Driver {
+	case "smtp":
+		if m.SMTPHost == "" {
+			errs = append(errs, errors.New("SMTP_HOST must not be empty for the smtp mail driver"))
+		}
+		if m.SMTPPort <= 0 || m.SMTPPort > 65535 {
+			errs = append(errs, fmt.Errorf("SMTP_PORT %d is out of range", m.SMTPPort))
+		}
+	case "file":
+		if m.Dir == "" {
+			errs = append(errs, errors.New("MAIL_DIR must not be empty for the file mail driver"))
+		}
+	case "log":
+	default:
+		errs = append(errs, fmt.Errorf("MAIL_DRIVER %q must be smtp, file or log", m.Driver))
+	}
+	return errs
+}
+
+// validate memeriksa konfigurasi rate limit, burst wajib diisi untuk limit yang aktif
+func (rl RateLimitConfig) validate() []error {
+	var errs []error
+	if rl.Store != "memory" {
+		errs = append(errs, fmt.Errorf("RATE_LIMIT_STORE %q must be memory", rl.Store))
+	}
+	limits := []struct {
+		name             string
+		perMinute, burst int
+	}{
+		{"IP", rl.IPPerMinute, rl.IPBurst},
+		{"USER", rl.UserPerMinute, rl.UserBurst},
+		{"LOGIN", rl.LoginPerMinute, rl.LoginBurst},
+	}
+	for _, l := range limits {
+		if l.perMinute < 0 {
+			errs = append(errs, fmt.Errorf("RATE_LIMIT_%s_PER_MINUTE must not be negative", l.name))
+		}
+		if l.perMinute > 0 && l.burst <= 0 {
+			errs = append(errs, fmt.Errorf("RATE_LIMIT_%s_BURST must be positive", l.name))
+		}
+	}
+	return errs
+}
+
+// validate memeriksa konfigurasi database sesuai driver-nya
+func (db DatabaseConfig) validate() []error {
+	var errs []error
+	switch db.Driver {
+	case DriverMySQL, DriverPostgres:
+		if db.DSN != "" { // DSN lengkap tidak perlu nilai lain
+			return nil
+		}
+		if db.Host == "" {
+			errs = append(errs, errors.New("DB_HOST must not be empty"))
+		}
+		if db.Port <= 0 || db.Port > 65535 {
+			errs = append(errs, fmt.Errorf("DB_PORT %d is out of range", db.Port))
+		}
+		if db.User == "" {
+			errs = append(errs, errors.New("DB_USER must not be empty"))
+		}
+		if db.Name == "" {
+			errs = append(errs, errors.New("DB_NAME must not be empty"))
+		}
+	case DriverSQLite:
+		if db.DSN == "" && db.Name == "" {
+			errs = append(errs, errors.New("DB_NAME must be a file path or :memory: for sqlite"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("DB_DRIVER %q must be mysql, postgres or sqlite", db.Driver))
+	}
+	return errs
+}
+
+// Redacted mengembalikan konfigurasi efektif dalam format KEY=value dengan nilai rahasia disamarkan
+func (c *Config) Redacted() string {
+	var b strings.Builder
+	for _, s := range c.settings() {
+		fmt.Fprintf(&b, "%s=%s\n", s.env, s.redacted())
+	}
+	return b.String()
+}
+
+// LogValue adalah implementasi slog.LogValuer, nilai rahasia disamarkan seperti pada Redacted
+func (c *Config) LogValue() slog.Value {
+	settings := c.settings()
+	attrs := make([]slog.Attr, 0, len(settings))
+	for _, s := range settings {
+		attrs = append(attrs, slog.String(s.env, s.redacted()))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// redacted mengembalikan nilai setting sebagai teks, disamarkan jika rahasia
+func (s setting) redacted() string {
+	value := s.get()
+	if s.secret && value != "" {
+		value = "********"
+	}
+	return value
+}
@@ -1,43 +1,97 @@
 package config
 
 import (
+	"context"
 	"fmt"
-	"os"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
 
-	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity" // Import definisi entitas (model) dari aplikasi
-	"github.com/joho/godotenv"                              // Import library untuk mengelola variabel lingkungan dari file .env
-	"gorm.io/driver/mysql"                                  // Import driver MySQL untuk GORM
-	"gorm.io/gorm"                                          // Import library GORM untuk ORM di Go
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/logging"   // Import logger GORM berbasis slog
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/migration" // Import migrasi SQL bernomor
+	"github.com/glebarez/sqlite"                               // Import driver SQLite tanpa cgo untuk GORM
+	"gorm.io/driver/mysql"                                     // Import driver MySQL untuk GORM
+	"gorm.io/driver/postgres"                                  // Import driver PostgreSQL untuk GORM
+	"gorm.io/gorm"                                             // Import library GORM untuk ORM di Go
 )
 
-// SetupDatabaseConnection membuat koneksi baru ke database
-func SetupDatabaseConnection() *gorm.DB {
-	errEnv := godotenv.Load() // Load file .env untuk mengambil variabel lingkungan
-	if errEnv != nil {
-		panic("Failed to load env file")
-	}
-
-	// Mendapatkan nilai variabel lingkungan untuk koneksi database
-	dbUser := os.Getenv("DB_USER")
-	dbPass := os.Getenv("DB_PASS")
-	dbHost := os.Getenv("DB_HOST")
-	dbName := os.Getenv("DB_NAME")
+// slowQueryThreshold adalah batas durasi query yang dicatat sebagai query lambat
+const slowQueryThreshold = 200 * time.Millisecond
 
-	// Mengonfigurasi DSN (Data Source Name) untuk koneksi database MySQL
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:3306)/%s?charset=utf8&parseTime=True&loc=Local", dbUser, dbPass, dbHost, dbName)
+// migrateTimeout adalah batas waktu menunggu lock dan menjalankan migrasi saat startup
+const migrateTimeout = 10 * time.Minute
 
-	// Membuat koneksi database menggunakan driver MySQL dan konfigurasi DSN
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+// SetupDatabaseConnection membuat koneksi baru ke database sesuai driver pada konfigurasi
+func SetupDatabaseConnection(cfg DatabaseConfig) *gorm.DB {
+	// Membuat koneksi database menggunakan driver dan DSN dari konfigurasi
+	db, err := gorm.Open(dialector(cfg), &gorm.Config{
+		TranslateError: true,                                      // TranslateError mengubah error driver (duplicate key, dll) menjadi error gorm
+		Logger:         logging.NewGormLogger(slowQueryThreshold), // Query dicatat lewat slog bersama request_id
+	})
 	if err != nil {
-		panic("Failed to create a connection to database")
+		panic("Failed to create a connection to database: " + err.Error())
+	}
+	if cfg.Driver == DriverSQLite {
+		sqlDB, err := db.DB()
+		if err != nil {
+			panic("Failed to create a connection to database: " + err.Error())
+		}
+		// SQLite hanya mengizinkan satu penulis, dan database :memory: hanya ada di satu koneksi
+		sqlDB.SetMaxOpenConns(1)
 	}
 
-	// Menjalankan proses migrasi otomatis untuk tabel-tabel yang didefinisikan dalam aplikasi (entity.Book{} dan entity.User{})
-	db.AutoMigrate(&entity.Book{}, &entity.User{})
+	if cfg.AutoMigrate { // Menerapkan migrasi yang belum dijalankan, instance lain menunggu lewat lock migrasi
+		ctx, cancel := context.WithTimeout(context.Background(), migrateTimeout)
+		defer cancel()
+		if _, err := NewMigrator(db).Up(ctx); err != nil {
+			panic("Failed to migrate the database: " + err.Error())
+		}
+	}
 
 	return db // Mengembalikan objek koneksi database yang sudah dibuat
 }
 
+// NewMigrator membuat migrator untuk dialect database db
+func NewMigrator(db *gorm.DB) *migration.Migrator {
+	migrator, err := migration.New(db)
+	if err != nil {
+		panic("Failed to load migrations: " + err.Error())
+	}
+	return migrator
+}
+
+// dialector memilih driver GORM dan menyusun DSN (Data Source Name) sesuai konfigurasi
+func dialector(cfg DatabaseConfig) gorm.Dialector {
+	switch cfg.Driver {
+	case DriverPostgres:
+		dsn := cfg.DSN
+		if dsn == "" {
+			u := url.URL{
+				Scheme:   "postgres",
+				User:     url.UserPassword(cfg.User, cfg.Password),
+				Host:     net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+				Path:     "/" + cfg.Name,
+				RawQuery: cfg.Params,
+			}
+			dsn = u.String()
+		}
+		return postgres.Open(dsn)
+	case DriverSQLite:
+		dsn := cfg.DSN
+		if dsn == "" {
+			dsn = cfg.Name + "?" + cfg.Params
+		}
+		return sqlite.Open(dsn)
+	default:
+		dsn := cfg.DSN
+		if dsn == "" {
+			dsn = fmt.Sprintf("%s:%s@tcp(%s)/%s?%s", cfg.User, cfg.Password, net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)), cfg.Name, cfg.Params)
+		}
+		return mysql.Open(dsn)
+	}
+}
+
 // CloseDatabaseConnection menutup koneksi database
 func CloseDatabaseConnection(db *gorm.DB) {
 	dbSQL, err := db.DB() // Mendapatkan objek database SQL dari objek GORM
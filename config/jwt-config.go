@@ -0,0 +1,42 @@
+package config
+
+import (
+	"os"   // Import package os untuk membaca file kunci
+	"time" // Import package time untuk masa berlaku token
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service" // Import service untuk membuat JWTService
+)
+
+// SetupJWTService membuat JWTService berdasarkan konfigurasi JWT.
+// Jika PrivateKeyFile diisi, token ditandatangani dengan kunci RSA (RS256) atau Ed25519 (EdDSA)
+// dari file PEM tersebut, dan PublicKeyFiles berisi kunci publik lama yang masih diterima
+// selama masa rotasi. Jika tidak, token ditandatangani dengan Secret (HS256).
+func SetupJWTService(cfg JWTConfig) service.JWTService {
+	ttl := time.Duration(cfg.AccessTokenTTL)
+	if cfg.PrivateKeyFile == "" {
+		return service.NewJWTService(ttl, service.NewHMACKey(cfg.Secret)) // Validate memastikan secret tidak kosong
+	}
+
+	signingKey := loadJWTKey(cfg.PrivateKeyFile)
+	if signingKey.SignKey == nil {
+		panic("JWT_PRIVATE_KEY_FILE does not contain a private key")
+	}
+	var verificationKeys []service.JWTKey
+	for _, file := range cfg.PublicKeyFiles {
+		verificationKeys = append(verificationKeys, loadJWTKey(file)) // Kunci lama yang masih diterima selama masa rotasi
+	}
+	return service.NewJWTService(ttl, signingKey, verificationKeys...)
+}
+
+// loadJWTKey membaca kunci JWT dari file PEM
+func loadJWTKey(file string) service.JWTKey {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		panic("Failed to read JWT key file " + file)
+	}
+	key, err := service.ParseJWTKeyPEM(data)
+	if err != nil {
+		panic("Failed to parse JWT key file " + file + ": " + err.Error())
+	}
+	return key
+}
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"log/slog" // Import package slog untuk structured logging
+	"os"       // Import package os untuk output log
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/logging" // Import package logging untuk membuat logger
+)
+
+// SetupLogger membuat logger sesuai konfigurasi dan menjadikannya logger default,
+// sehingga pemanggilan package log lama ikut ditulis dalam format yang sama.
+// Log ditulis ke stderr agar tidak tercampur dengan output perintah CLI.
+func SetupLogger(cfg LogConfig) *slog.Logger {
+	level, err := logging.ParseLevel(cfg.Level)
+	if err != nil {
+		panic("Invalid log level: " + err.Error())
+	}
+	logger, err := logging.New(os.Stderr, cfg.Format, level)
+	if err != nil {
+		panic("Invalid log format: " + err.Error())
+	}
+	slog.SetDefault(logger)
+	return logger
+}
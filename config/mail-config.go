@@ -0,0 +1,21 @@
+package config
+
+import (
+	"log/slog" // Import package slog untuk menampilkan peringatan konfigurasi
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/mail" // Import package mail untuk implementasi Mailer
+)
+
+// SetupMailer memilih pengirim email berdasarkan konfigurasi.
+// Driver "file" dan "log" tidak mengirim email sungguhan dan hanya cocok untuk development.
+func SetupMailer(cfg MailConfig) mail.Mailer {
+	switch cfg.Driver {
+	case "smtp":
+		return mail.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUser, cfg.SMTPPassword, cfg.From)
+	case "file":
+		return mail.NewFileMailer(cfg.Dir, cfg.From)
+	default:
+		slog.Warn("MAIL_DRIVER is log, mail is written to the log instead of being sent")
+		return mail.NewLogMailer(cfg.From)
+	}
+}
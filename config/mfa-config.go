@@ -0,0 +1,15 @@
+package config
+
+import (
+	"time" // Import package time untuk masa berlaku token login 2FA
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/repository" // Import repository untuk data user dan 2FA
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service"    // Import service untuk membuat MFAService
+)
+
+// SetupMFAService membuat service 2FA. Jika MFA_TOKEN_SECRET kosong, JWT_SECRET yang dipakai;
+// jika keduanya kosong, secret acak dibuat seperti pada SetupEmailVerificationService.
+func SetupMFAService(cfg MFAConfig, jwt JWTConfig, lockout LockoutConfig, userRepo repository.UserRepository, mfaRepo repository.MFARepository) service.MFAService {
+	secret := signingSecret("MFA_TOKEN_SECRET", cfg.Secret, jwt, "pending two-factor logins")
+	return service.NewMFAService(userRepo, mfaRepo, lockout.LockoutPolicy(), secret, cfg.Issuer, time.Duration(cfg.PendingTTL))
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"os" // Import package os untuk membaca daftar password bocor
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/password" // Import package password untuk hasher password
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service"  // Import service untuk kebijakan password
+)
+
+// SetupPasswordHasher membuat hasher dengan algoritma yang dipilih untuk hash baru.
+// Algoritma lain tetap bisa memverifikasi hash lama dan hash tersebut diganti saat user berhasil login.
+func SetupPasswordHasher(cfg PasswordConfig) password.Hasher {
+	argon2id := password.Argon2idHasher{Memory: uint32(cfg.Argon2Memory), Iterations: uint32(cfg.Argon2Iterations), Parallelism: uint8(cfg.Argon2Parallelism)}
+	bcrypt := password.BcryptHasher{Cost: cfg.BcryptCost}
+	if cfg.Algorithm == password.AlgorithmBcrypt {
+		return password.NewHasher(bcrypt, argon2id)
+	}
+	return password.NewHasher(argon2id, bcrypt)
+}
+
+// PasswordPolicy mengembalikan kebijakan password baru. Daftar password bawaan selalu ditolak,
+// ditambah isi PASSWORD_BREACHED_LIST jika diisi; aplikasi berhenti jika file tersebut tidak bisa dibaca.
+func (cfg PasswordConfig) PasswordPolicy() service.PasswordPolicy {
+	breached := password.CommonPasswords()
+	if cfg.BreachedList != "" {
+		list, err := os.ReadFile(cfg.BreachedList)
+		if err != nil {
+			panic("Failed to read PASSWORD_BREACHED_LIST: " + err.Error())
+		}
+		breached = append(breached, password.ParseList(string(list))...)
+	}
+	maxBytes := 0
+	if cfg.Algorithm == password.AlgorithmBcrypt {
+		maxBytes = password.BcryptMaxBytes // Bcrypt mengabaikan byte setelah batas ini
+	}
+	return service.NewPasswordPolicy(cfg.MinLength, cfg.MaxLength, maxBytes, cfg.MinClasses, breached)
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"time" // Import package time untuk durasi
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/ratelimit" // Import package ratelimit untuk penyimpanan token bucket
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service"   // Import package service untuk kebijakan penguncian akun
+)
+
+// SetupRateLimitStore memilih penyimpanan token bucket berdasarkan konfigurasi.
+// Saat ini hanya "memory" yang tersedia; penyimpanan bersama seperti Redis cukup mengimplementasikan ratelimit.Store.
+func SetupRateLimitStore(cfg RateLimitConfig) ratelimit.Store {
+	return ratelimit.NewMemoryStore()
+}
+
+// IPLimit mengembalikan limit untuk setiap IP client
+func (cfg RateLimitConfig) IPLimit() ratelimit.Limit {
+	return ratelimit.Limit{PerMinute: cfg.IPPerMinute, Burst: cfg.IPBurst}
+}
+
+// UserLimit mengembalikan limit untuk setiap user yang login
+func (cfg RateLimitConfig) UserLimit() ratelimit.Limit {
+	return ratelimit.Limit{PerMinute: cfg.UserPerMinute, Burst: cfg.UserBurst}
+}
+
+// LoginLimit mengembalikan limit percobaan login untuk setiap IP client
+func (cfg RateLimitConfig) LoginLimit() ratelimit.Limit {
+	return ratelimit.Limit{PerMinute: cfg.LoginPerMinute, Burst: cfg.LoginBurst}
+}
+
+// LockoutPolicy mengembalikan kebijakan penguncian akun untuk service auth
+func (cfg LockoutConfig) LockoutPolicy() service.LockoutPolicy {
+	return service.LockoutPolicy{Threshold: cfg.Threshold, BaseDelay: time.Duration(cfg.BaseDelay), MaxDelay: time.Duration(cfg.MaxDelay)}
+}
@@ -0,0 +1,18 @@
+package config
+
+import (
+	"time" // Import package time untuk masa berlaku access token
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/repository" // Import repository untuk implementasi penyimpanan pencabutan token
+	"gorm.io/gorm"                                              // Import library GORM untuk ORM di Go
+)
+
+// SetupRevocationStore memilih penyimpanan daftar token yang dicabut berdasarkan konfigurasi.
+// Nilai "memory" menyimpan daftar di memori proses, selain itu daftar disimpan di database.
+// tokenTTL adalah masa berlaku access token, dipakai penyimpanan memori untuk membuang batas pencabutan user yang sudah tidak berguna.
+func SetupRevocationStore(db *gorm.DB, cfg RevocationConfig, tokenTTL time.Duration) repository.RevocationStore {
+	if cfg.Store == "memory" {
+		return repository.NewMemoryRevocationStore(tokenTTL) // Hanya cocok untuk satu instance aplikasi
+	}
+	return repository.NewRevocationStore(db) // Default: disimpan di database agar berlaku di semua instance
+}
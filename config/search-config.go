@@ -0,0 +1,49 @@
+package config
+
+import (
+	"context"  // Import package context untuk proses pengisian index saat startup
+	"log/slog" // Import package slog untuk mencatat proses pengisian index
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity" // Import definisi entitas (model) dari aplikasi
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/search" // Import package search untuk index full-text
+	"gorm.io/gorm"                                          // Import library GORM untuk ORM di Go
+)
+
+// SetupBookIndex memilih index pencarian buku berdasarkan konfigurasi.
+// Backend "bleve" memakai index lokal di IndexPath (default data/books.bleve), "mysql" memakai
+// index FULLTEXT MySQL, dan "sql" memakai LIKE yang berjalan di semua database.
+func SetupBookIndex(db *gorm.DB, cfg SearchConfig) search.BookIndex {
+	switch cfg.Backend {
+	case "sql":
+		return search.NewSQLBookIndex(db)
+	case "mysql":
+		index, err := search.NewMySQLBookIndex(db)
+		if err != nil {
+			panic("Failed to create the FULLTEXT index on books: " + err.Error())
+		}
+		return index
+	}
+
+	index, created, err := search.OpenBleveBookIndex(cfg.IndexPath)
+	if err != nil {
+		panic("Failed to open the search index: " + err.Error())
+	}
+	if created { // Index baru dibuat, isi dengan buku yang sudah ada di database
+		var books []entity.Book
+		var count int
+		res := db.FindInBatches(&books, 500, func(tx *gorm.DB, batch int) error {
+			for _, book := range books {
+				if err := index.Index(context.Background(), book); err != nil {
+					return err
+				}
+			}
+			count += len(books)
+			return nil
+		})
+		if res.Error != nil {
+			panic("Failed to build the search index: " + res.Error.Error())
+		}
+		slog.Info("search index built", "books", count)
+	}
+	return index
+}
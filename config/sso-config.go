@@ -0,0 +1,48 @@
+package config
+
+import (
+	"strings" // Import package strings untuk menyusun URL callback
+	"time"    // Import package time untuk masa berlaku cookie state
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/identity"   // Import identity untuk membuat identity provider
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/repository" // Import repository untuk data user dan identitas
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service"    // Import service untuk membuat SSOService
+)
+
+// googleIssuer adalah issuer OpenID Connect milik Google
+const googleIssuer = "https://accounts.google.com"
+
+// SetupSSOService membuat service login dengan identity provider luar dari provider yang client ID-nya diisi.
+// Jika SSO_STATE_SECRET kosong, JWT_SECRET yang dipakai seperti pada SetupMFAService.
+func SetupSSOService(cfg SSOConfig, jwt JWTConfig, userRepo repository.UserRepository, identityRepo repository.IdentityRepository) service.SSOService {
+	providers := map[string]identity.Provider{}
+	if cfg.GoogleClientID != "" {
+		providers["google"] = identity.NewOIDCProvider(identity.OIDCConfig{
+			Issuer:       googleIssuer,
+			ClientID:     cfg.GoogleClientID,
+			ClientSecret: cfg.GoogleClientSecret,
+			RedirectURL:  cfg.RedirectURL("google"),
+			Scopes:       []string{"email", "profile"},
+		})
+	}
+	if cfg.GitHubClientID != "" {
+		providers["github"] = identity.NewGitHubProvider(cfg.GitHubClientID, cfg.GitHubClientSecret, cfg.RedirectURL("github"))
+	}
+	if cfg.OIDCIssuer != "" {
+		providers[cfg.OIDCName] = identity.NewOIDCProvider(identity.OIDCConfig{
+			Issuer:       cfg.OIDCIssuer,
+			ClientID:     cfg.OIDCClientID,
+			ClientSecret: cfg.OIDCClientSecret,
+			RedirectURL:  cfg.RedirectURL(cfg.OIDCName),
+			Scopes:       cfg.OIDCScopes,
+			TrustEmail:   cfg.OIDCTrustEmail,
+		})
+	}
+	secret := signingSecret("SSO_STATE_SECRET", cfg.StateSecret, jwt, "SSO logins in progress")
+	return service.NewSSOService(providers, userRepo, identityRepo, secret, time.Duration(cfg.StateTTL), cfg.AllowSignup)
+}
+
+// RedirectURL mengembalikan URL callback yang harus didaftarkan di provider
+func (cfg SSOConfig) RedirectURL(provider string) string {
+	return strings.TrimSuffix(cfg.CallbackURL, "/") + "/" + provider + "/callback"
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"context" // Import package context untuk membuat exporter dan shutdown
+	"os"      // Import package os untuk output exporter stdout
+
+	"go.opentelemetry.io/otel"                                        // Import API OpenTelemetry global
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp" // Import exporter OTLP/HTTP
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"           // Import exporter stdout untuk development
+	"go.opentelemetry.io/otel/propagation"                            // Import propagator W3C trace context
+	"go.opentelemetry.io/otel/sdk/resource"                           // Import resource untuk nama service
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"                     // Import SDK trace
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"                // Import nama attribute standar
+)
+
+// SetupTracing memasang TracerProvider global sesuai konfigurasi dan propagator W3C
+// (traceparent dan baggage) agar trace dari gateway berlanjut ke aplikasi ini.
+// Fungsi yang dikembalikan mengirim span yang tersisa lalu menutup exporter.
+// Dengan exporter "none" span tidak dibuat, tetapi trace context tetap diteruskan.
+func SetupTracing(cfg TracingConfig) func(context.Context) error {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if cfg.Exporter == "none" {
+		return func(context.Context) error { return nil }
+	}
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Exporter {
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case "otlp":
+		var opts []otlptracehttp.Option
+		if cfg.OTLPEndpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(cfg.OTLPEndpoint))
+		}
+		if cfg.OTLPInsecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err = otlptracehttp.New(context.Background(), opts...) // Koneksi dibuat saat span pertama dikirim
+	default:
+		panic("Unknown tracing exporter: " + cfg.Exporter)
+	}
+	if err != nil {
+		panic("Failed to create the trace exporter: " + err.Error())
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(cfg.ServiceName)))
+	if err != nil {
+		panic("Failed to create the trace resource: " + err.Error())
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.AlwaysSample())), // Mengikuti keputusan sampling dari gateway
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown
+}
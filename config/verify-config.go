@@ -0,0 +1,45 @@
+package config
+
+import (
+	"log/slog" // Import package slog untuk menampilkan peringatan konfigurasi
+	"time"     // Import package time untuk masa berlaku link
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/helper"     // Import package helper untuk membuat secret acak
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/mail"       // Import package mail untuk pengirim email
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/repository" // Import repository untuk data user
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service"    // Import service untuk membuat EmailVerificationService
+)
+
+// SetupEmailVerificationService membuat service verifikasi email. Jika EMAIL_VERIFICATION_SECRET kosong,
+// JWT_SECRET yang dipakai; jika keduanya kosong (misalnya JWT memakai kunci RSA), secret acak dibuat
+// sehingga link yang sudah terkirim tidak berlaku lagi setelah aplikasi di-restart.
+func SetupEmailVerificationService(cfg VerifyConfig, jwt JWTConfig, userRepo repository.UserRepository, mailer mail.Mailer) service.EmailVerificationService {
+	secret := signingSecret("EMAIL_VERIFICATION_SECRET", cfg.Secret, jwt, "verification links")
+	return service.NewEmailVerificationService(userRepo, mailer, secret, time.Duration(cfg.TTL), cfg.URL)
+}
+
+// signingSecret mengembalikan secret HMAC untuk token tanpa penyimpanan: secret dari env jika diisi, lalu JWT_SECRET,
+// lalu secret acak. Secret acak membuat token yang sudah diterbitkan (what) tidak berlaku lagi setelah aplikasi di-restart.
+func signingSecret(env string, secret string, jwt JWTConfig, what string) string {
+	if secret != "" {
+		return secret
+	}
+	if jwt.Secret != "" {
+		return jwt.Secret
+	}
+	slog.Warn(env + " and JWT_SECRET are empty, " + what + " will not survive a restart")
+	random, err := helper.GenerateRandomToken(32)
+	if err != nil {
+		panic("Failed to generate " + env + ": " + err.Error())
+	}
+	return random
+}
+
+// UnverifiedPolicy mengembalikan daftar aksi yang tidak boleh dilakukan user yang belum memverifikasi email
+func (cfg VerifyConfig) UnverifiedPolicy() service.UnverifiedPolicy {
+	policy, err := service.NewUnverifiedPolicy(cfg.Deny)
+	if err != nil {
+		panic("Invalid UNVERIFIED_DENY: " + err.Error())
+	}
+	return policy
+}
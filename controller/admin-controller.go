@@ -0,0 +1,179 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/dto"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/helper"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/middleware"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminController adalah interface yang mendefinisikan method-method yang dapat dipanggil oleh admin
+type AdminController interface {
+	Users(context *gin.Context)      // Method Users untuk meng-handle request mendapatkan semua user
+	SetRole(context *gin.Context)    // Method SetRole untuk meng-handle request mengubah role user
+	Suspend(context *gin.Context)    // Method Suspend untuk meng-handle request memblokir atau membuka blokir user
+	Unlock(context *gin.Context)     // Method Unlock untuk meng-handle request membuka kunci akun setelah login gagal berulang kali
+	ResetMFA(context *gin.Context)   // Method ResetMFA untuk meng-handle request mematikan 2FA user yang kehilangan authenticator
+	UpdateBook(context *gin.Context) // Method UpdateBook untuk meng-handle request mengubah buku milik siapa pun
+	DeleteBook(context *gin.Context) // Method DeleteBook untuk meng-handle request menghapus buku milik siapa pun
+}
+
+// adminController adalah implementasi dari AdminController
+type adminController struct {
+	userService service.UserService // userService adalah service yang digunakan untuk operasi terkait user
+	bookService service.BookService // bookService adalah service yang digunakan untuk operasi terkait buku
+	mfaService  service.MFAService  // mfaService adalah service yang digunakan untuk operasi terkait 2FA
+}
+
+// NewAdminController membuat instance baru dari AdminController
+func NewAdminController(userService service.UserService, bookService service.BookService, mfaService service.MFAService) AdminController {
+	return &adminController{
+		userService: userService,
+		bookService: bookService,
+		mfaService:  mfaService,
+	}
+}
+
+// Users adalah method untuk meng-handle request mendapatkan semua user
+func (c *adminController) Users(context *gin.Context) {
+	users, err := c.userService.All(context.Request.Context()) // Mendapatkan semua user dari service
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	res := helper.BuildResponse(true, "OK", dto.NewAdminUsers(users)) // Membuat response sukses
+	context.JSON(http.StatusOK, res)                                  // Mengirimkan response sukses
+}
+
+// SetRole adalah method untuk meng-handle request mengubah role user
+func (c *adminController) SetRole(context *gin.Context) {
+	id, err := c.targetUserID(context)
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	var roleDTO dto.UserRoleDTO
+	if errDTO := context.ShouldBind(&roleDTO); errDTO != nil {
+		respondBindError(context, errDTO) // Menampilkan response error jika terjadi kesalahan pada DTO
+		return
+	}
+	user, err := c.userService.SetRole(context.Request.Context(), id, roleDTO.Role) // Mengubah role melalui service
+	c.respondUser(context, user, err)
+}
+
+// Suspend adalah method untuk meng-handle request memblokir atau membuka blokir user
+func (c *adminController) Suspend(context *gin.Context) {
+	id, err := c.targetUserID(context)
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	var suspendDTO dto.UserSuspendDTO
+	if errDTO := context.ShouldBind(&suspendDTO); errDTO != nil {
+		respondBindError(context, errDTO) // Menampilkan response error jika terjadi kesalahan pada DTO
+		return
+	}
+	user, err := c.userService.SetSuspended(context.Request.Context(), id, *suspendDTO.Suspended) // Mengubah status blokir melalui service
+	c.respondUser(context, user, err)
+}
+
+// Unlock adalah method untuk meng-handle request membuka kunci akun setelah login gagal berulang kali
+func (c *adminController) Unlock(context *gin.Context) {
+	id, err := paramID(context) // Mendapatkan ID user dari request
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	user, err := c.userService.Unlock(context.Request.Context(), id) // Membuka kunci akun melalui service
+	c.respondUser(context, user, err)
+}
+
+// ResetMFA adalah method untuk meng-handle request mematikan 2FA user yang kehilangan authenticator dan kode pemulihan.
+// User bisa login hanya dengan password lalu mengaktifkan 2FA kembali.
+func (c *adminController) ResetMFA(context *gin.Context) {
+	id, err := paramID(context) // Mendapatkan ID user dari request
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	if err := c.mfaService.Reset(context.Request.Context(), id); err != nil { // Mematikan 2FA melalui service
+		respondError(context, err)
+		return
+	}
+	user, err := c.userService.Profile(context.Request.Context(), id) // Mengembalikan data user terbaru
+	c.respondUser(context, user, err)
+}
+
+// UpdateBook adalah method untuk meng-handle request mengubah buku milik siapa pun
+func (c *adminController) UpdateBook(context *gin.Context) {
+	id, err := paramID(context) // Mendapatkan ID buku dari request
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	var bookDTO dto.AdminBookUpdateDTO
+	if errDTO := context.ShouldBind(&bookDTO); errDTO != nil {
+		respondBindError(context, errDTO) // Menampilkan response error jika terjadi kesalahan pada DTO
+		return
+	}
+	book, err := c.bookService.FindByID(context.Request.Context(), id)
+	if err != nil {
+		respondError(context, err) // Menampilkan response 404 jika buku tidak ditemukan
+		return
+	}
+	result, err := c.bookService.Update(context.Request.Context(), dto.BookUpdateDTO{
+		ID:          book.ID,
+		Title:       bookDTO.Title,
+		Description: bookDTO.Description,
+		UserID:      book.UserID, // Pemilik buku tidak berubah
+	})
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	res := helper.BuildResponse(true, "OK", dto.NewBookResponse(result)) // Membuat response sukses
+	context.JSON(http.StatusOK, res)                                     // Mengirimkan response sukses
+}
+
+// DeleteBook adalah method untuk meng-handle request menghapus buku milik siapa pun
+func (c *adminController) DeleteBook(context *gin.Context) {
+	id, err := paramID(context) // Mendapatkan ID buku dari request
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	if err := c.bookService.Delete(context.Request.Context(), entity.Book{ID: id}); err != nil { // Menghapus buku melalui service
+		respondError(context, err) // Menampilkan response 404 jika buku tidak ditemukan
+		return
+	}
+	res := helper.BuildResponse(true, "Delete", helper.EmptyObj{}) // Membuat response sukses
+	context.JSON(http.StatusOK, res)                               // Mengirimkan response sukses
+}
+
+// targetUserID membaca ID user dari URL dan mencegah admin mengubah akunnya sendiri
+func (c *adminController) targetUserID(context *gin.Context) (uint64, error) {
+	id, err := paramID(context)
+	if err != nil {
+		return 0, err
+	}
+	if strconv.FormatUint(id, 10) == context.GetString(middleware.ContextUserIDKey) { // Admin tidak boleh mengunci dirinya sendiri
+		return 0, apperror.Invalid("Admins cannot change their own role or suspension").WithCode("self_modification")
+	}
+	return id, nil
+}
+
+// respondUser mengirimkan hasil operasi admin terhadap user
+func (c *adminController) respondUser(context *gin.Context, user entity.User, err error) {
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	res := helper.BuildResponse(true, "OK", dto.NewAdminUser(user)) // Membuat response sukses
+	context.JSON(http.StatusOK, res)                                // Mengirimkan response sukses
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/dto"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/helper"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service"
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyController adalah interface yang mendefinisikan method-method yang dapat dipanggil untuk mengelola API key user yang login
+type APIKeyController interface {
+	Create(context *gin.Context) // Method Create untuk meng-handle request membuat API key
+	List(context *gin.Context)   // Method List untuk meng-handle request mendapatkan API key milik user
+	Revoke(context *gin.Context) // Method Revoke untuk meng-handle request mencabut API key
+}
+
+// apiKeyController adalah implementasi dari APIKeyController
+type apiKeyController struct {
+	apiKeyService service.APIKeyService // apiKeyService adalah service yang digunakan untuk operasi terkait API key
+}
+
+// NewAPIKeyController membuat instance baru dari APIKeyController
+func NewAPIKeyController(apiKeyService service.APIKeyService) APIKeyController {
+	return &apiKeyController{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// Create adalah method untuk meng-handle request membuat API key. Key hanya ditampilkan pada respons ini.
+func (c *apiKeyController) Create(context *gin.Context) {
+	var keyDTO dto.APIKeyCreateDTO
+	if errDTO := context.ShouldBind(&keyDTO); errDTO != nil {
+		respondBindError(context, errDTO) // Menampilkan response error jika terjadi kesalahan pada DTO
+		return
+	}
+	id, err := currentUserID(context) // Mendapatkan ID user dari JWT token
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	created, err := c.apiKeyService.Create(context.Request.Context(), id, keyDTO) // Memanggil service untuk membuat API key
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	res := helper.BuildResponse(true, "API key created, store the key in a safe place", created) // Membuat response sukses
+	context.JSON(http.StatusCreated, res)                                                        // Mengirimkan response sukses
+}
+
+// List adalah method untuk meng-handle request mendapatkan API key milik user
+func (c *apiKeyController) List(context *gin.Context) {
+	id, err := currentUserID(context) // Mendapatkan ID user dari JWT token
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	keys, err := c.apiKeyService.List(context.Request.Context(), id) // Memanggil service untuk mendapatkan API key
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	res := helper.BuildResponse(true, "OK!", keys) // Membuat response sukses
+	context.JSON(http.StatusOK, res)               // Mengirimkan response sukses
+}
+
+// Revoke adalah method untuk meng-handle request mencabut API key
+func (c *apiKeyController) Revoke(context *gin.Context) {
+	keyID, err := paramID(context) // Mendapatkan ID API key dari request
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	id, err := currentUserID(context) // Mendapatkan ID user dari JWT token
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	if err := c.apiKeyService.Revoke(context.Request.Context(), id, keyID); err != nil { // Memanggil service untuk mencabut API key
+		respondError(context, err)
+		return
+	}
+	res := helper.BuildResponse(true, "API key revoked", helper.EmptyObj{}) // Membuat response sukses
+	context.JSON(http.StatusOK, res)                                        // Mengirimkan response sukses
+}
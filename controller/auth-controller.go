@@ -1,74 +1,417 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
+	"net/url"
 	"strconv"
 
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror"
 	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/dto"
 	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity"
 	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/helper"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/metrics"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/middleware"
 	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service"
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
 )
 
+var (
+	// errAccountSuspended dikembalikan ketika user yang diblokir admin mencoba login
+	errAccountSuspended = apperror.Forbidden("account suspended").WithCode("account_suspended")
+	// errAccountInactive dikembalikan ketika refresh token milik user yang sudah dihapus atau diblokir
+	errAccountInactive = apperror.Unauthorized("account is not active").WithCode("account_inactive")
+	// errDuplicateEmail dikembalikan ketika email registrasi sudah terdaftar
+	errDuplicateEmail = apperror.Conflict("duplicate email").WithCode("duplicate_email")
+)
+
+// ssoStateCookie adalah nama cookie yang menyimpan state, nonce, dan PKCE code verifier selama login SSO
+const ssoStateCookie = "sso_state"
+
 // AuthController interface adalah kontrak untuk controller ini
 type AuthController interface {
-	Login(ctx *gin.Context)    // Method untuk meng-handle request login
-	Register(ctx *gin.Context) // Method untuk meng-handle request registrasi
+	Login(ctx *gin.Context)              // Method untuk meng-handle request login
+	LoginMFA(ctx *gin.Context)           // Method untuk meng-handle request langkah kedua login dengan kode 2FA
+	Register(ctx *gin.Context)           // Method untuk meng-handle request registrasi
+	Refresh(ctx *gin.Context)            // Method untuk meng-handle request pembaruan token
+	Logout(ctx *gin.Context)             // Method untuk meng-handle request logout sesi saat ini
+	LogoutAll(ctx *gin.Context)          // Method untuk meng-handle request logout dari semua sesi
+	ForgotPassword(ctx *gin.Context)     // Method untuk meng-handle request pengiriman email reset password
+	ResetPassword(ctx *gin.Context)      // Method untuk meng-handle request reset password dengan token dari email
+	VerifyEmail(ctx *gin.Context)        // Method untuk meng-handle request verifikasi email dari link yang dikirim
+	ResendVerification(ctx *gin.Context) // Method untuk meng-handle request pengiriman ulang link verifikasi email
+	SSOProviders(ctx *gin.Context)       // Method untuk meng-handle request daftar identity provider
+	SSOLogin(ctx *gin.Context)           // Method untuk meng-handle request memulai login dengan identity provider
+	SSOCallback(ctx *gin.Context)        // Method untuk meng-handle callback dari identity provider
 }
 
 // authController adalah implementasi dari AuthController
 type authController struct {
-	authService service.AuthService // authService adalah service yang digunakan untuk operasi terkait auth
-	jwtService  service.JWTService  // jwtService adalah service yang digunakan untuk operasi terkait JWT
+	authService          service.AuthService              // authService adalah service yang digunakan untuk operasi terkait auth
+	jwtService           service.JWTService               // jwtService adalah service yang digunakan untuk operasi terkait JWT
+	refreshTokenService  service.RefreshTokenService      // refreshTokenService adalah service yang digunakan untuk operasi terkait refresh token
+	revocationService    service.TokenRevocationService   // revocationService adalah service yang digunakan untuk mencabut token
+	passwordResetService service.PasswordResetService     // passwordResetService adalah service yang digunakan untuk reset password
+	verificationService  service.EmailVerificationService // verificationService adalah service yang digunakan untuk verifikasi email
+	mfaService           service.MFAService               // mfaService adalah service yang digunakan untuk login dengan 2FA
+	ssoService           service.SSOService               // ssoService adalah service yang digunakan untuk login dengan identity provider luar
+	sessionService       service.SessionService           // sessionService adalah service yang digunakan untuk mencatat sesi login
+	ssoCallback          *url.URL                         // ssoCallback adalah awal URL callback SSO, menentukan path dan atribut Secure cookie state
+	metrics              *metrics.Metrics                 // metrics mencatat login, login gagal, dan registrasi
 }
 
 // NewAuthController membuat instance baru dari AuthController
-func NewAuthController(authService service.AuthService, jwtService service.JWTService) AuthController {
+func NewAuthController(authService service.AuthService, jwtService service.JWTService, refreshTokenService service.RefreshTokenService, revocationService service.TokenRevocationService, passwordResetService service.PasswordResetService, verificationService service.EmailVerificationService, mfaService service.MFAService, ssoService service.SSOService, sessionService service.SessionService, ssoCallbackURL string, m *metrics.Metrics) AuthController {
+	ssoCallback, err := url.Parse(ssoCallbackURL)
+	if err != nil {
+		panic("Invalid SSO callback URL: " + err.Error()) // Sudah divalidasi saat konfigurasi dibaca
+	}
 	return &authController{
-		authService: authService,
-		jwtService:  jwtService,
+		authService:          authService,
+		jwtService:           jwtService,
+		refreshTokenService:  refreshTokenService,
+		revocationService:    revocationService,
+		passwordResetService: passwordResetService,
+		verificationService:  verificationService,
+		mfaService:           mfaService,
+		ssoService:           ssoService,
+		sessionService:       sessionService,
+		ssoCallback:          ssoCallback,
+		metrics:              m,
 	}
 }
 
-// Login adalah method untuk meng-handle request login
+// Login adalah method untuk meng-handle request login.
+// User yang mengaktifkan 2FA belum menerima token, melainkan token login 2FA yang harus ditukar di LoginMFA bersama kode 2FA.
 func (c *authController) Login(ctx *gin.Context) {
 	var loginDTO dto.LoginDTO
-	errDTO := ctx.ShouldBind(&loginDTO)
-	if errDTO != nil {
-		response := helper.BuildErrorResponse("Failed to process request", errDTO.Error(), helper.EmptyObj{}) // Menampilkan response error jika terjadi kesalahan pada DTO
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, response)
+	if errDTO := ctx.ShouldBind(&loginDTO); errDTO != nil {
+		respondBindError(ctx, errDTO) // Menampilkan response error jika terjadi kesalahan pada DTO
+		return
+	}
+	user, err := c.authService.VerifyCredential(ctx.Request.Context(), loginDTO.Email, loginDTO.Password) // Verifikasi kredensial user
+	if err == nil && user.Suspended {                                                                     // User yang diblokir admin tidak boleh login
+		err = errAccountSuspended
+	}
+	if err != nil {
+		c.metrics.LoginFailed(apperror.CodeOf(err))
+		respondError(ctx, err)
+		return
+	}
+	if user.TOTPEnabled { // Password benar, tetapi login baru selesai setelah kode 2FA dimasukkan
+		response := helper.BuildResponse(true, "Two-factor authentication required", c.mfaService.IssueChallenge(user))
+		ctx.JSON(http.StatusOK, response)
+		return
+	}
+	c.startSession(ctx, user, loginDTO.DeviceName)
+}
+
+// LoginMFA adalah method untuk meng-handle request langkah kedua login, menukar token login 2FA dan kode 2FA dengan token
+func (c *authController) LoginMFA(ctx *gin.Context) {
+	var mfaDTO dto.MFALoginDTO
+	if errDTO := ctx.ShouldBind(&mfaDTO); errDTO != nil {
+		respondBindError(ctx, errDTO) // Menampilkan response error jika terjadi kesalahan pada DTO
+		return
+	}
+	user, err := c.mfaService.CompleteLogin(ctx.Request.Context(), mfaDTO.MFAToken, mfaDTO.Code) // Memeriksa token login 2FA dan kode 2FA
+	if err == nil && user.Suspended {                                                            // User bisa diblokir di antara kedua langkah login
+		err = errAccountSuspended
+	}
+	if err != nil {
+		c.metrics.LoginFailed(apperror.CodeOf(err))
+		respondError(ctx, err)
 		return
 	}
-	authResult := c.authService.VerifyCredential(loginDTO.Email, loginDTO.Password) // Verifikasi kredensial user
-	if v, ok := authResult.(entity.User); ok {                                      // Jika kredensial valid
-		generatedToken := c.jwtService.GenerateToken(strconv.FormatUint(v.ID, 10)) // Generate token JWT
-		v.Token = generatedToken
-		response := helper.BuildResponse(true, "OK!", v) // Membuat response sukses dengan token
-		ctx.JSON(http.StatusOK, response)                // Mengirimkan response sukses
+	c.startSession(ctx, user, mfaDTO.DeviceName)
+}
+
+// startSession menerbitkan access token dan refresh token untuk user yang sudah selesai login
+func (c *authController) startSession(ctx *gin.Context, user entity.User, deviceName string) {
+	user, err := c.issueSession(ctx, user, deviceName)
+	if err != nil {
+		respondError(ctx, err)
 		return
 	}
-	response := helper.BuildErrorResponse("Please check again your credential", "Invalid Credential", helper.EmptyObj{}) // Menampilkan response error kredensial tidak valid
-	ctx.AbortWithStatusJSON(http.StatusUnauthorized, response)
+	c.metrics.LoginSucceeded()
+	response := helper.BuildResponse(true, "OK!", dto.NewUserResponse(user)) // Membuat response sukses dengan token
+	ctx.JSON(http.StatusOK, response)                                        // Mengirimkan response sukses
+}
+
+// issueSession menerbitkan refresh token dengan family baru, mencatat sesi login untuk family tersebut,
+// lalu mengisi access token milik sesi dan refresh token pada user
+func (c *authController) issueSession(ctx *gin.Context, user entity.User, deviceName string) (entity.User, error) {
+	refreshToken, record, err := c.refreshTokenService.Issue(ctx.Request.Context(), user.ID) // Menerbitkan refresh token untuk sesi ini
+	if err != nil {
+		return user, err
+	}
+	user.Token, err = c.sessionToken(ctx, record, user.Role, deviceName) // Generate token JWT
+	if err != nil {
+		return user, err
+	}
+	user.RefreshToken = refreshToken
+	return user, nil
+}
+
+// sessionToken mencatat sesi untuk family refresh token, lalu menerbitkan access token yang terikat dengan sesi tersebut
+func (c *authController) sessionToken(ctx *gin.Context, record entity.RefreshToken, role string, deviceName string) (string, error) {
+	session, err := c.sessionService.Track(ctx.Request.Context(), record.UserID, record.FamilyID, dto.SessionDeviceDTO{
+		DeviceName: deviceName,
+		UserAgent:  ctx.Request.UserAgent(),
+		IPAddress:  ctx.ClientIP(), // IP asli client jika request melewati proxy yang dipercaya
+	})
+	if err != nil {
+		return "", err
+	}
+	return c.jwtService.GenerateSessionToken(strconv.FormatUint(record.UserID, 10), role, strconv.FormatUint(session.ID, 10))
 }
 
 // Register adalah method untuk meng-handle request registrasi
 func (c *authController) Register(ctx *gin.Context) {
 	var registerDTO dto.RegisterDTO
-	errDTO := ctx.ShouldBind(&registerDTO)
-	if errDTO != nil {
-		response := helper.BuildErrorResponse("Failed to process request", errDTO.Error(), helper.EmptyObj{}) // Menampilkan response error jika terjadi kesalahan pada DTO
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, response)
-		return
-	}
-	if !c.authService.IsDuplicateEmail(registerDTO.Email) { // Memeriksa duplikasi email
-		response := helper.BuildErrorResponse("Failed to process request", "Duplicate email", helper.EmptyObj{}) // Menampilkan response error jika email sudah terdaftar
-		ctx.JSON(http.StatusConflict, response)                                                                  // Mengirimkan response conflict
-	} else {
-		createdUser := c.authService.CreateUser(registerDTO)                        // Membuat user baru melalui service
-		token := c.jwtService.GenerateToken(strconv.FormatUint(createdUser.ID, 10)) // Generate token JWT
-		createdUser.Token = token
-		response := helper.BuildResponse(true, "OK!", createdUser) // Membuat response sukses dengan token
-		ctx.JSON(http.StatusCreated, response)                     // Mengirimkan response sukses
+	if errDTO := ctx.ShouldBind(&registerDTO); errDTO != nil {
+		respondBindError(ctx, errDTO) // Menampilkan response error jika terjadi kesalahan pada DTO
+		return
+	}
+	duplicate, err := c.authService.IsDuplicateEmail(ctx.Request.Context(), registerDTO.Email) // Memeriksa duplikasi email
+	if err != nil {
+		respondError(ctx, err)
+		return
 	}
+	if duplicate {
+		respondError(ctx, errDuplicateEmail) // Menampilkan response conflict jika email sudah terdaftar
+		return
+	}
+	createdUser, err := c.authService.CreateUser(ctx.Request.Context(), registerDTO) // Membuat user baru melalui service
+	if err != nil {
+		respondError(ctx, err) // Unique index email tetap menghasilkan conflict jika terjadi registrasi bersamaan
+		return
+	}
+	c.metrics.UserRegistered()
+	if err := c.verificationService.SendVerification(ctx.Request.Context(), createdUser); err != nil { // User baru belum terverifikasi sampai membuka link di email
+		respondError(ctx, err)
+		return
+	}
+	createdUser, err = c.issueSession(ctx, createdUser, "") // User baru langsung login di perangkat yang dipakai untuk registrasi
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	response := helper.BuildResponse(true, "OK!", dto.NewUserResponse(createdUser)) // Membuat response sukses dengan token
+	ctx.JSON(http.StatusCreated, response)                                          // Mengirimkan response sukses
+}
+
+// Refresh adalah method untuk meng-handle request pembaruan access token menggunakan refresh token
+func (c *authController) Refresh(ctx *gin.Context) {
+	var refreshDTO dto.RefreshTokenDTO
+	if errDTO := ctx.ShouldBind(&refreshDTO); errDTO != nil {
+		respondBindError(ctx, errDTO) // Menampilkan response error jika terjadi kesalahan pada DTO
+		return
+	}
+	refreshToken, record, err := c.refreshTokenService.Rotate(ctx.Request.Context(), refreshDTO.RefreshToken) // Merotasi refresh token
+	if err != nil {
+		respondError(ctx, err) // Token tidak valid atau dipakai ulang, user harus login ulang
+		return
+	}
+	user, err := c.authService.FindByID(ctx.Request.Context(), record.UserID) // Mengambil data user terbaru agar role di token selalu mutakhir
+	if errors.Is(err, apperror.ErrNotFound) {
+		err = errAccountInactive
+	}
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	if user.Suspended {
+		respondError(ctx, errAccountInactive)
+		return
+	}
+	accessToken, err := c.sessionToken(ctx, record, user.Role, "") // Generate access token baru untuk sesi yang sama
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	tokens := dto.TokenResponseDTO{
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(c.jwtService.TokenTTL().Seconds()),
+	}
+	response := helper.BuildResponse(true, "OK!", tokens) // Membuat response sukses dengan token baru
+	ctx.JSON(http.StatusOK, response)                     // Mengirimkan response sukses
+}
+
+// Logout adalah method untuk meng-handle request logout, mencabut access token yang dipakai dan mengakhiri sesi tersebut beserta refresh token-nya
+func (c *authController) Logout(ctx *gin.Context) {
+	var logoutDTO dto.LogoutDTO
+	if errDTO := ctx.ShouldBind(&logoutDTO); errDTO != nil {
+		respondBindError(ctx, errDTO) // Menampilkan response error jika terjadi kesalahan pada DTO
+		return
+	}
+	value, _ := ctx.Get(middleware.ContextTokenKey)
+	token, ok := value.(*jwt.Token) // Token sudah divalidasi oleh middleware AuthorizeJWT
+	if !ok {
+		respondError(ctx, apperror.Unauthorized("no token found").WithCode("token_missing"))
+		return
+	}
+	if err := c.revocationService.Revoke(ctx.Request.Context(), token); err != nil { // Mencabut access token yang sedang dipakai
+		respondError(ctx, err)
+		return
+	}
+	if sessionID := currentSessionID(ctx); sessionID != 0 { // Sesi yang sedang dipakai berakhir walaupun refresh token tidak dikirimkan
+		userID, err := currentUserID(ctx)
+		if err != nil {
+			respondError(ctx, err)
+			return
+		}
+		err = c.sessionService.Revoke(ctx.Request.Context(), userID, sessionID)
+		if err != nil && !errors.Is(err, service.ErrSessionNotFound) {
+			respondError(ctx, err)
+			return
+		}
+	}
+	if logoutDTO.RefreshToken != "" { // Refresh token sesi ini ikut dicabut jika dikirimkan
+		err := c.refreshTokenService.Revoke(ctx.Request.Context(), logoutDTO.RefreshToken)
+		if err != nil && !errors.Is(err, service.ErrInvalidRefreshToken) {
+			respondError(ctx, err)
+			return
+		}
+	}
+	response := helper.BuildResponse(true, "Logged out", helper.EmptyObj{}) // Membuat response sukses
+	ctx.JSON(http.StatusOK, response)                                       // Mengirimkan response sukses
+}
+
+// LogoutAll adalah method untuk meng-handle request logout dari semua sesi milik user
+func (c *authController) LogoutAll(ctx *gin.Context) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	if err := c.revocationService.RevokeAllForUser(ctx.Request.Context(), userID); err != nil { // Mencabut seluruh access token dan refresh token milik user
+		respondError(ctx, err)
+		return
+	}
+	response := helper.BuildResponse(true, "Logged out from all sessions", helper.EmptyObj{}) // Membuat response sukses
+	ctx.JSON(http.StatusOK, response)                                                         // Mengirimkan response sukses
+}
+
+// ForgotPassword adalah method untuk meng-handle request pengiriman email reset password.
+// Respons selalu sama, baik email terdaftar maupun tidak, agar client tidak bisa menebak email yang terdaftar.
+func (c *authController) ForgotPassword(ctx *gin.Context) {
+	var forgotDTO dto.ForgotPasswordDTO
+	if errDTO := ctx.ShouldBind(&forgotDTO); errDTO != nil {
+		respondBindError(ctx, errDTO) // Menampilkan response error jika terjadi kesalahan pada DTO
+		return
+	}
+	if err := c.passwordResetService.RequestReset(ctx.Request.Context(), forgotDTO.Email); err != nil { // Mengirim token reset password ke email
+		respondError(ctx, err)
+		return
+	}
+	response := helper.BuildResponse(true, "If the email is registered, a password reset link has been sent", helper.EmptyObj{}) // Membuat response sukses
+	ctx.JSON(http.StatusAccepted, response)                                                                                      // Mengirimkan response sukses
+}
+
+// ResetPassword adalah method untuk meng-handle request reset password dengan token dari email.
+// Semua sesi user dicabut sehingga user harus login ulang dengan password baru.
+func (c *authController) ResetPassword(ctx *gin.Context) {
+	var resetDTO dto.ResetPasswordDTO
+	if errDTO := ctx.ShouldBind(&resetDTO); errDTO != nil {
+		respondBindError(ctx, errDTO) // Menampilkan response error jika terjadi kesalahan pada DTO
+		return
+	}
+	if err := c.passwordResetService.ResetPassword(ctx.Request.Context(), resetDTO.Token, resetDTO.Password); err != nil { // Mengganti password melalui service
+		respondError(ctx, err)
+		return
+	}
+	response := helper.BuildResponse(true, "Password has been reset", helper.EmptyObj{}) // Membuat response sukses
+	ctx.JSON(http.StatusOK, response)                                                    // Mengirimkan response sukses
+}
+
+// VerifyEmail adalah method untuk meng-handle request verifikasi email dari link yang dikirim
+func (c *authController) VerifyEmail(ctx *gin.Context) {
+	var verifyDTO dto.VerifyEmailDTO
+	if errDTO := ctx.ShouldBindQuery(&verifyDTO); errDTO != nil {
+		respondBindError(ctx, errDTO) // Menampilkan response error jika terjadi kesalahan pada DTO
+		return
+	}
+	user, err := c.verificationService.Verify(ctx.Request.Context(), verifyDTO.Token) // Memverifikasi email melalui service
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	response := helper.BuildResponse(true, "Email has been verified", dto.NewUserResponse(user)) // Membuat response sukses
+	ctx.JSON(http.StatusOK, response)                                                            // Mengirimkan response sukses
+}
+
+// ResendVerification adalah method untuk meng-handle request pengiriman ulang link verifikasi email kepada user yang login
+func (c *authController) ResendVerification(ctx *gin.Context) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	if err := c.verificationService.Resend(ctx.Request.Context(), userID); err != nil { // Mengirim ulang link verifikasi melalui service
+		respondError(ctx, err)
+		return
+	}
+	response := helper.BuildResponse(true, "Verification email has been sent", helper.EmptyObj{}) // Membuat response sukses
+	ctx.JSON(http.StatusAccepted, response)                                                       // Mengirimkan response sukses
+}
+
+// SSOProviders adalah method untuk meng-handle request daftar identity provider yang bisa dipakai untuk login
+func (c *authController) SSOProviders(ctx *gin.Context) {
+	providers := []dto.SSOProviderDTO{}
+	for _, name := range c.ssoService.Providers() {
+		providers = append(providers, dto.SSOProviderDTO{Name: name, LoginURL: c.ssoCallback.Path + "/" + name + "/login"})
+	}
+	response := helper.BuildResponse(true, "OK", providers) // Membuat response sukses
+	ctx.JSON(http.StatusOK, response)                       // Mengirimkan response sukses
+}
+
+// SSOLogin adalah method untuk meng-handle request memulai login dengan identity provider.
+// Browser diarahkan ke halaman login provider, state disimpan di cookie HttpOnly untuk diperiksa saat callback.
+func (c *authController) SSOLogin(ctx *gin.Context) {
+	authURL, state, err := c.ssoService.Begin(ctx.Request.Context(), ctx.Param("provider"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	c.setSSOCookie(ctx, state, 0)
+	ctx.Redirect(http.StatusFound, authURL) // Mengarahkan browser ke halaman login provider
+}
+
+// SSOCallback adalah method untuk meng-handle callback dari identity provider.
+// User yang mengaktifkan 2FA menerima token login 2FA seperti pada Login.
+func (c *authController) SSOCallback(ctx *gin.Context) {
+	var callbackDTO dto.SSOCallbackDTO
+	if errDTO := ctx.ShouldBindQuery(&callbackDTO); errDTO != nil {
+		respondBindError(ctx, errDTO) // Menampilkan response error jika terjadi kesalahan pada DTO
+		return
+	}
+	state, _ := ctx.Cookie(ssoStateCookie) // Cookie yang hilang ditolak oleh service sebagai state tidak valid
+	c.setSSOCookie(ctx, "", -1)            // State hanya boleh dipakai sekali
+	user, created, err := c.ssoService.Complete(ctx.Request.Context(), ctx.Param("provider"), state, callbackDTO)
+	if err == nil && user.Suspended { // User yang diblokir admin tidak boleh login
+		err = errAccountSuspended
+	}
+	if err != nil {
+		c.metrics.LoginFailed(apperror.CodeOf(err))
+		respondError(ctx, err)
+		return
+	}
+	if created {
+		c.metrics.UserRegistered()
+	}
+	if user.TOTPEnabled { // Provider tidak menggantikan 2FA yang diaktifkan user
+		response := helper.BuildResponse(true, "Two-factor authentication required", c.mfaService.IssueChallenge(user))
+		ctx.JSON(http.StatusOK, response)
+		return
+	}
+	c.startSession(ctx, user, "")
+}
+
+// setSSOCookie menulis cookie state SSO, maxAge negatif menghapus cookie.
+// SameSite Lax diperlukan agar cookie ikut terkirim saat provider mengarahkan browser kembali ke callback.
+func (c *authController) setSSOCookie(ctx *gin.Context, value string, maxAge int) {
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(ssoStateCookie, value, maxAge, c.ssoCallback.Path, "", c.ssoCallback.Scheme == "https", true)
 }
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// respondError menghentikan request dan menyerahkan err ke middleware ErrorHandler,
+// yang menentukan status HTTP dan kode error dari jenis error domain.
+func respondError(ctx *gin.Context, err error) {
+	middleware.AbortWithError(ctx, err)
+}
+
+// respondBindError menghentikan request yang gagal di-bind ke DTO
+func respondBindError(ctx *gin.Context, err error) {
+	respondError(ctx, apperror.Wrap(apperror.KindInvalid, "invalid request", err).WithCode("invalid_request"))
+}
+
+// currentUserID mengambil ID user dari token yang sudah divalidasi oleh middleware AuthorizeJWT
+func currentUserID(ctx *gin.Context) (uint64, error) {
+	id, err := strconv.ParseUint(ctx.GetString(middleware.ContextUserIDKey), 10, 64)
+	if err != nil {
+		return 0, apperror.Unauthorized("token does not contain a valid user id").WithCode("token_invalid")
+	}
+	return id, nil
+}
+
+// currentSessionID mengambil ID sesi login dari token, 0 jika token tidak memiliki sesi
+func currentSessionID(ctx *gin.Context) uint64 {
+	id, _ := strconv.ParseUint(ctx.GetString(middleware.ContextSessionIDKey), 10, 64)
+	return id
+}
+
+// paramID membaca parameter :id dari URL
+func paramID(ctx *gin.Context) (uint64, error) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return 0, apperror.Invalid("No param id was found").WithCode("invalid_id")
+	}
+	return id, nil
+}
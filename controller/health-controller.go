@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"net/http" // Mengimport package net/http untuk status code HTTP
+	"sort"     // Mengimport package sort untuk mengurutkan pesan error
+	"strings"  // Mengimport package strings untuk menggabungkan pesan error
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/helper"  // Mengimport package helper untuk membangun respons
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service" // Mengimport package service untuk HealthService
+	"github.com/gin-gonic/gin"                               // Mengimport package gin untuk web framework
+)
+
+// HealthController adalah interface yang mendefinisikan method untuk probe liveness dan readiness
+type HealthController interface {
+	Live(context *gin.Context)  // Method untuk meng-handle request GET /healthz
+	Ready(context *gin.Context) // Method untuk meng-handle request GET /readyz
+}
+
+// healthController adalah implementasi dari HealthController
+type healthController struct {
+	healthService service.HealthService // healthService adalah service untuk memeriksa dependency
+}
+
+// NewHealthController membuat instance baru dari HealthController
+func NewHealthController(healthService service.HealthService) HealthController {
+	return &healthController{
+		healthService: healthService,
+	}
+}
+
+// Live adalah method untuk meng-handle probe liveness. Selama proses masih bisa menjawab
+// request, server dianggap hidup, dependency tidak diperiksa agar gangguan database
+// tidak membuat orchestrator me-restart semua pod.
+func (c *healthController) Live(context *gin.Context) {
+	context.JSON(http.StatusOK, helper.BuildResponse(true, "OK!", gin.H{"status": "ok"}))
+}
+
+// Ready adalah method untuk meng-handle probe readiness, status 503 jika ada dependency
+// yang gagal atau server sedang berhenti
+func (c *healthController) Ready(context *gin.Context) {
+	checks, ready := c.healthService.Ready(context.Request.Context())
+	if ready {
+		context.JSON(http.StatusOK, helper.BuildResponse(true, "OK!", checks))
+		return
+	}
+	var failed []string
+	for name, result := range checks {
+		if result != "ok" {
+			failed = append(failed, name+": "+result)
+		}
+	}
+	sort.Strings(failed)
+	res := helper.BuildErrorResponse("Service is not ready", strings.Join(failed, "\n"), checks)
+	res.Code = "not_ready"
+	context.JSON(http.StatusServiceUnavailable, res)
+}
@@ -0,0 +1,32 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service"
+	"github.com/gin-gonic/gin"
+)
+
+// JWKSController adalah interface yang mendefinisikan method untuk mempublikasikan kunci verifikasi JWT
+type JWKSController interface {
+	Keys(ctx *gin.Context) // Method untuk meng-handle request GET /.well-known/jwks.json
+}
+
+// jwksController adalah implementasi dari JWKSController
+type jwksController struct {
+	jwtService service.JWTService // jwtService adalah service yang menyimpan kunci verifikasi JWT
+}
+
+// NewJWKSController membuat instance baru dari JWKSController
+func NewJWKSController(jwtService service.JWTService) JWKSController {
+	return &jwksController{
+		jwtService: jwtService,
+	}
+}
+
+// Keys adalah method untuk meng-handle request daftar kunci publik. Response mengikuti format
+// JWKS standar (bukan helper.Response) agar bisa langsung dibaca oleh library JWT di service lain.
+func (c *jwksController) Keys(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=300") // Mengizinkan service lain meng-cache kunci selama 5 menit
+	ctx.JSON(http.StatusOK, c.jwtService.JWKS())
+}
@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/dto"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/helper"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service"
+	"github.com/gin-gonic/gin"
+)
+
+// MFAController adalah interface yang mendefinisikan method-method yang dapat dipanggil untuk mengelola 2FA user yang login
+type MFAController interface {
+	Status(context *gin.Context)  // Method Status untuk meng-handle request status 2FA
+	Enroll(context *gin.Context)  // Method Enroll untuk meng-handle request memulai enrollment 2FA
+	Confirm(context *gin.Context) // Method Confirm untuk meng-handle request mengaktifkan 2FA dengan kode pertama
+	Disable(context *gin.Context) // Method Disable untuk meng-handle request mematikan 2FA
+}
+
+// mfaController adalah implementasi dari MFAController
+type mfaController struct {
+	mfaService service.MFAService // mfaService adalah service yang digunakan untuk operasi terkait 2FA
+}
+
+// NewMFAController membuat instance baru dari MFAController
+func NewMFAController(mfaService service.MFAService) MFAController {
+	return &mfaController{
+		mfaService: mfaService,
+	}
+}
+
+// Status adalah method untuk meng-handle request status 2FA
+func (c *mfaController) Status(context *gin.Context) {
+	id, err := currentUserID(context) // Mendapatkan ID user dari JWT token
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	status, err := c.mfaService.Status(context.Request.Context(), id) // Memanggil service untuk mendapatkan status 2FA
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	res := helper.BuildResponse(true, "OK!", status) // Membuat response sukses
+	context.JSON(http.StatusOK, res)                 // Mengirimkan response sukses
+}
+
+// Enroll adalah method untuk meng-handle request memulai enrollment 2FA.
+// 2FA belum aktif sampai user mengirimkan kode pertama ke Confirm.
+func (c *mfaController) Enroll(context *gin.Context) {
+	id, err := currentUserID(context) // Mendapatkan ID user dari JWT token
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	enrollment, err := c.mfaService.Enroll(context.Request.Context(), id) // Memanggil service untuk membuat secret TOTP
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	res := helper.BuildResponse(true, "Scan the QR code with an authenticator app, then confirm with a code", enrollment) // Membuat response sukses
+	context.JSON(http.StatusOK, res)                                                                                      // Mengirimkan response sukses
+}
+
+// Confirm adalah method untuk meng-handle request mengaktifkan 2FA. Kode pemulihan hanya ditampilkan pada respons ini.
+func (c *mfaController) Confirm(context *gin.Context) {
+	var codeDTO dto.MFACodeDTO
+	if errDTO := context.ShouldBind(&codeDTO); errDTO != nil {
+		respondBindError(context, errDTO) // Menampilkan response error jika terjadi kesalahan pada DTO
+		return
+	}
+	id, err := currentUserID(context) // Mendapatkan ID user dari JWT token
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	codes, err := c.mfaService.Confirm(context.Request.Context(), id, codeDTO.Code) // Memanggil service untuk mengaktifkan 2FA
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	res := helper.BuildResponse(true, "Two-factor authentication enabled, store the recovery codes in a safe place", dto.MFARecoveryCodesDTO{RecoveryCodes: codes}) // Membuat response sukses
+	context.JSON(http.StatusOK, res)                                                                                                                                // Mengirimkan response sukses
+}
+
+// Disable adalah method untuk meng-handle request mematikan 2FA dengan kode TOTP atau kode pemulihan
+func (c *mfaController) Disable(context *gin.Context) {
+	var codeDTO dto.MFACodeDTO
+	if errDTO := context.ShouldBind(&codeDTO); errDTO != nil {
+		respondBindError(context, errDTO) // Menampilkan response error jika terjadi kesalahan pada DTO
+		return
+	}
+	id, err := currentUserID(context) // Mendapatkan ID user dari JWT token
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	if err := c.mfaService.Disable(context.Request.Context(), id, codeDTO.Code); err != nil { // Memanggil service untuk mematikan 2FA
+		respondError(context, err)
+		return
+	}
+	res := helper.BuildResponse(true, "Two-factor authentication disabled", helper.EmptyObj{}) // Membuat response sukses
+	context.JSON(http.StatusOK, res)                                                           // Mengirimkan response sukses
+}
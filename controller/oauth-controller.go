@@ -0,0 +1,238 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/dto"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/helper"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service"
+	"github.com/gin-gonic/gin"
+)
+
+// OAuthController adalah interface yang mendefinisikan method-method authorization server OAuth2
+type OAuthController interface {
+	Consent(ctx *gin.Context)      // Method untuk meng-handle request data halaman persetujuan
+	Decide(ctx *gin.Context)       // Method untuk meng-handle keputusan user di halaman persetujuan
+	Token(ctx *gin.Context)        // Method untuk meng-handle request token dari client (RFC 6749)
+	Introspect(ctx *gin.Context)   // Method untuk meng-handle request introspection token dari client (RFC 7662)
+	Revoke(ctx *gin.Context)       // Method untuk meng-handle request pencabutan token dari client (RFC 7009)
+	Grants(ctx *gin.Context)       // Method untuk meng-handle request daftar aplikasi yang memiliki akses ke akun user
+	RevokeGrant(ctx *gin.Context)  // Method untuk meng-handle request mencabut akses aplikasi ke akun user
+	Clients(ctx *gin.Context)      // Method untuk meng-handle request daftar client (khusus admin)
+	CreateClient(ctx *gin.Context) // Method untuk meng-handle request mendaftarkan client (khusus admin)
+	DeleteClient(ctx *gin.Context) // Method untuk meng-handle request menghapus client (khusus admin)
+}
+
+// oauthController adalah implementasi dari OAuthController
+type oauthController struct {
+	oauthService service.OAuthService // oauthService adalah service yang digunakan untuk operasi OAuth2
+}
+
+// NewOAuthController membuat instance baru dari OAuthController
+func NewOAuthController(oauthService service.OAuthService) OAuthController {
+	return &oauthController{
+		oauthService: oauthService,
+	}
+}
+
+// Consent adalah method untuk meng-handle request data halaman persetujuan. Frontend meneruskan parameter
+// authorization request dari aplikasi partner bersama token login user, lalu menampilkan aplikasi dan scope yang diminta.
+func (c *oauthController) Consent(ctx *gin.Context) {
+	var authorizeDTO dto.OAuthAuthorizeDTO
+	if errDTO := ctx.ShouldBindQuery(&authorizeDTO); errDTO != nil {
+		respondBindError(ctx, errDTO) // Menampilkan response error jika terjadi kesalahan pada DTO
+		return
+	}
+	consent, err := c.oauthService.Consent(ctx.Request.Context(), authorizeDTO) // Memeriksa authorization request melalui service
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	res := helper.BuildResponse(true, "OK", consent) // Membuat response sukses
+	ctx.JSON(http.StatusOK, res)                     // Mengirimkan response sukses
+}
+
+// Decide adalah method untuk meng-handle keputusan user di halaman persetujuan.
+// Frontend mengarahkan browser ke redirect_uri pada respons, yang berisi code atau error untuk aplikasi partner.
+func (c *oauthController) Decide(ctx *gin.Context) {
+	var decisionDTO dto.OAuthDecisionDTO
+	if errDTO := ctx.ShouldBind(&decisionDTO); errDTO != nil {
+		respondBindError(ctx, errDTO) // Menampilkan response error jika terjadi kesalahan pada DTO
+		return
+	}
+	userID, err := currentUserID(ctx) // Mendapatkan ID user dari JWT token
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	redirectURI, err := c.oauthService.Authorize(ctx.Request.Context(), userID, decisionDTO) // Mencatat keputusan user melalui service
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	res := helper.BuildResponse(true, "OK", dto.OAuthRedirectDTO{RedirectURI: redirectURI}) // Membuat response sukses
+	ctx.JSON(http.StatusOK, res)                                                            // Mengirimkan response sukses
+}
+
+// Token adalah method untuk meng-handle request token dari client. Request dan respons mengikuti format
+// OAuth2 (form-urlencoded dan JSON standar, bukan helper.Response) agar bisa dipakai library OAuth2 mana pun.
+func (c *oauthController) Token(ctx *gin.Context) {
+	var tokenDTO dto.OAuthTokenDTO
+	if errDTO := ctx.ShouldBind(&tokenDTO); errDTO != nil {
+		respondOAuthError(ctx, apperror.Invalid("grant_type is required").WithCode("invalid_request"))
+		return
+	}
+	tokenDTO.ClientID, tokenDTO.ClientSecret = clientCredentials(ctx, tokenDTO.ClientID, tokenDTO.ClientSecret)
+	token, err := c.oauthService.Token(ctx.Request.Context(), tokenDTO) // Menerbitkan token melalui service
+	if err != nil {
+		respondOAuthError(ctx, err)
+		return
+	}
+	ctx.Header("Cache-Control", "no-store") // Token tidak boleh disimpan di cache (RFC 6749 bagian 5.1)
+	ctx.Header("Pragma", "no-cache")
+	ctx.JSON(http.StatusOK, token)
+}
+
+// Introspect adalah method untuk meng-handle request introspection token dari client
+func (c *oauthController) Introspect(ctx *gin.Context) {
+	actionDTO, ok := bindTokenAction(ctx)
+	if !ok {
+		return
+	}
+	result, err := c.oauthService.Introspect(ctx.Request.Context(), actionDTO) // Memeriksa token melalui service
+	if err != nil {
+		respondOAuthError(ctx, err)
+		return
+	}
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(http.StatusOK, result)
+}
+
+// Revoke adalah method untuk meng-handle request pencabutan token dari client, respons kosong dengan status 200 (RFC 7009 bagian 2.2)
+func (c *oauthController) Revoke(ctx *gin.Context) {
+	actionDTO, ok := bindTokenAction(ctx)
+	if !ok {
+		return
+	}
+	if err := c.oauthService.Revoke(ctx.Request.Context(), actionDTO); err != nil { // Mencabut token melalui service
+		respondOAuthError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusOK)
+}
+
+// Grants adalah method untuk meng-handle request daftar aplikasi yang memiliki akses ke akun user
+func (c *oauthController) Grants(ctx *gin.Context) {
+	userID, err := currentUserID(ctx) // Mendapatkan ID user dari JWT token
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	grants, err := c.oauthService.Grants(ctx.Request.Context(), userID) // Mendapatkan aplikasi dengan akses aktif melalui service
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	res := helper.BuildResponse(true, "OK", grants) // Membuat response sukses
+	ctx.JSON(http.StatusOK, res)                    // Mengirimkan response sukses
+}
+
+// RevokeGrant adalah method untuk meng-handle request mencabut akses aplikasi ke akun user
+func (c *oauthController) RevokeGrant(ctx *gin.Context) {
+	userID, err := currentUserID(ctx) // Mendapatkan ID user dari JWT token
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	if err := c.oauthService.RevokeGrant(ctx.Request.Context(), userID, ctx.Param("client_id")); err != nil { // Mencabut akses aplikasi melalui service
+		respondError(ctx, err)
+		return
+	}
+	res := helper.BuildResponse(true, "Access revoked", helper.EmptyObj{}) // Membuat response sukses
+	ctx.JSON(http.StatusOK, res)                                           // Mengirimkan response sukses
+}
+
+// Clients adalah method untuk meng-handle request daftar client
+func (c *oauthController) Clients(ctx *gin.Context) {
+	clients, err := c.oauthService.Clients(ctx.Request.Context()) // Mendapatkan semua client melalui service
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	res := helper.BuildResponse(true, "OK", clients) // Membuat response sukses
+	ctx.JSON(http.StatusOK, res)                     // Mengirimkan response sukses
+}
+
+// CreateClient adalah method untuk meng-handle request mendaftarkan client. Secret hanya ditampilkan pada respons ini.
+func (c *oauthController) CreateClient(ctx *gin.Context) {
+	var clientDTO dto.OAuthClientCreateDTO
+	if errDTO := ctx.ShouldBind(&clientDTO); errDTO != nil {
+		respondBindError(ctx, errDTO) // Menampilkan response error jika terjadi kesalahan pada DTO
+		return
+	}
+	adminID, err := currentUserID(ctx) // Mendapatkan ID admin dari JWT token
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	created, err := c.oauthService.RegisterClient(ctx.Request.Context(), adminID, clientDTO) // Mendaftarkan client melalui service
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	res := helper.BuildResponse(true, "OAuth client created", created) // Membuat response sukses
+	ctx.JSON(http.StatusCreated, res)                                  // Mengirimkan response sukses
+}
+
+// DeleteClient adalah method untuk meng-handle request menghapus client beserta semua token miliknya
+func (c *oauthController) DeleteClient(ctx *gin.Context) {
+	if err := c.oauthService.DeleteClient(ctx.Request.Context(), ctx.Param("client_id")); err != nil { // Menghapus client melalui service
+		respondError(ctx, err)
+		return
+	}
+	res := helper.BuildResponse(true, "Delete", helper.EmptyObj{}) // Membuat response sukses
+	ctx.JSON(http.StatusOK, res)                                   // Mengirimkan response sukses
+}
+
+// bindTokenAction membaca request introspection dan pencabutan token beserta kredensial client
+func bindTokenAction(ctx *gin.Context) (dto.OAuthTokenActionDTO, bool) {
+	var actionDTO dto.OAuthTokenActionDTO
+	if errDTO := ctx.ShouldBind(&actionDTO); errDTO != nil {
+		respondOAuthError(ctx, apperror.Invalid("token is required").WithCode("invalid_request"))
+		return actionDTO, false
+	}
+	actionDTO.ClientID, actionDTO.ClientSecret = clientCredentials(ctx, actionDTO.ClientID, actionDTO.ClientSecret)
+	return actionDTO, true
+}
+
+// clientCredentials mengambil kredensial client dari header HTTP Basic, atau dari body jika header tidak dikirim (RFC 6749 bagian 2.3.1)
+func clientCredentials(ctx *gin.Context, formID string, formSecret string) (string, string) {
+	id, secret, ok := ctx.Request.BasicAuth()
+	if !ok {
+		return formID, formSecret
+	}
+	if unescaped, err := url.QueryUnescape(id); err == nil { // Kredensial di header Basic di-encode form-urlencoded
+		id = unescaped
+	}
+	if unescaped, err := url.QueryUnescape(secret); err == nil {
+		secret = unescaped
+	}
+	return id, secret
+}
+
+// respondOAuthError mengirimkan error dengan format OAuth2 (RFC 6749 bagian 5.2), error internal tetap ditangani ErrorHandler
+func respondOAuthError(ctx *gin.Context, err error) {
+	var appErr *apperror.Error
+	if !errors.As(err, &appErr) || appErr.Kind == apperror.KindInternal {
+		respondError(ctx, err)
+		return
+	}
+	if appErr.Code == "invalid_client" {
+		ctx.Header("WWW-Authenticate", `Basic realm="oauth"`)
+	}
+	ctx.Header("Cache-Control", "no-store")
+	ctx.AbortWithStatusJSON(apperror.HTTPStatus(err), gin.H{"error": apperror.CodeOf(err), "error_description": appErr.Message})
+}
@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/helper"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service"
+	"github.com/gin-gonic/gin"
+)
+
+// SessionController adalah interface yang mendefinisikan method-method yang dapat dipanggil untuk mengelola sesi login user
+type SessionController interface {
+	List(context *gin.Context)   // Method List untuk meng-handle request mendapatkan sesi aktif milik user
+	Revoke(context *gin.Context) // Method Revoke untuk meng-handle request mengakhiri sesi
+}
+
+// sessionController adalah implementasi dari SessionController
+type sessionController struct {
+	sessionService service.SessionService // sessionService adalah service yang digunakan untuk operasi terkait sesi login
+}
+
+// NewSessionController membuat instance baru dari SessionController
+func NewSessionController(sessionService service.SessionService) SessionController {
+	return &sessionController{
+		sessionService: sessionService,
+	}
+}
+
+// List adalah method untuk meng-handle request mendapatkan sesi aktif milik user, sesi yang sedang dipakai ditandai current
+func (c *sessionController) List(context *gin.Context) {
+	id, err := currentUserID(context) // Mendapatkan ID user dari JWT token
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	sessions, err := c.sessionService.List(context.Request.Context(), id, currentSessionID(context)) // Memanggil service untuk mendapatkan sesi aktif
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	res := helper.BuildResponse(true, "OK", sessions) // Membuat response sukses
+	context.JSON(http.StatusOK, res)                  // Mengirimkan response sukses
+}
+
+// Revoke adalah method untuk meng-handle request mengakhiri sesi. Mengakhiri sesi yang sedang dipakai sama dengan logout.
+func (c *sessionController) Revoke(context *gin.Context) {
+	sessionID, err := paramID(context) // Mendapatkan ID sesi dari request
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	id, err := currentUserID(context) // Mendapatkan ID user dari JWT token
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	if err := c.sessionService.Revoke(context.Request.Context(), id, sessionID); err != nil { // Memanggil service untuk mengakhiri sesi
+		respondError(context, err)
+		return
+	}
+	res := helper.BuildResponse(true, "Session revoked", helper.EmptyObj{}) // Membuat response sukses
+	context.JSON(http.StatusOK, res)                                        // Mengirimkan response sukses
+}
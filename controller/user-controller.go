@@ -1,72 +1,111 @@
 package controller
 
 import (
-	"fmt"
 	"net/http"
-	"strconv"
 
 	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/dto"
 	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/helper"
 	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service"
-	"github.com/dgrijalva/jwt-go"
 	"github.com/gin-gonic/gin"
 )
 
 // UserController adalah interface yang mendefinisikan method-method yang dapat dipanggil untuk mengelola user
 type UserController interface {
-	Update(context *gin.Context)  // Method Update untuk meng-handle request update profile user
-	Profile(context *gin.Context) // Method Profile untuk meng-handle request profile user
+	Update(context *gin.Context)         // Method Update untuk meng-handle request update profile user
+	Profile(context *gin.Context)        // Method Profile untuk meng-handle request profile user
+	ChangePassword(context *gin.Context) // Method ChangePassword untuk meng-handle request ganti password
+	ChangeEmail(context *gin.Context)    // Method ChangeEmail untuk meng-handle request ganti email
 }
 
 // userController adalah implementasi dari UserController
 type userController struct {
 	userService service.UserService // userService adalah service yang digunakan untuk operasi terkait user
-	jwtService  service.JWTService  // jwtService adalah service yang digunakan untuk operasi terkait JWT
 }
 
 // NewUserController membuat instance baru dari UserController
-func NewUserController(userService service.UserService, jwtService service.JWTService) UserController {
+func NewUserController(userService service.UserService) UserController {
 	return &userController{
 		userService: userService,
-		jwtService:  jwtService,
 	}
 }
 
 // Update adalah method untuk meng-handle request update profile user
 func (c *userController) Update(context *gin.Context) {
 	var userUpdateDTO dto.UserUpdateDTO
-	errDTO := context.ShouldBind(&userUpdateDTO)
-	if errDTO != nil {
-		res := helper.BuildErrorResponse("Failed to process request", errDTO.Error(), helper.EmptyObj{})
-		context.AbortWithStatusJSON(http.StatusBadRequest, res) // Menampilkan response error bad request jika terjadi kesalahan pada DTO
+	if errDTO := context.ShouldBind(&userUpdateDTO); errDTO != nil {
+		respondBindError(context, errDTO) // Menampilkan response error bad request jika terjadi kesalahan pada DTO
 		return
 	}
-	authHeader := context.GetHeader("Authorization")
-	token, errToken := c.jwtService.ValidateToken(authHeader)
-	if errToken != nil {
-		panic(errToken.Error()) // Panic jika terjadi kesalahan pada validasi token
-	}
-	claims := token.Claims.(jwt.MapClaims)
-	id, err := strconv.ParseUint(fmt.Sprintf("%v", claims["user_id"]), 10, 64)
+	id, err := currentUserID(context) // Mendapatkan ID user dari JWT token
 	if err != nil {
-		panic(err.Error()) // Panic jika terjadi kesalahan pada parsing user ID
+		respondError(context, err)
+		return
 	}
 	userUpdateDTO.ID = id
-	u := c.userService.Update(userUpdateDTO)    // Memanggil service untuk melakukan update user
-	res := helper.BuildResponse(true, "OK!", u) // Membuat response sukses
-	context.JSON(http.StatusOK, res)            // Mengirimkan response sukses
+	u, err := c.userService.Update(context.Request.Context(), userUpdateDTO) // Memanggil service untuk melakukan update user
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	res := helper.BuildResponse(true, "OK!", dto.NewUserResponse(u)) // Membuat response sukses
+	context.JSON(http.StatusOK, res)                                 // Mengirimkan response sukses
+}
+
+// ChangePassword adalah method untuk meng-handle request ganti password.
+// Semua sesi dicabut termasuk sesi saat ini, sehingga user harus login ulang dengan password baru.
+func (c *userController) ChangePassword(context *gin.Context) {
+	var changeDTO dto.ChangePasswordDTO
+	if errDTO := context.ShouldBind(&changeDTO); errDTO != nil {
+		respondBindError(context, errDTO)
+		return
+	}
+	id, err := currentUserID(context) // Mendapatkan ID user dari JWT token
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	if err := c.userService.ChangePassword(context.Request.Context(), id, changeDTO.CurrentPassword, changeDTO.NewPassword); err != nil {
+		respondError(context, err)
+		return
+	}
+	res := helper.BuildResponse(true, "Password has been changed, please log in again", helper.EmptyObj{})
+	context.JSON(http.StatusOK, res)
+}
+
+// ChangeEmail adalah method untuk meng-handle request ganti email.
+// Semua sesi dicabut termasuk sesi saat ini, dan link verifikasi dikirim ke email baru.
+func (c *userController) ChangeEmail(context *gin.Context) {
+	var changeDTO dto.ChangeEmailDTO
+	if errDTO := context.ShouldBind(&changeDTO); errDTO != nil {
+		respondBindError(context, errDTO)
+		return
+	}
+	id, err := currentUserID(context) // Mendapatkan ID user dari JWT token
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	u, err := c.userService.ChangeEmail(context.Request.Context(), id, changeDTO.CurrentPassword, changeDTO.Email)
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	res := helper.BuildResponse(true, "OK!", dto.NewUserResponse(u))
+	context.JSON(http.StatusOK, res)
 }
 
 // Profile adalah method untuk meng-handle request profile user
 func (c *userController) Profile(context *gin.Context) {
-	authHeader := context.GetHeader("Authorization")
-	token, errToken := c.jwtService.ValidateToken(authHeader)
-	if errToken != nil {
-		panic(errToken.Error()) // Panic jika terjadi kesalahan pada validasi token
+	id, err := currentUserID(context) // Mendapatkan ID user dari JWT token
+	if err != nil {
+		respondError(context, err)
+		return
+	}
+	user, err := c.userService.Profile(context.Request.Context(), id) // Memanggil service untuk mendapatkan profile user
+	if err != nil {
+		respondError(context, err)
+		return
 	}
-	claims := token.Claims.(jwt.MapClaims)
-	id := fmt.Sprintf("%v", claims["user_id"])
-	user := c.userService.Profile(id)              // Memanggil service untuk mendapatkan profile user
-	res := helper.BuildResponse(true, "OK!", user) // Membuat response sukses
-	context.JSON(http.StatusOK, res)               // Mengirimkan response sukses
+	res := helper.BuildResponse(true, "OK!", dto.NewUserResponse(user)) // Membuat response sukses
+	context.JSON(http.StatusOK, res)                                    // Mengirimkan response sukses
 }
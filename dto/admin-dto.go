@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"time" // Mengimport package time untuk batas waktu penguncian akun dan verifikasi email
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity" // Mengimport package entity untuk model entitas
+)
+
+// UserRoleDTO digunakan oleh admin saat mengubah role user menggunakan metode PUT
+type UserRoleDTO struct {
+	Role string `json:"role" form:"role" binding:"required,oneof=reader editor admin"` // Role adalah role baru user (wajib diisi: reader, editor, atau admin)
+}
+
+// UserSuspendDTO digunakan oleh admin saat memblokir atau membuka blokir user menggunakan metode PUT
+type UserSuspendDTO struct {
+	Suspended *bool `json:"suspended" form:"suspended" binding:"required"` // Suspended adalah status blokir baru user (wajib diisi)
+}
+
+// AdminBookUpdateDTO digunakan oleh admin saat mengubah buku milik user lain, ID buku diambil dari URL
+type AdminBookUpdateDTO struct {
+	Title       string `json:"title" form:"title" binding:"required"`             // Title adalah judul baru dari buku (wajib diisi)
+	Description string `json:"description" form:"description" binding:"required"` // Description adalah deskripsi baru dari buku (wajib diisi)
+}
+
+// AdminUserDTO adalah data user beserta status akunnya yang hanya dikirimkan kepada admin
+type AdminUserDTO struct {
+	ID              uint64     `json:"id"`                          // ID adalah identitas unik dari user
+	Name            string     `json:"name"`                        // Name adalah nama lengkap dari user
+	Email           string     `json:"email"`                       // Email adalah alamat email dari user
+	Role            string     `json:"role"`                        // Role adalah hak akses user (reader, editor, atau admin)
+	Suspended       bool       `json:"suspended"`                   // Suspended menandakan user diblokir oleh admin
+	LockedUntil     *time.Time `json:"locked_until,omitempty"`      // LockedUntil adalah batas waktu akun dikunci karena terlalu banyak login gagal
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"` // EmailVerifiedAt adalah waktu email diverifikasi, kosong berarti belum terverifikasi
+	TOTPEnabled     bool       `json:"totp_enabled"`                // TOTPEnabled menandakan 2FA aktif pada akun
+}
+
+// NewAdminUser membuat AdminUserDTO dari entitas user
+func NewAdminUser(u entity.User) AdminUserDTO {
+	return AdminUserDTO{
+		ID:              u.ID,
+		Name:            u.Name,
+		Email:           u.Email,
+		Role:            u.Role,
+		Suspended:       u.Suspended,
+		LockedUntil:     u.LockedUntil,
+		EmailVerifiedAt: u.EmailVerifiedAt,
+		TOTPEnabled:     u.TOTPEnabled,
+	}
+}
+
+// NewAdminUsers membuat daftar AdminUserDTO dari daftar entitas user
+func NewAdminUsers(users []entity.User) []AdminUserDTO {
+	responses := make([]AdminUserDTO, 0, len(users))
+	for _, u := range users {
+		responses = append(responses, NewAdminUser(u))
+	}
+	return responses
+}
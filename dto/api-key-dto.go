@@ -0,0 +1,20 @@
+package dto
+
+import (
+	"time" // Mengimport package time untuk waktu kedaluwarsa API key
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity" // Mengimport package entity untuk model entitas
+)
+
+// APIKeyCreateDTO digunakan saat client melakukan POST dari URL /user/api-keys
+type APIKeyCreateDTO struct {
+	Name      string     `json:"name" form:"name" binding:"required,max=100"`                          // Name adalah nama API key (wajib diisi)
+	Scopes    []string   `json:"scopes" form:"scopes" binding:"required,min=1"`                        // Scopes adalah hak akses API key, misalnya books:read (wajib diisi)
+	ExpiresAt *time.Time `json:"expires_at" form:"expires_at" time_format:"2006-01-02T15:04:05Z07:00"` // ExpiresAt adalah waktu kedaluwarsa API key dalam format RFC 3339, kosong berarti tidak kedaluwarsa
+}
+
+// APIKeyCreatedDTO adalah model yang dikirimkan ke client setelah API key dibuat. Key hanya ditampilkan sekali.
+type APIKeyCreatedDTO struct {
+	APIKey entity.APIKey `json:"api_key"` // APIKey adalah data API key yang tersimpan
+	Key    string        `json:"key"`     // Key adalah API key yang dikirim di header X-API-Key
+}
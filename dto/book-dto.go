@@ -1,5 +1,11 @@
 package dto
 
+import (
+	"time" // Mengimport package time untuk waktu buku dibuat dan diubah
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity" // Mengimport package entity untuk model entitas
+)
+
 // BookUpdateDTO adalah model yang digunakan oleh client saat melakukan update pada buku
 type BookUpdateDTO struct {
 	ID          uint64 `json:"id" binding:"required"`                             // ID adalah identitas unik dari buku yang akan diupdate (wajib diisi)
@@ -14,3 +20,41 @@ type BookCreateDTO struct {
 	Description string `json:"description" form:"description" binding:"required"` // Description adalah deskripsi dari buku yang akan dibuat (wajib diisi)
 	UserID      uint64 `json:"user_id,omitempty" form:"user_id,omitempty"`        // UserID adalah ID user yang membuat buku (opsional, bisa kosong)
 }
+
+// BookOwnerDTO adalah data publik pemilik buku yang ikut dikirim bersama buku
+type BookOwnerDTO struct {
+	ID   uint64 `json:"id"`   // ID adalah identitas unik dari pemilik buku
+	Name string `json:"name"` // Name adalah nama pemilik buku
+}
+
+// BookResponseDTO adalah model buku yang dikirimkan ke client.
+// Pemilik hanya ditampilkan ID dan namanya karena daftar buku bisa dibaca oleh siapa pun yang punya akses books:read.
+type BookResponseDTO struct {
+	ID          uint64       `json:"ID"`          // ID adalah identitas unik dari buku (nama field sama seperti respons sebelumnya)
+	Title       string       `json:"title"`       // Title adalah judul dari buku
+	Description string       `json:"description"` // Description adalah deskripsi atau konten dari buku
+	User        BookOwnerDTO `json:"user"`        // User adalah pemilik buku
+	CreatedAt   time.Time    `json:"created_at"`  // CreatedAt adalah waktu buku dibuat
+	UpdatedAt   time.Time    `json:"updated_at"`  // UpdatedAt adalah waktu terakhir buku diubah
+}
+
+// NewBookResponse membuat BookResponseDTO dari entitas buku
+func NewBookResponse(b entity.Book) BookResponseDTO {
+	return BookResponseDTO{
+		ID:          b.ID,
+		Title:       b.Title,
+		Description: b.Description,
+		User:        BookOwnerDTO{ID: b.User.ID, Name: b.User.Name},
+		CreatedAt:   b.CreatedAt,
+		UpdatedAt:   b.UpdatedAt,
+	}
+}
+
+// NewBookResponses membuat daftar BookResponseDTO dari daftar entitas buku
+func NewBookResponses(books []entity.Book) []BookResponseDTO {
+	responses := make([]BookResponseDTO, 0, len(books))
+	for _, b := range books {
+		responses = append(responses, NewBookResponse(b))
+	}
+	return responses
+}
@@ -0,0 +1,13 @@
+package dto
+
+// BookQueryDTO adalah model query string yang digunakan oleh client saat mengambil daftar buku
+type BookQueryDTO struct {
+	Page        int    `form:"page" binding:"omitempty,min=1"`             // Page adalah nomor halaman (dimulai dari 1, default 1)
+	PerPage     int    `form:"per_page" binding:"omitempty,min=1,max=100"` // PerPage adalah jumlah buku per halaman (default 20, maksimal 100)
+	Cursor      string `form:"cursor"`                                     // Cursor adalah next_cursor dari response sebelumnya, jika diisi maka page diabaikan
+	Title       string `form:"title"`                                      // Title adalah filter judul buku yang mengandung teks ini
+	OwnerID     uint64 `form:"owner_id"`                                   // OwnerID adalah filter ID user pemilik buku
+	CreatedFrom string `form:"created_from"`                               // CreatedFrom adalah batas awal tanggal dibuat (YYYY-MM-DD atau RFC 3339)
+	CreatedTo   string `form:"created_to"`                                 // CreatedTo adalah batas akhir tanggal dibuat (YYYY-MM-DD atau RFC 3339, inklusif)
+	Sort        string `form:"sort"`                                       // Sort adalah urutan data, misalnya "title,-created_at" (awalan - untuk descending)
+}
@@ -0,0 +1,15 @@
+package dto
+
+// BookSearchDTO adalah model query string yang digunakan oleh client saat mencari buku
+type BookSearchDTO struct {
+	Q       string `form:"q" binding:"required"`                       // Q adalah kata kunci pencarian pada judul dan deskripsi (wajib diisi)
+	Page    int    `form:"page" binding:"omitempty,min=1"`             // Page adalah nomor halaman hasil pencarian (default 1)
+	PerPage int    `form:"per_page" binding:"omitempty,min=1,max=100"` // PerPage adalah jumlah hasil per halaman (default 20, maksimal 100)
+}
+
+// BookSearchResultDTO adalah satu hasil pencarian buku yang dikirimkan ke client
+type BookSearchResultDTO struct {
+	Book       BookResponseDTO     `json:"book"`       // Book adalah buku yang cocok dengan pencarian
+	Score      float64             `json:"score"`      // Score adalah nilai relevansi, semakin besar semakin relevan
+	Highlights map[string][]string `json:"highlights"` // Highlights adalah potongan teks per field dengan kata yang cocok ditandai <mark>
+}
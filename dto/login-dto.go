@@ -1,7 +1,17 @@
 package dto
 
+import (
+	"log/slog" // Mengimport package slog agar password tidak pernah tercatat di log
+)
+
 // LoginDTO adalah model yang digunakan oleh client saat melakukan POST dari URL /login
 type LoginDTO struct {
-	Email    string `json:"email" form:"email" binding:"required"`       // Email adalah alamat email yang digunakan untuk login (wajib diisi)
-	Password string `json:"password" form:"password" binding:"required"` // Password adalah kata sandi yang digunakan untuk login (wajib diisi)
+	Email      string `json:"email" form:"email" binding:"required"`            // Email adalah alamat email yang digunakan untuk login (wajib diisi)
+	Password   string `json:"password" form:"password" binding:"required"`      // Password adalah kata sandi yang digunakan untuk login (wajib diisi)
+	DeviceName string `json:"device_name" form:"device_name" binding:"max=100"` // DeviceName adalah nama perangkat yang ditampilkan di daftar sesi, misalnya "Laptop kantor"
+}
+
+// LogValue adalah implementasi slog.LogValuer agar password tidak tercatat jika DTO ditulis ke log
+func (d LoginDTO) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("email", d.Email), slog.String("password", "[REDACTED]"))
 }
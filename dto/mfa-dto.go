@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"log/slog" // Mengimport package slog agar kode 2FA dan token tidak pernah tercatat di log
+)
+
+// MFACodeDTO digunakan saat client melakukan POST dari URL /user/mfa/confirm dan /user/mfa/disable
+type MFACodeDTO struct {
+	Code string `json:"code" form:"code" binding:"required"` // Code adalah kode TOTP dari aplikasi authenticator atau kode pemulihan (wajib diisi)
+}
+
+// LogValue adalah implementasi slog.LogValuer agar kode tidak tercatat jika DTO ditulis ke log
+func (d MFACodeDTO) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("code", "[REDACTED]"))
+}
+
+// MFALoginDTO digunakan saat client melakukan POST dari URL /login/mfa
+type MFALoginDTO struct {
+	MFAToken   string `json:"mfa_token" form:"mfa_token" binding:"required"`    // MFAToken adalah token yang diterima dari /login (wajib diisi)
+	Code       string `json:"code" form:"code" binding:"required"`              // Code adalah kode TOTP atau kode pemulihan (wajib diisi)
+	DeviceName string `json:"device_name" form:"device_name" binding:"max=100"` // DeviceName adalah nama perangkat yang ditampilkan di daftar sesi
+}
+
+// LogValue adalah implementasi slog.LogValuer agar token dan kode tidak tercatat jika DTO ditulis ke log
+func (d MFALoginDTO) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("mfa_token", "[REDACTED]"), slog.String("code", "[REDACTED]"))
+}
+
+// MFAChallengeDTO adalah model yang dikirimkan ke client dari /login ketika user harus memasukkan kode 2FA
+type MFAChallengeDTO struct {
+	MFARequired bool   `json:"mfa_required"` // MFARequired selalu true, menandakan login belum selesai
+	MFAToken    string `json:"mfa_token"`    // MFAToken adalah token sementara yang ditukar dengan access token di /login/mfa
+	ExpiresIn   int64  `json:"expires_in"`   // ExpiresIn adalah masa berlaku MFAToken dalam detik
+}
+
+// MFAEnrollmentDTO adalah model yang dikirimkan ke client setelah enrollment 2FA dimulai
+type MFAEnrollmentDTO struct {
+	Secret string `json:"secret"`  // Secret adalah secret TOTP dalam base32 untuk dimasukkan manual ke aplikasi authenticator
+	URI    string `json:"uri"`     // URI adalah provisioning URI otpauth://
+	QRCode string `json:"qr_code"` // QRCode adalah gambar PNG berisi URI dalam bentuk data URL
+}
+
+// MFAStatusDTO adalah model yang dikirimkan ke client untuk menampilkan status 2FA
+type MFAStatusDTO struct {
+	Enabled                bool  `json:"enabled"`                  // Enabled menandakan 2FA sudah aktif
+	Pending                bool  `json:"pending"`                  // Pending menandakan enrollment sudah dimulai tetapi belum dikonfirmasi
+	RecoveryCodesRemaining int64 `json:"recovery_codes_remaining"` // RecoveryCodesRemaining adalah jumlah kode pemulihan yang belum dipakai
+}
+
+// MFARecoveryCodesDTO adalah model yang dikirimkan ke client setelah 2FA dikonfirmasi. Kode hanya ditampilkan sekali.
+type MFARecoveryCodesDTO struct {
+	RecoveryCodes []string `json:"recovery_codes"` // RecoveryCodes adalah daftar kode pemulihan sekali pakai
+}
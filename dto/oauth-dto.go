@@ -0,0 +1,109 @@
+package dto
+
+import (
+	"log/slog" // Mengimport package slog agar secret, code, dan token tidak pernah tercatat di log
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity" // Mengimport package entity untuk model entitas
+)
+
+// OAuthClientCreateDTO digunakan saat admin melakukan POST dari URL /admin/oauth/clients
+type OAuthClientCreateDTO struct {
+	Name         string   `json:"name" form:"name" binding:"required,max=100"`                               // Name adalah nama aplikasi yang ditampilkan di halaman persetujuan (wajib diisi)
+	RedirectURIs []string `json:"redirect_uris" form:"redirect_uris" binding:"required,min=1,dive,required"` // RedirectURIs adalah URL callback aplikasi (wajib diisi)
+	Scopes       []string `json:"scopes" form:"scopes" binding:"required,min=1"`                             // Scopes adalah scope maksimum yang boleh diminta aplikasi (wajib diisi)
+	Public       bool     `json:"public" form:"public"`                                                      // Public menandakan aplikasi tidak bisa menyimpan secret, misalnya aplikasi mobile
+}
+
+// OAuthClientCreatedDTO adalah model yang dikirimkan ke admin setelah client didaftarkan. Secret hanya ditampilkan sekali.
+type OAuthClientCreatedDTO struct {
+	Client       entity.OAuthClient `json:"client"`                  // Client adalah data client yang tersimpan
+	ClientSecret string             `json:"client_secret,omitempty"` // ClientSecret adalah secret client, kosong untuk public client
+}
+
+// OAuthAuthorizeDTO berisi parameter authorization request (RFC 6749 bagian 4.1.1 dan RFC 7636) yang diteruskan frontend dari aplikasi partner
+type OAuthAuthorizeDTO struct {
+	ResponseType        string `json:"response_type" form:"response_type" binding:"required"`   // ResponseType harus "code" (wajib diisi)
+	ClientID            string `json:"client_id" form:"client_id" binding:"required"`           // ClientID adalah identitas publik client (wajib diisi)
+	RedirectURI         string `json:"redirect_uri" form:"redirect_uri" binding:"required"`     // RedirectURI harus sama persis dengan salah satu URL callback client (wajib diisi)
+	Scope               string `json:"scope" form:"scope"`                                      // Scope adalah scope yang diminta dipisahkan spasi, kosong berarti semua scope client
+	State               string `json:"state" form:"state"`                                      // State dikembalikan apa adanya ke client
+	CodeChallenge       string `json:"code_challenge" form:"code_challenge" binding:"required"` // CodeChallenge adalah PKCE code challenge (wajib diisi)
+	CodeChallengeMethod string `json:"code_challenge_method" form:"code_challenge_method"`      // CodeChallengeMethod harus "S256"
+}
+
+// OAuthDecisionDTO digunakan saat user menyetujui atau menolak akses client dengan POST dari URL /oauth/authorize
+type OAuthDecisionDTO struct {
+	OAuthAuthorizeDTO
+	Approve *bool `json:"approve" form:"approve" binding:"required"` // Approve adalah keputusan user (wajib diisi)
+}
+
+// OAuthScopeDTO adalah scope beserta penjelasannya untuk ditampilkan di halaman persetujuan
+type OAuthScopeDTO struct {
+	Scope       string `json:"scope"`       // Scope adalah nama scope, misalnya books:read
+	Description string `json:"description"` // Description adalah penjelasan scope untuk user
+}
+
+// OAuthConsentDTO adalah model yang dikirimkan ke frontend untuk menampilkan halaman persetujuan
+type OAuthConsentDTO struct {
+	ClientID    string          `json:"client_id"`    // ClientID adalah identitas publik client
+	ClientName  string          `json:"client_name"`  // ClientName adalah nama aplikasi yang meminta akses
+	Scopes      []OAuthScopeDTO `json:"scopes"`       // Scopes adalah scope yang diminta
+	RedirectURI string          `json:"redirect_uri"` // RedirectURI adalah URL tujuan setelah user memutuskan
+}
+
+// OAuthRedirectDTO adalah model yang dikirimkan ke frontend setelah user memutuskan, frontend mengarahkan browser ke RedirectURI
+type OAuthRedirectDTO struct {
+	RedirectURI string `json:"redirect_uri"` // RedirectURI adalah URL callback client berisi code atau error dan state
+}
+
+// OAuthTokenDTO digunakan saat client melakukan POST dari URL /oauth/token (RFC 6749 bagian 4.1.3 dan 6)
+type OAuthTokenDTO struct {
+	GrantType    string `form:"grant_type" binding:"required"` // GrantType adalah "authorization_code" atau "refresh_token" (wajib diisi)
+	Code         string `form:"code"`                          // Code adalah authorization code untuk grant authorization_code
+	RedirectURI  string `form:"redirect_uri"`                  // RedirectURI harus sama dengan redirect_uri saat code diminta
+	CodeVerifier string `form:"code_verifier"`                 // CodeVerifier adalah PKCE code verifier untuk grant authorization_code
+	RefreshToken string `form:"refresh_token"`                 // RefreshToken adalah refresh token untuk grant refresh_token
+	Scope        string `form:"scope"`                         // Scope adalah scope yang lebih sempit untuk grant refresh_token, kosong berarti scope yang sama
+	ClientID     string `form:"client_id"`                     // ClientID diisi jika client tidak memakai HTTP Basic authentication
+	ClientSecret string `form:"client_secret"`                 // ClientSecret diisi jika client tidak memakai HTTP Basic authentication
+}
+
+// LogValue adalah implementasi slog.LogValuer agar code, token, dan secret tidak tercatat jika DTO ditulis ke log
+func (d OAuthTokenDTO) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("grant_type", d.GrantType), slog.String("client_id", d.ClientID), slog.String("code", "[REDACTED]"),
+		slog.String("code_verifier", "[REDACTED]"), slog.String("refresh_token", "[REDACTED]"), slog.String("client_secret", "[REDACTED]"))
+}
+
+// OAuthTokenResponseDTO adalah respons sukses endpoint token (RFC 6749 bagian 5.1)
+type OAuthTokenResponseDTO struct {
+	AccessToken  string `json:"access_token"`  // AccessToken adalah JWT yang dibatasi scope
+	TokenType    string `json:"token_type"`    // TokenType selalu "Bearer"
+	ExpiresIn    int64  `json:"expires_in"`    // ExpiresIn adalah masa berlaku access token dalam detik
+	RefreshToken string `json:"refresh_token"` // RefreshToken dipakai untuk mendapatkan access token baru
+	Scope        string `json:"scope"`         // Scope adalah scope yang diberikan, dipisahkan spasi
+}
+
+// OAuthTokenActionDTO digunakan saat client melakukan POST dari URL /oauth/introspect (RFC 7662) dan /oauth/revoke (RFC 7009)
+type OAuthTokenActionDTO struct {
+	Token         string `form:"token" binding:"required"` // Token adalah access token atau refresh token (wajib diisi)
+	TokenTypeHint string `form:"token_type_hint"`          // TokenTypeHint adalah "access_token" atau "refresh_token"
+	ClientID      string `form:"client_id"`                // ClientID diisi jika client tidak memakai HTTP Basic authentication
+	ClientSecret  string `form:"client_secret"`            // ClientSecret diisi jika client tidak memakai HTTP Basic authentication
+}
+
+// LogValue adalah implementasi slog.LogValuer agar token dan secret tidak tercatat jika DTO ditulis ke log
+func (d OAuthTokenActionDTO) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("token", "[REDACTED]"), slog.String("token_type_hint", d.TokenTypeHint),
+		slog.String("client_id", d.ClientID), slog.String("client_secret", "[REDACTED]"))
+}
+
+// OAuthIntrospectionDTO adalah respons endpoint introspection (RFC 7662 bagian 2.2). Token yang tidak aktif hanya berisi Active.
+type OAuthIntrospectionDTO struct {
+	Active    bool   `json:"active"`               // Active menandakan token masih berlaku
+	Scope     string `json:"scope,omitempty"`      // Scope adalah scope token, dipisahkan spasi
+	ClientID  string `json:"client_id,omitempty"`  // ClientID adalah client pemegang token
+	Sub       string `json:"sub,omitempty"`        // Sub adalah ID user yang memberi akses
+	TokenType string `json:"token_type,omitempty"` // TokenType adalah "access_token" atau "refresh_token"
+	Exp       int64  `json:"exp,omitempty"`        // Exp adalah waktu kedaluwarsa token dalam detik sejak epoch
+	Iat       int64  `json:"iat,omitempty"`        // Iat adalah waktu token diterbitkan dalam detik sejak epoch
+}
@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"log/slog" // Mengimport package slog agar token dan password tidak pernah tercatat di log
+)
+
+// ForgotPasswordDTO digunakan saat client melakukan POST dari URL /forgot-password
+type ForgotPasswordDTO struct {
+	Email string `json:"email" form:"email" binding:"required,email"` // Email adalah alamat email akun yang ingin dipulihkan (wajib diisi)
+}
+
+// ResetPasswordDTO digunakan saat client melakukan POST dari URL /reset-password
+type ResetPasswordDTO struct {
+	Token    string `json:"token" form:"token" binding:"required"`       // Token adalah token reset password yang diterima lewat email (wajib diisi)
+	Password string `json:"password" form:"password" binding:"required"` // Password adalah kata sandi baru (wajib diisi)
+}
+
+// LogValue adalah implementasi slog.LogValuer agar token dan password tidak tercatat jika DTO ditulis ke log
+func (d ResetPasswordDTO) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("token", "[REDACTED]"), slog.String("password", "[REDACTED]"))
+}
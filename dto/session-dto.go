@@ -0,0 +1,8 @@
+package dto
+
+// SessionDeviceDTO berisi informasi perangkat yang dicatat saat sesi login dibuat
+type SessionDeviceDTO struct {
+	DeviceName string // DeviceName adalah nama perangkat yang dikirim client, boleh kosong
+	UserAgent  string // UserAgent adalah header User-Agent dari request login
+	IPAddress  string // IPAddress adalah alamat IP client dari request login
+}
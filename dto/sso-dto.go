@@ -0,0 +1,15 @@
+package dto
+
+// SSOCallbackDTO digunakan saat identity provider mengarahkan browser kembali ke GET /sso/:provider/callback
+type SSOCallbackDTO struct {
+	Code             string `form:"code"`              // Code adalah authorization code yang ditukar dengan token di provider
+	State            string `form:"state"`             // State harus sama dengan state yang disimpan di cookie saat login dimulai
+	Error            string `form:"error"`             // Error diisi provider jika user menolak login atau terjadi kesalahan di provider
+	ErrorDescription string `form:"error_description"` // ErrorDescription adalah penjelasan Error dari provider
+}
+
+// SSOProviderDTO adalah identity provider yang bisa dipakai untuk login
+type SSOProviderDTO struct {
+	Name     string `json:"name"`      // Name adalah nama provider, misalnya "google"
+	LoginURL string `json:"login_url"` // LoginURL adalah endpoint yang memulai login dengan provider tersebut
+}
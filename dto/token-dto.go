@@ -0,0 +1,18 @@
+package dto
+
+// RefreshTokenDTO digunakan saat client melakukan POST dari URL /refresh
+type RefreshTokenDTO struct {
+	RefreshToken string `json:"refresh_token" form:"refresh_token" binding:"required"` // RefreshToken adalah refresh token yang diterima saat login (wajib diisi)
+}
+
+// TokenResponseDTO adalah model yang dikirimkan ke client setelah token berhasil diperbarui
+type TokenResponseDTO struct {
+	Token        string `json:"token"`         // Token adalah access token JWT yang baru
+	RefreshToken string `json:"refresh_token"` // RefreshToken adalah refresh token pengganti yang harus dipakai pada request berikutnya
+	ExpiresIn    int64  `json:"expires_in"`    // ExpiresIn adalah masa berlaku access token dalam detik
+}
+
+// LogoutDTO digunakan saat client melakukan POST dari URL /logout
+type LogoutDTO struct {
+	RefreshToken string `json:"refresh_token" form:"refresh_token"` // RefreshToken adalah refresh token sesi yang ikut dicabut (opsional)
+}
@@ -1,11 +1,71 @@
 package dto
 
-// UserUpdateDTO digunakan oleh client saat melakukan update profile menggunakan metode PUT
+import (
+	"log/slog" // Mengimport package slog agar password tidak pernah tercatat di log
+	"time"     // Mengimport package time untuk waktu verifikasi email
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity" // Mengimport package entity untuk model entitas
+)
+
+// UserUpdateDTO digunakan oleh client saat melakukan update profile menggunakan metode PUT.
+// Email dan password tidak bisa diubah di sini karena endpoint profil juga menerima API key dan token aplikasi OAuth2.
 type UserUpdateDTO struct {
-	ID       uint64 `json:"id" form:"id"`                                                    // ID adalah identitas unik dari user yang akan diupdate
-	Name     string `json:"name" form:"name" binding:"required"`                             // Name adalah nama lengkap user yang akan diupdate (wajib diisi)
-	Email    string `json:"email" form:"email" binding:"required,email"`                     // Email adalah alamat email user yang akan diupdate (wajib diisi dan harus sesuai format email)
-	Password string `json:"password,omitempty" form:"password,omitempty" binding:"required"` // Password adalah kata sandi user yang akan diupdate (wajib diisi)
+	ID   uint64 `json:"id" form:"id"`                        // ID adalah identitas unik dari user yang akan diupdate
+	Name string `json:"name" form:"name" binding:"required"` // Name adalah nama lengkap user yang akan diupdate (wajib diisi)
+}
+
+// ChangePasswordDTO digunakan oleh client saat mengganti password menggunakan metode PUT
+type ChangePasswordDTO struct {
+	CurrentPassword string `json:"current_password" form:"current_password" binding:"required"` // CurrentPassword adalah kata sandi saat ini (wajib diisi)
+	NewPassword     string `json:"new_password" form:"new_password" binding:"required"`         // NewPassword adalah kata sandi baru (wajib diisi)
+}
+
+// LogValue adalah implementasi slog.LogValuer agar password tidak tercatat jika DTO ditulis ke log
+func (d ChangePasswordDTO) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("current_password", "[REDACTED]"), slog.String("new_password", "[REDACTED]"))
+}
+
+// ChangeEmailDTO digunakan oleh client saat mengganti email menggunakan metode PUT
+type ChangeEmailDTO struct {
+	CurrentPassword string `json:"current_password" form:"current_password" binding:"required"` // CurrentPassword adalah kata sandi saat ini (wajib diisi)
+	Email           string `json:"email" form:"email" binding:"required,email"`                 // Email adalah alamat email baru (wajib diisi dan harus sesuai format email)
+}
+
+// LogValue adalah implementasi slog.LogValuer agar password tidak tercatat jika DTO ditulis ke log
+func (d ChangeEmailDTO) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("email", d.Email), slog.String("current_password", "[REDACTED]"))
+}
+
+// UserResponseDTO adalah data akun yang dikirimkan kepada pemilik akun, misalnya saat login atau membuka profil
+type UserResponseDTO struct {
+	ID              uint64             `json:"id"`                          // ID adalah identitas unik dari user
+	Name            string             `json:"name"`                        // Name adalah nama lengkap dari user
+	Email           string             `json:"email"`                       // Email adalah alamat email dari user
+	Role            string             `json:"role"`                        // Role adalah hak akses user (reader, editor, atau admin)
+	EmailVerifiedAt *time.Time         `json:"email_verified_at,omitempty"` // EmailVerifiedAt adalah waktu email diverifikasi, kosong berarti belum terverifikasi
+	TOTPEnabled     bool               `json:"totp_enabled"`                // TOTPEnabled menandakan 2FA aktif pada akun
+	Token           string             `json:"token,omitempty"`             // Token adalah token JWT yang diterbitkan saat login
+	RefreshToken    string             `json:"refresh_token,omitempty"`     // RefreshToken adalah refresh token yang diterbitkan bersama token JWT
+	Books           *[]BookResponseDTO `json:"books,omitempty"`             // Books adalah daftar buku milik user, hanya terisi pada profil
+}
+
+// NewUserResponse membuat UserResponseDTO dari entitas user
+func NewUserResponse(u entity.User) UserResponseDTO {
+	res := UserResponseDTO{
+		ID:              u.ID,
+		Name:            u.Name,
+		Email:           u.Email,
+		Role:            u.Role,
+		EmailVerifiedAt: u.EmailVerifiedAt,
+		TOTPEnabled:     u.TOTPEnabled,
+		Token:           u.Token,
+		RefreshToken:    u.RefreshToken,
+	}
+	if u.Books != nil {
+		books := NewBookResponses(*u.Books)
+		res.Books = &books
+	}
+	return res
 }
 
 // UserCreateDTO digunakan oleh client saat membuat user baru (kode ini di-comment karena tidak digunakan saat ini)
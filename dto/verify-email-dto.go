@@ -0,0 +1,6 @@
+package dto
+
+// VerifyEmailDTO digunakan saat client membuka link GET /verify-email dari email verifikasi
+type VerifyEmailDTO struct {
+	Token string `form:"token" binding:"required"` // Token adalah token verifikasi dari link di email (wajib diisi)
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// APIKey adalah model entitas yang merepresentasikan API key milik user untuk script dan integrasi.
+// Key asli hanya ditampilkan sekali saat dibuat, database hanya menyimpan hash SHA-256 dari key tersebut.
+type APIKey struct {
+	ID         uint64     `gorm:"primary_key:auto_increment" json:"id"`           // ID adalah identitas unik dari API key
+	UserID     uint64     `gorm:"not null;index" json:"-"`                        // UserID adalah ID user pemilik API key, request dengan key ini bertindak sebagai user tersebut
+	Name       string     `gorm:"type:varchar(100);not null" json:"name"`         // Name adalah nama API key agar user bisa membedakan key miliknya, misalnya "nightly catalog sync"
+	Prefix     string     `gorm:"type:varchar(16);not null" json:"prefix"`        // Prefix adalah awal key yang aman ditampilkan untuk mengenali key
+	KeyHash    string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"` // KeyHash adalah hash SHA-256 dari key
+	Scopes     string     `gorm:"type:varchar(255);not null" json:"scopes"`       // Scopes adalah daftar scope yang dipisahkan spasi, misalnya "books:read books:write"
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`                           // ExpiresAt adalah waktu kedaluwarsa key, kosong berarti tidak kedaluwarsa
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`                         // LastUsedAt adalah perkiraan waktu terakhir key dipakai
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`                           // RevokedAt diisi ketika key dicabut
+	CreatedAt  time.Time  `json:"created_at"`                                     // CreatedAt adalah waktu key dibuat
+}
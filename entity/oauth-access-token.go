@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// OAuthAccessToken adalah model entitas yang mencatat access token yang diterbitkan kepada client OAuth2 dalam satu family.
+// Catatan ini dipakai untuk mencabut access token yang sudah terbit jika authorization code atau refresh token family dipakai ulang.
+type OAuthAccessToken struct {
+	ID        uint64    `gorm:"primary_key:auto_increment" json:"id"`     // ID adalah identitas unik dari catatan access token
+	ClientID  uint64    `gorm:"not null;index" json:"-"`                  // ClientID adalah ID client pemegang token
+	FamilyID  string    `gorm:"type:varchar(64);not null;index" json:"-"` // FamilyID adalah family refresh token tempat access token diterbitkan
+	JTI       string    `gorm:"type:varchar(64);not null" json:"-"`       // JTI adalah ID unik dari access token
+	ExpiresAt time.Time `gorm:"not null;index" json:"-"`                  // ExpiresAt adalah waktu kedaluwarsa access token, setelahnya catatan boleh dihapus
+	CreatedAt time.Time `json:"-"`                                        // CreatedAt adalah waktu access token diterbitkan
+}
+
+// TableName mengembalikan nama tabel access token OAuth2, GORM akan menamainya o_auth_... tanpa method ini
+func (OAuthAccessToken) TableName() string {
+	return "oauth_access_tokens"
+}
@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+// OAuthAuthorizationCode adalah model entitas yang merepresentasikan authorization code yang diterbitkan setelah user
+// menyetujui akses client. Code hanya bisa ditukar sekali, dalam waktu singkat, dan dengan PKCE code verifier yang cocok.
+type OAuthAuthorizationCode struct {
+	ID            uint64     `gorm:"primary_key:auto_increment" json:"id"`           // ID adalah identitas unik dari authorization code
+	CodeHash      string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"` // CodeHash adalah hash SHA-256 dari authorization code
+	ClientID      uint64     `gorm:"not null;index" json:"-"`                        // ClientID adalah ID client yang meminta code
+	UserID        uint64     `gorm:"not null;index" json:"-"`                        // UserID adalah ID user yang memberi persetujuan
+	FamilyID      string     `gorm:"type:varchar(64);not null" json:"-"`             // FamilyID adalah family refresh token yang diterbitkan dari code ini, dicabut jika code dipakai ulang
+	RedirectURI   string     `gorm:"type:text;not null" json:"-"`                    // RedirectURI adalah redirect_uri pada request persetujuan, harus dikirim ulang saat code ditukar
+	Scopes        string     `gorm:"type:varchar(255);not null" json:"-"`            // Scopes adalah scope yang disetujui user, dipisahkan spasi
+	CodeChallenge string     `gorm:"type:varchar(128);not null" json:"-"`            // CodeChallenge adalah PKCE code challenge S256 dari client
+	ExpiresAt     time.Time  `gorm:"not null" json:"-"`                              // ExpiresAt adalah waktu kedaluwarsa code
+	UsedAt        *time.Time `json:"-"`                                              // UsedAt diisi ketika code sudah ditukar dengan token
+	CreatedAt     time.Time  `json:"-"`                                              // CreatedAt adalah waktu code diterbitkan
+}
+
+// TableName mengembalikan nama tabel authorization code OAuth2, GORM akan menamainya o_auth_... tanpa method ini
+func (OAuthAuthorizationCode) TableName() string {
+	return "oauth_authorization_codes"
+}
@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// OAuthClient adalah model entitas yang merepresentasikan aplikasi pihak ketiga yang terdaftar sebagai client OAuth2.
+// Client secret hanya ditampilkan sekali saat client dibuat, database hanya menyimpan hash SHA-256-nya.
+type OAuthClient struct {
+	ID           uint64    `gorm:"primary_key:auto_increment" json:"id"`                   // ID adalah identitas unik dari client
+	ClientID     string    `gorm:"type:varchar(64);not null;uniqueIndex" json:"client_id"` // ClientID adalah identitas publik client yang dikirim di request OAuth2
+	SecretHash   string    `gorm:"type:varchar(64)" json:"-"`                              // SecretHash adalah hash SHA-256 dari client secret, kosong untuk public client
+	Name         string    `gorm:"type:varchar(100);not null" json:"name"`                 // Name adalah nama aplikasi yang ditampilkan kepada user di halaman persetujuan
+	RedirectURIs string    `gorm:"type:text;not null" json:"redirect_uris"`                // RedirectURIs adalah daftar URL callback yang dipisahkan spasi, redirect_uri harus sama persis dengan salah satunya
+	Scopes       string    `gorm:"type:varchar(255);not null" json:"scopes"`               // Scopes adalah scope maksimum yang boleh diminta client, dipisahkan spasi
+	CreatedBy    uint64    `gorm:"not null" json:"created_by"`                             // CreatedBy adalah ID admin yang mendaftarkan client
+	CreatedAt    time.Time `json:"created_at"`                                             // CreatedAt adalah waktu client didaftarkan
+}
+
+// Confidential mengembalikan true jika client memiliki secret, misalnya aplikasi server.
+// Public client (aplikasi mobile atau SPA) tidak bisa menyimpan secret dan hanya diamankan dengan PKCE.
+func (c OAuthClient) Confidential() bool {
+	return c.SecretHash != ""
+}
+
+// TableName mengembalikan nama tabel client OAuth2, GORM akan menamainya o_auth_... tanpa method ini
+func (OAuthClient) TableName() string {
+	return "oauth_clients"
+}
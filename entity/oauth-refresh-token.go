@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// OAuthRefreshToken adalah model entitas yang merepresentasikan refresh token yang diterbitkan kepada client OAuth2.
+// Disimpan terpisah dari RefreshToken agar token milik client tidak bisa ditukar dengan token login user yang tidak dibatasi scope.
+type OAuthRefreshToken struct {
+	ID         uint64     `gorm:"primary_key:auto_increment" json:"id"`           // ID adalah identitas unik dari refresh token
+	ClientID   uint64     `gorm:"not null;index" json:"-"`                        // ClientID adalah ID client pemegang token
+	UserID     uint64     `gorm:"not null;index" json:"-"`                        // UserID adalah ID user yang memberi akses
+	FamilyID   string     `gorm:"type:varchar(64);not null;index" json:"-"`       // FamilyID mengelompokkan token hasil rotasi dari satu persetujuan yang sama
+	TokenHash  string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"` // TokenHash adalah hash SHA-256 dari refresh token
+	Scopes     string     `gorm:"type:varchar(255);not null" json:"scopes"`       // Scopes adalah scope yang disetujui user, dipisahkan spasi
+	ExpiresAt  time.Time  `gorm:"not null" json:"expires_at"`                     // ExpiresAt adalah waktu kedaluwarsa refresh token
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`                           // RevokedAt diisi ketika token sudah dirotasi atau dicabut
+	ReplacedBy *uint64    `json:"-"`                                              // ReplacedBy adalah ID token pengganti hasil rotasi
+	CreatedAt  time.Time  `json:"created_at"`                                     // CreatedAt adalah waktu token diterbitkan
+}
+
+// TableName mengembalikan nama tabel refresh token milik client OAuth2, GORM akan menamainya o_auth_... tanpa method ini
+func (OAuthRefreshToken) TableName() string {
+	return "oauth_refresh_tokens"
+}
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// PasswordResetToken adalah model entitas yang merepresentasikan token reset password yang dikirim lewat email.
+// Token asli tidak pernah disimpan, hanya hash SHA-256 dari token tersebut.
+type PasswordResetToken struct {
+	ID        uint64     `gorm:"primary_key:auto_increment" json:"id"`           // ID adalah identitas unik dari token reset password
+	UserID    uint64     `gorm:"not null;index" json:"-"`                        // UserID adalah ID user pemilik token
+	TokenHash string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"` // TokenHash adalah hash SHA-256 dari token
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`                     // ExpiresAt adalah waktu kedaluwarsa token
+	UsedAt    *time.Time `json:"used_at,omitempty"`                              // UsedAt diisi ketika token sudah dipakai atau dibatalkan, token hanya bisa dipakai sekali
+	CreatedAt time.Time  `json:"created_at"`                                     // CreatedAt adalah waktu token diterbitkan
+}
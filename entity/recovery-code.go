@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// RecoveryCode adalah model entitas yang merepresentasikan kode pemulihan 2FA milik user.
+// Kode dipakai untuk login ketika user kehilangan aplikasi authenticator, dan hanya bisa dipakai sekali.
+// Kode asli tidak pernah disimpan, hanya hash SHA-256 dari kode tersebut.
+type RecoveryCode struct {
+	ID        uint64     `gorm:"primary_key:auto_increment" json:"id"`           // ID adalah identitas unik dari kode pemulihan
+	UserID    uint64     `gorm:"not null;index" json:"-"`                        // UserID adalah ID user pemilik kode
+	CodeHash  string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"` // CodeHash adalah hash SHA-256 dari kode
+	UsedAt    *time.Time `json:"used_at,omitempty"`                              // UsedAt diisi ketika kode sudah dipakai
+	CreatedAt time.Time  `json:"created_at"`                                     // CreatedAt adalah waktu kode dibuat
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// RefreshToken adalah model entitas yang merepresentasikan refresh token yang diterbitkan kepada user.
+// Token asli tidak pernah disimpan, hanya hash SHA-256 dari token tersebut.
+type RefreshToken struct {
+	ID         uint64     `gorm:"primary_key:auto_increment" json:"id"`           // ID adalah identitas unik dari refresh token
+	UserID     uint64     `gorm:"not null;index" json:"-"`                        // UserID adalah ID user pemilik refresh token
+	FamilyID   string     `gorm:"type:varchar(64);not null;index" json:"-"`       // FamilyID mengelompokkan token hasil rotasi dari satu login yang sama
+	TokenHash  string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"` // TokenHash adalah hash SHA-256 dari refresh token
+	ExpiresAt  time.Time  `gorm:"not null" json:"expires_at"`                     // ExpiresAt adalah waktu kedaluwarsa refresh token
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`                           // RevokedAt diisi ketika token sudah dirotasi atau dicabut
+	ReplacedBy *uint64    `json:"-"`                                              // ReplacedBy adalah ID token pengganti hasil rotasi
+	CreatedAt  time.Time  `json:"created_at"`                                     // CreatedAt adalah waktu token diterbitkan
+}
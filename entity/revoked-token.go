@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// RevokedToken adalah model entitas yang mencatat access token (berdasarkan jti) yang sudah dicabut sebelum kedaluwarsa
+type RevokedToken struct {
+	JTI       string    `gorm:"primary_key;type:varchar(64)" json:"jti"` // JTI adalah ID unik dari token yang dicabut
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"`        // ExpiresAt adalah waktu kedaluwarsa token, setelahnya record boleh dihapus
+	CreatedAt time.Time `json:"created_at"`                              // CreatedAt adalah waktu token dicabut
+}
+
+// UserRevocation adalah model entitas yang mencatat batas waktu pencabutan seluruh token milik seorang user
+type UserRevocation struct {
+	UserID        uint64    `gorm:"primary_key;autoIncrement:false" json:"user_id"` // UserID adalah ID user yang semua sesinya dicabut
+	RevokedBefore time.Time `gorm:"not null" json:"revoked_before"`                 // RevokedBefore adalah batas waktu, token yang diterbitkan sebelum waktu ini ditolak
+}
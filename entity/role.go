@@ -0,0 +1,13 @@
+package entity
+
+// Daftar role yang bisa dimiliki user
+const (
+	RoleReader = "reader" // RoleReader adalah role default, hanya bisa mengelola buku miliknya sendiri
+	RoleEditor = "editor" // RoleEditor bisa mengubah dan menghapus buku milik siapa pun
+	RoleAdmin  = "admin"  // RoleAdmin bisa melakukan semua hal, termasuk mengelola user melalui api/admin
+)
+
+// IsValidRole memeriksa apakah role termasuk dalam daftar role yang dikenal
+func IsValidRole(role string) bool {
+	return role == RoleReader || role == RoleEditor || role == RoleAdmin
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// Session adalah model entitas yang merepresentasikan satu login user di sebuah perangkat.
+// Sesi terhubung dengan family refresh token dari login tersebut dan tetap aktif selama family itu
+// masih memiliki refresh token yang belum dicabut dan belum kedaluwarsa.
+type Session struct {
+	ID         uint64    `gorm:"primary_key:auto_increment" json:"id"`           // ID adalah identitas unik dari sesi, juga disimpan di claim sid access token
+	UserID     uint64    `gorm:"not null;index" json:"-"`                        // UserID adalah ID user pemilik sesi
+	FamilyID   string    `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"` // FamilyID adalah family refresh token milik sesi
+	DeviceName string    `gorm:"type:varchar(100)" json:"device_name"`           // DeviceName adalah nama perangkat dari client, atau ringkasan user agent jika tidak dikirim
+	UserAgent  string    `gorm:"type:varchar(255)" json:"user_agent"`            // UserAgent adalah header User-Agent saat login
+	IPAddress  string    `gorm:"type:varchar(45)" json:"ip_address"`             // IPAddress adalah alamat IP client saat login
+	CreatedAt  time.Time `json:"created_at"`                                     // CreatedAt adalah waktu login
+	LastSeenAt time.Time `gorm:"not null" json:"last_seen_at"`                   // LastSeenAt adalah perkiraan waktu terakhir sesi dipakai
+	Current    bool      `gorm:"-" json:"current"`                               // Current menandai sesi yang sedang dipakai untuk request ini, tidak disimpan di database
+}
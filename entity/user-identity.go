@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// UserIdentity adalah model entitas yang menghubungkan user dengan akun di identity provider luar,
+// misalnya Google, GitHub, atau SSO perusahaan. Satu user bisa memiliki beberapa identitas.
+type UserIdentity struct {
+	ID        uint64    `gorm:"primary_key:auto_increment" json:"id"`                                              // ID adalah identitas unik dari hubungan identitas
+	UserID    uint64    `gorm:"not null;index" json:"-"`                                                           // UserID adalah ID user pemilik identitas
+	Provider  string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_user_identities_subject" json:"provider"` // Provider adalah nama identity provider, misalnya "google"
+	Subject   string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_user_identities_subject" json:"-"`       // Subject adalah ID user yang stabil di identity provider (claim "sub")
+	Email     string    `gorm:"type:varchar(255)" json:"email"`                                                    // Email adalah email dari identity provider saat identitas dihubungkan
+	CreatedAt time.Time `json:"created_at"`                                                                        // CreatedAt adalah waktu identitas dihubungkan
+}
@@ -1,11 +1,23 @@
 package entity
 
-// User adalah model entitas yang merepresentasikan data pengguna dalam sistem
+import "time" // Mengimport package time untuk batas waktu penguncian akun dan verifikasi email
+
+// User adalah model entitas yang merepresentasikan data pengguna dalam sistem.
+// Status akun tidak ikut di JSON entitas, status akun hanya dikirim melalui UserResponseDTO dan AdminUserDTO.
 type User struct {
-	ID       uint64  `gorm:"primary_key:auto_increament" json:"id"`      // ID adalah identitas unik dari user
-	Name     string  `gorm:"type:varchar(255)" json:"name"`              // Name adalah nama lengkap dari user
-	Email    string  `gorm:"uniqueIndex;type:varchar(255)" json:"email"` // Email adalah alamat email dari user
-	Password string  `gorm:"->;<-;not null" json:"-"`                    // Password adalah kata sandi dari user (disembunyikan dalam respons JSON)
-	Token    string  `gorm:"-" json:"token,omitempty"`                   // Token adalah token JWT yang diterbitkan kepada user saat login (tidak disimpan dalam database)
-	Books    *[]Book `json:"books,omitempty"`                            // Books adalah daftar buku yang dimiliki oleh user (opsional, bisa kosong)
+	ID              uint64     `gorm:"primary_key:auto_increment" json:"id"`                             // ID adalah identitas unik dari user
+	Name            string     `gorm:"type:varchar(255)" json:"name"`                                    // Name adalah nama lengkap dari user
+	Email           string     `gorm:"uniqueIndex;type:varchar(255)" json:"email"`                       // Email adalah alamat email dari user
+	Password        string     `gorm:"->;<-;not null" json:"-"`                                          // Password adalah kata sandi dari user (disembunyikan dalam respons JSON)
+	Role            string     `gorm:"type:varchar(20);not null;default:reader" json:"-"`                // Role adalah hak akses user (reader, editor, atau admin)
+	Suspended       bool       `gorm:"not null;default:false" json:"-"`                                  // Suspended menandakan user diblokir oleh admin dan tidak bisa login
+	FailedLogins    int        `gorm:"not null;default:0" json:"-"`                                      // FailedLogins adalah jumlah login gagal berturut-turut
+	LockedUntil     *time.Time `json:"-"`                                                                // LockedUntil adalah batas waktu akun dikunci karena terlalu banyak login gagal
+	EmailVerifiedAt *time.Time `json:"-"`                                                                // EmailVerifiedAt adalah waktu user membuktikan kepemilikan email, kosong berarti belum terverifikasi
+	TOTPSecret      string     `gorm:"column:totp_secret;type:varchar(64);not null;default:''" json:"-"` // TOTPSecret adalah secret TOTP user, terisi sejak enrollment dimulai
+	TOTPEnabled     bool       `gorm:"column:totp_enabled;not null;default:false" json:"-"`              // TOTPEnabled menandakan user sudah mengkonfirmasi 2FA dan wajib memasukkan kode saat login
+	TOTPLastStep    int64      `gorm:"column:totp_last_step;not null;default:0" json:"-"`                // TOTPLastStep adalah time step kode TOTP terakhir yang dipakai, kode yang sama tidak bisa dipakai dua kali
+	Token           string     `gorm:"-" json:"token,omitempty"`                                         // Token adalah token JWT yang diterbitkan kepada user saat login (tidak disimpan dalam database)
+	RefreshToken    string     `gorm:"-" json:"refresh_token,omitempty"`                                 // RefreshToken adalah refresh token yang diterbitkan bersama token JWT (tidak disimpan dalam database)
+	Books           *[]Book    `json:"books,omitempty"`                                                  // Books adalah daftar buku yang dimiliki oleh user (opsional, bisa kosong)
 }
@@ -0,0 +1,9 @@
+package helper
+
+import "strings" // Mengimport package strings untuk mengganti karakter
+
+// EscapeLike meng-escape karakter wildcard LIKE dengan '!' sebagai karakter escape.
+// Dipakai bersama klausa ESCAPE '!' agar input user dicari apa adanya.
+func EscapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
+}
@@ -0,0 +1,11 @@
+package helper
+
+// PageMeta adalah metadata pagination yang dikirimkan pada field meta dari Response
+type PageMeta struct {
+	Page       int    `json:"page,omitempty"`        // Page adalah nomor halaman saat ini (hanya untuk pagination berbasis halaman)
+	PerPage    int    `json:"per_page"`              // PerPage adalah jumlah data maksimal per halaman
+	Total      *int64 `json:"total,omitempty"`       // Total adalah jumlah seluruh data yang cocok (hanya untuk pagination berbasis halaman)
+	TotalPages *int   `json:"total_pages,omitempty"` // TotalPages adalah jumlah seluruh halaman (hanya untuk pagination berbasis halaman)
+	HasMore    bool   `json:"has_more"`              // HasMore menandakan masih ada data setelah halaman ini
+	NextCursor string `json:"next_cursor,omitempty"` // NextCursor adalah cursor untuk mengambil data berikutnya
+}
@@ -6,10 +6,12 @@ import (
 
 // Response digunakan untuk bentuk statis dari respons JSON
 type Response struct {
-	Status  bool        `json:"status"`  // Status respons
-	Message string      `json:"message"` // Pesan respons
-	Errors  interface{} `json:"errors"`  // Error yang terjadi
-	Data    interface{} `json:"data"`    // Data yang dikirimkan
+	Status  bool        `json:"status"`         // Status respons
+	Message string      `json:"message"`        // Pesan respons
+	Code    string      `json:"code,omitempty"` // Kode error yang bisa dibaca mesin, hanya diisi pada respons gagal
+	Errors  interface{} `json:"errors"`         // Error yang terjadi
+	Data    interface{} `json:"data"`           // Data yang dikirimkan
+	Meta    interface{} `json:"meta,omitempty"` // Metadata tambahan, misalnya informasi pagination
 }
 
 // EmptyObj digunakan ketika data tidak ingin menjadi null pada JSON
@@ -26,6 +28,13 @@ func BuildResponse(status bool, message string, data interface{}) Response {
 	return res // Mengembalikan respons yang telah dibuat
 }
 
+// BuildPaginatedResponse adalah metode untuk menyusun respons sukses yang berisi satu halaman data beserta metadata pagination
+func BuildPaginatedResponse(message string, data interface{}, meta PageMeta) Response {
+	res := BuildResponse(true, message, data) // Menyusun respons sukses biasa
+	res.Meta = meta                           // Menambahkan metadata pagination
+	return res
+}
+
 // BuildErrorResponse adalah metode untuk menyusun respons gagal yang dinamis
 func BuildErrorResponse(message string, err string, data interface{}) Response {
 	splitedError := strings.Split(err, "\n") // Membagi pesan error menjadi beberapa baris jika ada newline
@@ -37,3 +46,10 @@ func BuildErrorResponse(message string, err string, data interface{}) Response {
 	}
 	return res // Mengembalikan respons yang telah dibuat
 }
+
+// BuildCodedErrorResponse adalah metode untuk menyusun respons gagal beserta kode error yang bisa dibaca mesin
+func BuildCodedErrorResponse(code string, message string, err string) Response {
+	res := BuildErrorResponse(message, err, EmptyObj{}) // Menyusun respons gagal biasa
+	res.Code = code                                     // Menambahkan kode error
+	return res
+}
@@ -0,0 +1,23 @@
+package helper
+
+import (
+	"crypto/rand"     // Mengimport package crypto/rand untuk membangkitkan byte acak yang aman
+	"crypto/sha256"   // Mengimport package sha256 untuk hashing token
+	"encoding/base64" // Mengimport package base64 untuk encoding token
+	"encoding/hex"    // Mengimport package hex untuk encoding hash
+)
+
+// GenerateRandomToken adalah fungsi untuk membuat token acak sepanjang size byte dalam bentuk base64 URL-safe
+func GenerateRandomToken(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil { // Mengisi slice dengan byte acak dari sumber kriptografis
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil // Mengembalikan token dalam bentuk string
+}
+
+// HashToken adalah fungsi untuk menghasilkan hash SHA-256 (hex) dari sebuah token sebelum disimpan ke database
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,94 @@
+package identity
+
+import (
+	"context"       // Mengimport package context untuk pembatalan dan tenggat waktu request ke provider
+	"encoding/json" // Mengimport package json untuk membaca respons API GitHub
+	"fmt"           // Mengimport package fmt untuk membungkus error
+	"net/http"      // Mengimport package http untuk memanggil API GitHub
+	"strconv"       // Mengimport package strconv untuk menulis ID user GitHub
+
+	"golang.org/x/oauth2"        // Mengimport library OAuth2 untuk authorization code flow
+	"golang.org/x/oauth2/github" // Mengimport endpoint OAuth2 GitHub
+)
+
+// githubAPIURL adalah alamat API GitHub untuk membaca profil dan email user
+const githubAPIURL = "https://api.github.com"
+
+// GitHubProvider adalah Provider untuk login dengan akun GitHub. GitHub bukan provider OIDC,
+// sehingga identitas dibaca dari API GitHub dengan access token hasil authorization code flow.
+type GitHubProvider struct {
+	oauth  *oauth2.Config // Konfigurasi OAuth2 GitHub
+	apiURL string         // Alamat API GitHub
+}
+
+// NewGitHubProvider adalah constructor untuk GitHubProvider
+func NewGitHubProvider(clientID string, clientSecret string, redirectURL string) *GitHubProvider {
+	return &GitHubProvider{
+		oauth: &oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			RedirectURL:  redirectURL,
+			Endpoint:     github.Endpoint,
+			Scopes:       []string{"read:user", "user:email"},
+		},
+		apiURL: githubAPIURL,
+	}
+}
+
+// AuthCodeURL adalah implementasi fungsi AuthCodeURL dari Provider, nonce tidak dipakai karena GitHub tidak menerbitkan ID token
+func (p *GitHubProvider) AuthCodeURL(ctx context.Context, state string, nonce string, verifier string) (string, error) {
+	return p.oauth.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier)), nil
+}
+
+// Exchange adalah implementasi fungsi Exchange dari Provider. Email yang dipakai adalah email utama yang sudah diverifikasi GitHub.
+func (p *GitHubProvider) Exchange(ctx context.Context, code string, nonce string, verifier string) (Identity, error) {
+	token, err := p.oauth.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return Identity{}, fmt.Errorf("%w: %v", ErrExchangeFailed, err)
+	}
+	client := p.oauth.Client(ctx, token)
+	var user struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+		Name  string `json:"name"`
+	}
+	if err := p.get(ctx, client, "/user", &user); err != nil {
+		return Identity{}, err
+	}
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := p.get(ctx, client, "/user/emails", &emails); err != nil {
+		return Identity{}, err
+	}
+	identity := Identity{Subject: strconv.FormatInt(user.ID, 10), Name: user.Name}
+	if identity.Name == "" {
+		identity.Name = user.Login
+	}
+	for _, e := range emails {
+		if e.Primary {
+			identity.Email, identity.EmailVerified = e.Email, e.Verified
+		}
+	}
+	return identity, nil
+}
+
+// get memanggil API GitHub dan membaca respons JSON ke target
+func (p *GitHubProvider) get(ctx context.Context, client *http.Client, path string, target any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.apiURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	res, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("github %s: %w", path, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: github %s returned %s", ErrExchangeFailed, path, res.Status)
+	}
+	return json.NewDecoder(res.Body).Decode(target)
+}
@@ -0,0 +1,229 @@
+// Package identitytest berisi provider OpenID Connect palsu berbasis httptest untuk menguji login SSO tanpa provider sungguhan.
+package identitytest
+
+import (
+	"crypto/rand"       // Mengimport package rand untuk kunci RSA dan authorization code
+	"crypto/rsa"        // Mengimport package rsa untuk menandatangani ID token
+	"crypto/sha256"     // Mengimport package sha256 untuk memeriksa PKCE challenge S256
+	"encoding/base64"   // Mengimport package base64 untuk JWKS, PKCE, dan authorization code
+	"encoding/json"     // Mengimport package json untuk respons discovery, JWKS, dan token
+	"errors"            // Mengimport package errors untuk membuat error
+	"math/big"          // Mengimport package big untuk eksponen publik RSA
+	"net/http"          // Mengimport package http untuk handler provider
+	"net/http/httptest" // Mengimport package httptest untuk server provider lokal
+	"net/url"           // Mengimport package url untuk redirect ke callback
+	"sync"              // Mengimport package sync untuk melindungi authorization code
+	"time"              // Mengimport package time untuk masa berlaku ID token
+
+	"github.com/golang-jwt/jwt/v4" // Mengimport library JWT untuk membuat ID token
+)
+
+// keyID adalah kid kunci penanda tangan ID token di JWKS
+const keyID = "identitytest"
+
+// User adalah user yang login di provider palsu
+type User struct {
+	Subject       string // Subject adalah claim sub
+	Email         string // Email adalah claim email
+	EmailVerified *bool  // EmailVerified adalah claim email_verified, nil berarti claim tidak dikirim
+	Name          string // Name adalah claim name
+}
+
+// OIDCServer adalah provider OpenID Connect palsu dengan discovery, JWKS, authorization endpoint, dan token endpoint.
+// Token endpoint memeriksa client secret, redirect URI, dan PKCE S256, dan setiap authorization code hanya bisa ditukar sekali.
+type OIDCServer struct {
+	*httptest.Server
+
+	ClientID     string // ClientID adalah satu-satunya client yang dikenal provider
+	ClientSecret string // ClientSecret adalah secret client tersebut
+
+	mu    sync.Mutex             // Melindungi User dan codes
+	user  User                   // User yang login pada authorize berikutnya
+	key   *rsa.PrivateKey        // Kunci penanda tangan ID token
+	codes map[string]authRequest // Authorization code yang belum ditukar
+}
+
+// authRequest adalah request authorize yang menunggu ditukar di token endpoint
+type authRequest struct {
+	redirectURI string // Redirect URI yang harus dikirim ulang saat penukaran
+	challenge   string // PKCE code challenge S256
+	nonce       string // Nonce yang dimasukkan ke ID token
+	user        User   // User yang login
+}
+
+// NewOIDCServer menjalankan provider palsu untuk satu client. Pemanggil harus memanggil Close setelah selesai.
+func NewOIDCServer(clientID string, clientSecret string) *OIDCServer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic("identitytest: generate key: " + err.Error())
+	}
+	s := &OIDCServer{ClientID: clientID, ClientSecret: clientSecret, key: key, codes: map[string]authRequest{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("/keys", s.keys)
+	mux.HandleFunc("/authorize", s.authorize)
+	mux.HandleFunc("/token", s.token)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// SetUser menentukan user yang login pada authorize berikutnya
+func (s *OIDCServer) SetUser(user User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.user = user
+}
+
+// Authorize membuka authURL seperti browser user yang langsung menyetujui login, lalu mengembalikan code dan state
+// dari redirect ke callback aplikasi
+func (s *OIDCServer) Authorize(authURL string) (code string, state string, err error) {
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	res, err := client.Get(authURL)
+	if err != nil {
+		return "", "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusFound {
+		return "", "", errors.New("identitytest: authorize returned " + res.Status)
+	}
+	location, err := url.Parse(res.Header.Get("Location"))
+	if err != nil {
+		return "", "", err
+	}
+	query := location.Query()
+	if query.Get("error") != "" {
+		return "", "", errors.New("identitytest: authorize error " + query.Get("error"))
+	}
+	return query.Get("code"), query.Get("state"), nil
+}
+
+// discovery menulis dokumen discovery OpenID Connect
+func (s *OIDCServer) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                s.URL,
+		"authorization_endpoint":                s.URL + "/authorize",
+		"token_endpoint":                        s.URL + "/token",
+		"jwks_uri":                              s.URL + "/keys",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+// keys menulis JWKS berisi kunci publik penanda tangan ID token
+func (s *OIDCServer) keys(w http.ResponseWriter, r *http.Request) {
+	pub := s.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]interface{}{"keys": []map[string]string{{
+		"kty": "RSA",
+		"kid": keyID,
+		"alg": "RS256",
+		"use": "sig",
+		"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+	}}})
+}
+
+// authorize menerbitkan authorization code untuk user saat ini dan mengarahkan kembali ke redirect_uri
+func (s *OIDCServer) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	redirectURI := query.Get("redirect_uri")
+	if query.Get("client_id") != s.ClientID || redirectURI == "" {
+		http.Error(w, "unknown client or missing redirect_uri", http.StatusBadRequest)
+		return
+	}
+	callback, err := url.Parse(redirectURI)
+	if err != nil {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	params := url.Values{"state": {query.Get("state")}}
+	if query.Get("response_type") != "code" || query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		params.Set("error", "invalid_request") // Provider ini hanya menerima authorization code flow dengan PKCE S256
+	} else {
+		code := randomString()
+		s.mu.Lock()
+		s.codes[code] = authRequest{redirectURI: redirectURI, challenge: query.Get("code_challenge"), nonce: query.Get("nonce"), user: s.user}
+		s.mu.Unlock()
+		params.Set("code", code)
+	}
+	callback.RawQuery = params.Encode()
+	http.Redirect(w, r, callback.String(), http.StatusFound)
+}
+
+// token menukar authorization code dengan access token dan ID token
+func (s *OIDCServer) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	clientID, clientSecret, ok := r.BasicAuth()
+	if ok { // Client library meng-encode client ID dan secret sebelum dikirim lewat HTTP Basic
+		clientID, _ = url.QueryUnescape(clientID)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+	} else {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != s.ClientID || clientSecret != s.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+	code := r.PostForm.Get("code")
+	s.mu.Lock()
+	req, ok := s.codes[code]
+	delete(s.codes, code) // Code hanya bisa ditukar sekali, termasuk jika penukaran gagal
+	s.mu.Unlock()
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || req.redirectURI != r.PostForm.Get("redirect_uri") || base64.RawURLEncoding.EncodeToString(sum[:]) != req.challenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":   s.URL,
+		"sub":   req.user.Subject,
+		"aud":   s.ClientID,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+		"nonce": req.nonce,
+		"email": req.user.Email,
+		"name":  req.user.Name,
+	}
+	if req.user.EmailVerified != nil {
+		claims["email_verified"] = *req.user.EmailVerified
+	}
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	idToken.Header["kid"] = keyID
+	signed, err := idToken.SignedString(s.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     signed,
+	})
+}
+
+// writeJSON menulis body JSON dengan status tertentu
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// randomString membuat nilai acak untuk authorization code dan access token
+func randomString() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic("identitytest: read random bytes: " + err.Error())
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
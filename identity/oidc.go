@@ -0,0 +1,107 @@
+package identity
+
+import (
+	"context" // Mengimport package context untuk pembatalan dan tenggat waktu request ke provider
+	"fmt"     // Mengimport package fmt untuk membungkus error
+	"sync"    // Mengimport package sync untuk menyimpan hasil discovery
+
+	"github.com/coreos/go-oidc/v3/oidc" // Mengimport library OIDC untuk discovery dan verifikasi ID token
+	"golang.org/x/oauth2"               // Mengimport library OAuth2 untuk authorization code flow
+)
+
+// OIDCConfig adalah konfigurasi client untuk provider OpenID Connect
+type OIDCConfig struct {
+	Issuer       string   // Issuer adalah URL issuer provider, dokumen discovery dibaca dari <Issuer>/.well-known/openid-configuration
+	ClientID     string   // ClientID adalah ID client yang didaftarkan di provider
+	ClientSecret string   // ClientSecret adalah secret client, boleh kosong untuk public client yang hanya memakai PKCE
+	RedirectURL  string   // RedirectURL adalah URL callback yang didaftarkan di provider
+	Scopes       []string // Scopes adalah scope tambahan selain "openid"
+	TrustEmail   bool     // TrustEmail menganggap email terverifikasi walaupun provider tidak mengirim claim email_verified, untuk SSO perusahaan
+}
+
+// OIDCProvider adalah Provider untuk provider OpenID Connect, misalnya Google atau SSO perusahaan.
+// Dokumen discovery baru dibaca saat login pertama, sehingga aplikasi tetap bisa start walaupun provider sedang tidak bisa dihubungi.
+type OIDCProvider struct {
+	cfg OIDCConfig // Konfigurasi client
+
+	mu       sync.Mutex            // Melindungi hasil discovery
+	oauth    *oauth2.Config        // Konfigurasi OAuth2 dari hasil discovery
+	verifier *oidc.IDTokenVerifier // Pemeriksa ID token dari hasil discovery
+}
+
+// NewOIDCProvider adalah constructor untuk OIDCProvider
+func NewOIDCProvider(cfg OIDCConfig) *OIDCProvider {
+	return &OIDCProvider{cfg: cfg}
+}
+
+// discover membaca dokumen discovery provider sekali, lalu menyimpannya. Discovery yang gagal dicoba lagi pada login berikutnya.
+func (p *OIDCProvider) discover(ctx context.Context) (*oauth2.Config, *oidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.oauth != nil {
+		return p.oauth, p.verifier, nil
+	}
+	provider, err := oidc.NewProvider(context.WithoutCancel(ctx), p.cfg.Issuer) // Kunci publik provider tetap dibaca setelah request ini selesai
+	if err != nil {
+		return nil, nil, fmt.Errorf("oidc discovery for %s: %w", p.cfg.Issuer, err)
+	}
+	p.oauth = &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		RedirectURL:  p.cfg.RedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       append([]string{oidc.ScopeOpenID}, p.cfg.Scopes...),
+	}
+	p.verifier = provider.Verifier(&oidc.Config{ClientID: p.cfg.ClientID})
+	return p.oauth, p.verifier, nil
+}
+
+// AuthCodeURL adalah implementasi fungsi AuthCodeURL dari Provider
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context, state string, nonce string, verifier string) (string, error) {
+	oauth, _, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	return oauth.AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier)), nil
+}
+
+// Exchange adalah implementasi fungsi Exchange dari Provider. ID token diperiksa tanda tangan, issuer, audience, masa berlaku, dan nonce-nya.
+func (p *OIDCProvider) Exchange(ctx context.Context, code string, nonce string, verifier string) (Identity, error) {
+	oauth, idVerifier, err := p.discover(ctx)
+	if err != nil {
+		return Identity{}, err
+	}
+	token, err := oauth.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return Identity{}, fmt.Errorf("%w: %v", ErrExchangeFailed, err)
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return Identity{}, fmt.Errorf("%w: token response has no id_token", ErrExchangeFailed)
+	}
+	idToken, err := idVerifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return Identity{}, fmt.Errorf("%w: %v", ErrExchangeFailed, err)
+	}
+	if idToken.Nonce != nonce { // ID token harus dibuat untuk login ini, bukan diputar ulang dari login lain
+		return Identity{}, fmt.Errorf("%w: id_token nonce mismatch", ErrExchangeFailed)
+	}
+	var claims struct {
+		Email         string `json:"email"`
+		EmailVerified *bool  `json:"email_verified"`
+		Name          string `json:"name"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return Identity{}, fmt.Errorf("%w: %v", ErrExchangeFailed, err)
+	}
+	verified := p.cfg.TrustEmail
+	if claims.EmailVerified != nil {
+		verified = *claims.EmailVerified
+	}
+	return Identity{
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: verified && claims.Email != "",
+		Name:          claims.Name,
+	}, nil
+}
@@ -0,0 +1,111 @@
+package identity_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/identity"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/identity/identitytest"
+	"golang.org/x/oauth2"
+)
+
+// newProvider menjalankan provider palsu dan membuat OIDCProvider yang memakai discovery dari provider tersebut
+func newProvider(t *testing.T, trustEmail bool) (*identitytest.OIDCServer, *identity.OIDCProvider) {
+	t.Helper()
+	server := identitytest.NewOIDCServer("bookstore", "client-secret")
+	t.Cleanup(server.Close)
+	provider := identity.NewOIDCProvider(identity.OIDCConfig{
+		Issuer:       server.URL,
+		ClientID:     "bookstore",
+		ClientSecret: "client-secret",
+		RedirectURL:  "http://localhost:9090/api/auth/sso/sso/callback",
+		Scopes:       []string{"email", "profile"},
+		TrustEmail:   trustEmail,
+	})
+	return server, provider
+}
+
+// authorize memulai login dan mengembalikan authorization code dari provider palsu
+func authorize(t *testing.T, server *identitytest.OIDCServer, provider *identity.OIDCProvider, state string, nonce string, verifier string) string {
+	t.Helper()
+	authURL, err := provider.AuthCodeURL(context.Background(), state, nonce, verifier)
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	code, gotState, err := server.Authorize(authURL)
+	if err != nil {
+		t.Fatalf("Authorize: %v", err)
+	}
+	if gotState != state {
+		t.Fatalf("state = %q, want %q", gotState, state)
+	}
+	return code
+}
+
+func TestOIDCProviderExchange(t *testing.T) {
+	server, provider := newProvider(t, false)
+	verified := true
+	server.SetUser(identitytest.User{Subject: "user-1", Email: "ana@example.com", EmailVerified: &verified, Name: "Ana"})
+	verifier := oauth2.GenerateVerifier()
+	code := authorize(t, server, provider, "state-1", "nonce-1", verifier)
+
+	id, err := provider.Exchange(context.Background(), code, "nonce-1", verifier)
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	want := identity.Identity{Subject: "user-1", Email: "ana@example.com", EmailVerified: true, Name: "Ana"}
+	if id != want {
+		t.Fatalf("identity = %+v, want %+v", id, want)
+	}
+
+	if _, err := provider.Exchange(context.Background(), code, "nonce-1", verifier); !errors.Is(err, identity.ErrExchangeFailed) {
+		t.Fatalf("reusing the code: err = %v, want ErrExchangeFailed", err)
+	}
+}
+
+func TestOIDCProviderExchangeRejectsWrongVerifierAndNonce(t *testing.T) {
+	server, provider := newProvider(t, false)
+	verified := true
+	server.SetUser(identitytest.User{Subject: "user-1", Email: "ana@example.com", EmailVerified: &verified})
+
+	code := authorize(t, server, provider, "state-1", "nonce-1", oauth2.GenerateVerifier())
+	if _, err := provider.Exchange(context.Background(), code, "nonce-1", oauth2.GenerateVerifier()); !errors.Is(err, identity.ErrExchangeFailed) {
+		t.Fatalf("wrong PKCE verifier: err = %v, want ErrExchangeFailed", err)
+	}
+
+	verifier := oauth2.GenerateVerifier()
+	code = authorize(t, server, provider, "state-2", "nonce-2", verifier)
+	if _, err := provider.Exchange(context.Background(), code, "nonce-from-another-login", verifier); !errors.Is(err, identity.ErrExchangeFailed) {
+		t.Fatalf("wrong nonce: err = %v, want ErrExchangeFailed", err)
+	}
+}
+
+func TestOIDCProviderEmailVerified(t *testing.T) {
+	no := false
+	tests := []struct {
+		name       string
+		claim      *bool
+		trustEmail bool
+		want       bool
+	}{
+		{name: "claim false", claim: &no, trustEmail: true, want: false},
+		{name: "claim missing", claim: nil, trustEmail: false, want: false},
+		{name: "claim missing with trusted provider", claim: nil, trustEmail: true, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, provider := newProvider(t, tt.trustEmail)
+			server.SetUser(identitytest.User{Subject: "user-1", Email: "ana@example.com", EmailVerified: tt.claim})
+			verifier := oauth2.GenerateVerifier()
+			code := authorize(t, server, provider, "state", "nonce", verifier)
+			id, err := provider.Exchange(context.Background(), code, "nonce", verifier)
+			if err != nil {
+				t.Fatalf("Exchange: %v", err)
+			}
+			if id.EmailVerified != tt.want {
+				t.Fatalf("EmailVerified = %v, want %v", id.EmailVerified, tt.want)
+			}
+		})
+	}
+}
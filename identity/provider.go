@@ -0,0 +1,28 @@
+// Package identity berisi abstraksi identity provider eksternal (OIDC, GitHub, SSO perusahaan) untuk login tanpa password.
+package identity
+
+import (
+	"context" // Mengimport package context untuk pembatalan dan tenggat waktu request ke provider
+	"errors"  // Mengimport package errors untuk membuat error
+)
+
+// ErrExchangeFailed dikembalikan ketika provider menolak authorization code atau identitas dari provider tidak valid
+var ErrExchangeFailed = errors.New("identity provider exchange failed")
+
+// Identity adalah identitas user yang sudah diverifikasi oleh provider
+type Identity struct {
+	Subject       string // Subject adalah ID user yang stabil di provider, tidak berubah walaupun email berubah
+	Email         string // Email adalah alamat email user di provider
+	EmailVerified bool   // EmailVerified menandakan provider menjamin user memiliki email tersebut
+	Name          string // Name adalah nama user di provider, bisa kosong
+}
+
+// Provider adalah identity provider yang mendukung authorization code flow dengan PKCE.
+// Implementasi lain, misalnya provider palsu untuk pengujian, cukup memenuhi interface ini.
+type Provider interface {
+	// AuthCodeURL mengembalikan URL halaman login provider. state dikembalikan provider ke callback, nonce ditandatangani di ID token,
+	// dan verifier adalah PKCE code verifier yang hanya dikirim sebagai challenge S256, lalu dikirim utuh ke Exchange.
+	AuthCodeURL(ctx context.Context, state string, nonce string, verifier string) (string, error)
+	// Exchange menukar authorization code dengan identitas user, lalu memeriksa nonce jika provider mendukungnya
+	Exchange(ctx context.Context, code string, nonce string, verifier string) (Identity, error)
+}
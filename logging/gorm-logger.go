@@ -0,0 +1,77 @@
+package logging
+
+import (
+	"context"  // Mengimport package context untuk logger per request
+	"errors"   // Mengimport package errors untuk mengenali record yang tidak ditemukan
+	"fmt"      // Mengimport package fmt untuk formatting pesan
+	"log/slog" // Mengimport package slog untuk structured logging
+	"time"     // Mengimport package time untuk durasi query
+
+	"gorm.io/gorm"                   // Mengimport package gorm untuk error bawaan
+	gormlogger "gorm.io/gorm/logger" // Mengimport interface logger GORM
+)
+
+// GormLogger adalah logger GORM yang menulis lewat slog dengan request_id dan user_id dari context.
+// Query dicatat tanpa nilai parameter agar hash password maupun token tidak masuk log.
+type GormLogger struct {
+	SlowThreshold time.Duration // Query yang lebih lama dari ini dicatat sebagai peringatan
+}
+
+// NewGormLogger adalah constructor untuk GormLogger
+func NewGormLogger(slowThreshold time.Duration) *GormLogger {
+	return &GormLogger{SlowThreshold: slowThreshold}
+}
+
+// LogMode adalah implementasi fungsi LogMode dari logger.Interface, level diatur oleh slog
+func (l *GormLogger) LogMode(gormlogger.LogLevel) gormlogger.Interface {
+	return l
+}
+
+// Info adalah implementasi fungsi Info dari logger.Interface
+func (l *GormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	FromContext(ctx).InfoContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+// Warn adalah implementasi fungsi Warn dari logger.Interface
+func (l *GormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	FromContext(ctx).WarnContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+// Error adalah implementasi fungsi Error dari logger.Interface
+func (l *GormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	FromContext(ctx).ErrorContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+// Trace adalah implementasi fungsi Trace dari logger.Interface. Query yang gagal dicatat
+// sebagai error, query lambat sebagai peringatan, dan query lainnya hanya pada level debug.
+// Record yang tidak ditemukan bukan error karena sudah ditangani oleh repository.
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	logger := FromContext(ctx)
+	elapsed := time.Since(begin)
+	level := slog.LevelDebug
+	msg := "database query"
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		level, msg = slog.LevelError, "database query failed"
+	case l.SlowThreshold > 0 && elapsed > l.SlowThreshold:
+		level, msg = slog.LevelWarn, "slow database query"
+	}
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+	sql, rows := fc()
+	attrs := []slog.Attr{
+		slog.String("sql", sql),
+		slog.Int64("rows", rows),
+		slog.Float64("duration_ms", float64(elapsed.Microseconds())/1000),
+	}
+	if err != nil && level == slog.LevelError {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// ParamsFilter adalah implementasi gorm.ParamsFilter yang membuang nilai parameter dari SQL di log
+func (l *GormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
@@ -0,0 +1,88 @@
+package logging
+
+import (
+	"context"  // Mengimport package context untuk menyimpan logger per request
+	"fmt"      // Mengimport package fmt untuk pesan error
+	"io"       // Mengimport package io untuk tujuan output log
+	"log/slog" // Mengimport package slog untuk structured logging
+	"net/http" // Mengimport package http untuk header request
+	"strings"  // Mengimport package strings untuk membandingkan nama field
+)
+
+// Redacted adalah nilai pengganti untuk field rahasia di log
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys adalah nama attribute dan header (huruf kecil) yang nilainya tidak boleh masuk log
+var sensitiveKeys = map[string]bool{
+	"password":      true,
+	"authorization": true,
+	"cookie":        true,
+	"set-cookie":    true,
+	"token":         true,
+	"refresh_token": true,
+	"secret":        true,
+	"x-api-key":     true,
+}
+
+// contextKey adalah tipe key context agar tidak bentrok dengan package lain
+type contextKey struct{}
+
+// New membuat logger dengan format "json" atau "text" dan level minimum level.
+// Attribute dengan nama sensitif (misalnya "password" atau "authorization") selalu disamarkan.
+func New(w io.Writer, format string, level slog.Level) (*slog.Logger, error) {
+	opts := &slog.HandlerOptions{Level: level, ReplaceAttr: redactAttr}
+	switch format {
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	case "text":
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	}
+	return nil, fmt.Errorf("unknown log format %q", format)
+}
+
+// ParseLevel membaca level log seperti "debug", "info", "warn", atau "error"
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	err := level.UnmarshalText([]byte(s))
+	return level, err
+}
+
+// redactAttr menyamarkan attribute yang namanya termasuk sensitiveKeys
+func redactAttr(groups []string, a slog.Attr) slog.Attr {
+	if sensitiveKeys[strings.ToLower(a.Key)] {
+		return slog.String(a.Key, Redacted)
+	}
+	return a
+}
+
+// WithContext mengembalikan context turunan ctx yang membawa logger
+func WithContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext mengembalikan logger yang dibawa ctx, berisi request_id dan user_id jika ada,
+// atau slog.Default() jika ctx tidak membawa logger
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// With menambahkan attribute ke logger yang dibawa ctx, misalnya user_id setelah token divalidasi
+func With(ctx context.Context, args ...any) context.Context {
+	return WithContext(ctx, FromContext(ctx).With(args...))
+}
+
+// Headers mengubah header HTTP menjadi attribute log dengan header rahasia seperti Authorization disamarkan
+func Headers(h http.Header) slog.Attr {
+	attrs := make([]any, 0, len(h))
+	for name, values := range h {
+		value := strings.Join(values, ", ")
+		if sensitiveKeys[strings.ToLower(name)] {
+			value = Redacted
+		}
+		attrs = append(attrs, slog.String(name, value))
+	}
+	return slog.Group("headers", attrs...)
+}
@@ -0,0 +1,60 @@
+package mail
+
+import (
+	"context"       // Mengimport package context untuk memenuhi interface Mailer
+	"fmt"           // Mengimport package fmt untuk nama file
+	"log/slog"      // Mengimport package slog untuk mencatat email
+	"os"            // Mengimport package os untuk menulis file
+	"path/filepath" // Mengimport package filepath untuk lokasi file
+	"time"          // Mengimport package time untuk nama file
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/helper"  // Mengimport package helper untuk nama file acak
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/logging" // Mengimport package logging untuk logger per request
+)
+
+// LogMailer tidak mengirim email, tetapi mencatatnya di log. Hanya untuk development,
+// karena isi email seperti link reset password ikut tercatat.
+type LogMailer struct {
+	From string // Alamat pengirim
+}
+
+// NewLogMailer adalah constructor untuk LogMailer
+func NewLogMailer(from string) *LogMailer {
+	return &LogMailer{From: from}
+}
+
+// Send adalah implementasi fungsi Send dari Mailer
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	logging.FromContext(ctx).Info("mail not sent, MAIL_DRIVER is log", slog.String("from", m.From), slog.String("to", msg.To), slog.String("subject", msg.Subject), slog.String("body", msg.Body))
+	return nil
+}
+
+// FileMailer tidak mengirim email, tetapi menyimpan setiap email sebagai file .eml di Dir
+// yang bisa dibuka dengan aplikasi email. Hanya untuk development.
+type FileMailer struct {
+	Dir  string // Folder tempat file .eml disimpan
+	From string // Alamat pengirim
+}
+
+// NewFileMailer adalah constructor untuk FileMailer
+func NewFileMailer(dir, from string) *FileMailer {
+	return &FileMailer{Dir: dir, From: from}
+}
+
+// Send adalah implementasi fungsi Send dari Mailer
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	if err := os.MkdirAll(m.Dir, 0o700); err != nil {
+		return err
+	}
+	suffix, err := helper.GenerateRandomToken(6)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405"), suffix) // Nama file urut berdasarkan waktu
+	path := filepath.Join(m.Dir, name)
+	if err := os.WriteFile(path, format(m.From, msg), 0o600); err != nil {
+		return err
+	}
+	logging.FromContext(ctx).Info("mail written to file", slog.String("to", msg.To), slog.String("subject", msg.Subject), slog.String("path", path))
+	return nil
+}
@@ -0,0 +1,36 @@
+package mail
+
+import (
+	"context" // Mengimport package context untuk pembatalan pengiriman
+	"fmt"     // Mengimport package fmt untuk menyusun header email
+	"mime"    // Mengimport package mime untuk encoding subject
+	"strings" // Mengimport package strings untuk menyusun isi email
+	"time"    // Mengimport package time untuk header Date
+)
+
+// Message adalah satu email teks yang akan dikirim
+type Message struct {
+	To      string // To adalah alamat email penerima
+	Subject string // Subject adalah judul email
+	Body    string // Body adalah isi email dalam bentuk teks biasa
+}
+
+// Mailer adalah interface pengirim email. Implementasi yang tersedia adalah SMTP untuk produksi,
+// serta file dan log untuk development agar link di dalam email bisa dibaca tanpa server email.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error // Fungsi untuk mengirim satu email
+}
+
+// format menyusun email lengkap dengan header dalam format RFC 5322
+func format(from string, msg Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n")) // Baris email wajib diakhiri CRLF
+	return []byte(b.String())
+}
@@ -0,0 +1,75 @@
+package mail
+
+import (
+	"context"    // Mengimport package context untuk batas waktu koneksi
+	"crypto/tls" // Mengimport package tls untuk STARTTLS
+	"errors"     // Mengimport package errors untuk error konfigurasi
+	"net"        // Mengimport package net untuk membuka koneksi
+	"net/smtp"   // Mengimport package smtp untuk protokol SMTP
+	"strconv"    // Mengimport package strconv untuk port
+	"strings"    // Mengimport package strings untuk memeriksa header
+)
+
+// SMTPMailer mengirim email lewat server SMTP. STARTTLS dipakai jika server mendukungnya,
+// dan autentikasi PLAIN hanya dilakukan jika Username diisi.
+type SMTPMailer struct {
+	Host     string // Host server SMTP
+	Port     int    // Port server SMTP, biasanya 587
+	Username string // User SMTP, kosong berarti tanpa autentikasi
+	Password string // Password SMTP
+	From     string // Alamat pengirim
+}
+
+// NewSMTPMailer adalah constructor untuk SMTPMailer
+func NewSMTPMailer(host string, port int, username, password, from string) *SMTPMailer {
+	return &SMTPMailer{Host: host, Port: port, Username: username, Password: password, From: from}
+}
+
+// Send adalah implementasi fungsi Send dari Mailer
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	if strings.ContainsAny(msg.To, "\r\n") || strings.ContainsAny(msg.Subject, "\r\n") { // Mencegah header injection
+		return errors.New("mail: header must not contain line breaks")
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(m.Host, strconv.Itoa(m.Port)))
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok { // Perintah SMTP berikutnya juga mengikuti batas waktu ctx
+		_ = conn.SetDeadline(deadline)
+	}
+	client, err := smtp.NewClient(conn, m.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.Host}); err != nil {
+			return err
+		}
+	}
+	if m.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.Username, m.Password, m.Host)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(m.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(msg.To); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(format(m.From, msg)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
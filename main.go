@@ -1,54 +1,104 @@
 package main
 
 import (
-	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/config"     // Mengimport konfigurasi aplikasi
-	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/controller" // Mengimport controller aplikasi
-	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/middleware" // Mengimport middleware aplikasi
-	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/repository" // Mengimport repository aplikasi
-	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service"    // Mengimport service aplikasi
-	"github.com/gin-gonic/gin"                                  // Mengimport framework gin untuk routing HTTP
-	"gorm.io/gorm"                                              // Mengimport ORM GORM untuk manipulasi database
-)
+	"errors"  // Mengimport package errors untuk mengenali permintaan bantuan
+	"flag"    // Mengimport package flag untuk flag milik subcommand
+	"fmt"     // Mengimport package fmt untuk mencetak petunjuk penggunaan
+	"io"      // Mengimport package io untuk tujuan output petunjuk penggunaan
+	"os"      // Mengimport package os untuk argumen command-line dan kode keluar
+	"strings" // Mengimport package strings untuk mencocokkan nama subcommand
 
-// Inisialisasi variabel yang digunakan dalam aplikasi
-var (
-	db             *gorm.DB                  = config.SetupDatabaseConnection()                      // Membuat koneksi database
-	userRepository repository.UserRepository = repository.NewUserRepository(db)                      // Membuat repository user
-	bookRepository repository.BookRepository = repository.NewBookRepository(db)                      // Membuat repository buku
-	jwtService     service.JWTService        = service.NewJWTService()                               // Membuat service JWT
-	userService    service.UserService       = service.NewUserService(userRepository)                // Membuat service user
-	bookService    service.BookService       = service.NewBookService(bookRepository)                // Membuat service buku
-	authService    service.AuthService       = service.NewAuthService(userRepository)                // Membuat service auth
-	authController controller.AuthController = controller.NewAuthController(authService, jwtService) // Membuat controller auth
-	userController controller.UserController = controller.NewUserController(userService, jwtService) // Membuat controller user
-	bookController controller.BookController = controller.NewBookController(bookService, jwtService) // Membuat controller buku
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/config" // Mengimport konfigurasi aplikasi
 )
 
+// command adalah satu subcommand CLI, misalnya "serve" atau "user create"
+type command struct {
+	name    string                    // Nama subcommand, bisa terdiri dari beberapa kata
+	args    string                    // Argumen dan flag khusus subcommand untuk petunjuk penggunaan
+	summary string                    // Penjelasan singkat subcommand
+	run     func(args []string) error // Fungsi yang menjalankan subcommand dengan argumen sisanya
+}
+
+// commands adalah daftar semua subcommand, "serve" dijalankan jika tidak ada subcommand yang diberikan
+var commands = []command{
+	{"serve", "", "start the HTTP server (default)", runServe},
+	{"migrate", "up | down [steps] | status", "apply, revert or list database migrations", runMigrate},
+	{"seed", "[-password value]", "insert sample users and books", runSeed},
+	{"user create", "-name value -email value [-password value] [-admin | -role value]", "create a user, the password is read from stdin when omitted", runUserCreate},
+	{"user reset-password", "-email value [-password value]", "set a new password and revoke all sessions of a user", runUserResetPassword},
+	{"user unlock", "-email value", "unlock an account locked after too many failed logins", runUserUnlock},
+	{"user reset-mfa", "-email value", "disable two-factor authentication of a user who lost their authenticator", runUserResetMFA},
+	{"token issue", "-email value [-refresh]", "print an access token for a user", runTokenIssue},
+	{"config check", "", "validate the configuration and print it without secrets", runConfigCheck},
+}
+
 // Fungsi utama aplikasi
 func main() {
-	defer config.CloseDatabaseConnection(db) // Menutup koneksi database secara defer
-	r := gin.Default()                       // Menggunakan router default dari Gin
+	args := os.Args[1:]
+	if len(args) > 0 && (args[0] == "help" || args[0] == "-h" || args[0] == "-help" || args[0] == "--help") {
+		usage(os.Stdout)
+		return
+	}
+	cmd, rest, ok := findCommand(args)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", strings.Join(args, " "))
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+	if err := cmd.run(rest); err != nil {
+		if errors.Is(err, flag.ErrHelp) { // Petunjuk flag sudah dicetak oleh package flag
+			return
+		}
+		fmt.Fprintf(os.Stderr, "%s: %v\n", cmd.name, err)
+		os.Exit(1)
+	}
+}
 
-	authRoutes := r.Group("api/auth") // Membuat grup endpoint untuk auth
-	{
-		authRoutes.POST("/login", authController.Login)       // Endpoint login
-		authRoutes.POST("/register", authController.Register) // Endpoint register
+// findCommand mencari subcommand yang namanya cocok dengan kata-kata awal args.
+// Tanpa subcommand (args kosong atau diawali flag) server HTTP yang dijalankan.
+func findCommand(args []string) (command, []string, bool) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return commands[0], args, true
+	}
+	for _, cmd := range commands {
+		words := strings.Fields(cmd.name)
+		if len(args) < len(words) {
+			continue
+		}
+		if strings.Join(args[:len(words)], " ") == cmd.name {
+			return cmd, args[len(words):], true
+		}
 	}
+	return command{}, nil, false
+}
 
-	userRoutes := r.Group("api/user", middleware.AuthorizeJWT(jwtService)) // Membuat grup endpoint untuk user dengan middleware JWT
-	{
-		userRoutes.GET("/profile", userController.Profile) // Endpoint profil user
-		userRoutes.PUT("/profile", userController.Update)  // Endpoint update profil user
+// usage mencetak daftar subcommand
+func usage(w io.Writer) {
+	fmt.Fprintf(w, "usage: %s <command> [flags]\n\ncommands:\n", os.Args[0])
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %s %s\n    \t%s\n", cmd.name, cmd.args, cmd.summary)
 	}
+	fmt.Fprintf(w, "\nevery command also accepts the configuration flags, run \"%s <command> -h\" to list them\n", os.Args[0])
+}
 
-	bookRoutes := r.Group("api/books", middleware.AuthorizeJWT(jwtService)) // Membuat grup endpoint untuk buku dengan middleware JWT
-	{
-		bookRoutes.GET("/", bookController.All)          // Endpoint untuk mendapatkan semua buku
-		bookRoutes.POST("/", bookController.Insert)      // Endpoint untuk menyimpan buku baru
-		bookRoutes.GET("/:id", bookController.FindByID)  // Endpoint untuk mencari buku berdasarkan ID
-		bookRoutes.PUT("/:id", bookController.Update)    // Endpoint untuk mengupdate buku berdasarkan ID
-		bookRoutes.DELETE("/:id", bookController.Delete) // Endpoint untuk menghapus buku berdasarkan ID
+// loadConfig membaca konfigurasi untuk subcommand name. flags berisi flag khusus subcommand
+// dan boleh nil. Argumen posisi ditolak kecuali subcommand membacanya sendiri lewat Config.Args.
+func loadConfig(name string, flags *flag.FlagSet, args []string, positional bool) (*config.Config, error) {
+	if flags == nil {
+		flags = newFlagSet(name)
 	}
+	cfg, err := config.LoadFlags(flags, args)
+	if err != nil {
+		return nil, err
+	}
+	config.SetupLogger(cfg.Log) // Semua subcommand menulis log dalam format yang sama
+	if !positional && len(cfg.Args()) > 0 {
+		return nil, fmt.Errorf("unexpected argument %q", cfg.Args()[0])
+	}
+	return cfg, nil
+}
 
-	r.Run(":9090") // Menjalankan server pada port 9090
+// newFlagSet membuat FlagSet untuk subcommand name yang mengembalikan error alih-alih keluar
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet(name, flag.ContinueOnError)
 }
@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"errors" // Mengimport package errors untuk menggabungkan error dan mengenali record yang tidak ditemukan
+	"time"   // Mengimport package time untuk mengukur durasi query
+
+	"github.com/prometheus/client_golang/prometheus/collectors" // Mengimport collector statistik connection pool
+	"gorm.io/gorm"                                              // Mengimport package gorm untuk callback query
+)
+
+// startKey adalah key instance GORM untuk menyimpan waktu mulai query
+const startKey = "metrics:start"
+
+// InstrumentDB memasang plugin GORM yang mengukur durasi dan error setiap query,
+// lalu mendaftarkan statistik connection pool database dengan label db_name=name
+func (m *Metrics) InstrumentDB(db *gorm.DB, name string) error {
+	if err := db.Use(&gormPlugin{metrics: m}); err != nil {
+		return err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return m.registry.Register(collectors.NewDBStatsCollector(sqlDB, name))
+}
+
+// gormPlugin adalah plugin GORM yang mencatat metrik query
+type gormPlugin struct {
+	metrics *Metrics // Metrik tujuan pencatatan
+}
+
+// Name adalah implementasi fungsi Name dari gorm.Plugin
+func (p *gormPlugin) Name() string {
+	return "metrics"
+}
+
+// Initialize adalah implementasi fungsi Initialize dari gorm.Plugin, memasang callback
+// sebelum dan sesudah setiap jenis operasi
+func (p *gormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("metrics:before_create", p.before),
+		cb.Create().After("gorm:create").Register("metrics:after_create", p.after("create")),
+		cb.Query().Before("gorm:query").Register("metrics:before_query", p.before),
+		cb.Query().After("gorm:query").Register("metrics:after_query", p.after("query")),
+		cb.Update().Before("gorm:update").Register("metrics:before_update", p.before),
+		cb.Update().After("gorm:update").Register("metrics:after_update", p.after("update")),
+		cb.Delete().Before("gorm:delete").Register("metrics:before_delete", p.before),
+		cb.Delete().After("gorm:delete").Register("metrics:after_delete", p.after("delete")),
+		cb.Row().Before("gorm:row").Register("metrics:before_row", p.before),
+		cb.Row().After("gorm:row").Register("metrics:after_row", p.after("row")),
+		cb.Raw().Before("gorm:raw").Register("metrics:before_raw", p.before),
+		cb.Raw().After("gorm:raw").Register("metrics:after_raw", p.after("raw")),
+	)
+}
+
+// before menyimpan waktu mulai query
+func (p *gormPlugin) before(db *gorm.DB) {
+	db.InstanceSet(startKey, time.Now())
+}
+
+// after mencatat durasi query dan error selain record yang tidak ditemukan
+func (p *gormPlugin) after(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		start, ok := db.InstanceGet(startKey)
+		if !ok {
+			return
+		}
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		p.metrics.dbDuration.WithLabelValues(operation, table).Observe(time.Since(start.(time.Time)).Seconds())
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			p.metrics.dbErrors.WithLabelValues(operation, table).Inc()
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"net/http" // Mengimport package net/http untuk handler /metrics
+	"strconv"  // Mengimport package strconv untuk label status code
+	"time"     // Mengimport package time untuk durasi
+
+	"github.com/prometheus/client_golang/prometheus"            // Mengimport library Prometheus untuk mendefinisikan metrik
+	"github.com/prometheus/client_golang/prometheus/collectors" // Mengimport collector bawaan untuk runtime Go dan proses
+	"github.com/prometheus/client_golang/prometheus/promhttp"   // Mengimport handler HTTP Prometheus
+)
+
+// Metrics menyimpan semua metrik Prometheus aplikasi dalam registry sendiri.
+// Semua method aman dipanggil pada *Metrics nil sehingga metrik bersifat opsional.
+type Metrics struct {
+	registry *prometheus.Registry // Registry yang diekspos lewat /metrics
+
+	httpRequests *prometheus.CounterVec   // Jumlah request HTTP per method, route, dan status
+	httpDuration *prometheus.HistogramVec // Durasi request HTTP per method, route, dan status
+
+	dbDuration *prometheus.HistogramVec // Durasi query GORM per operasi dan tabel
+	dbErrors   *prometheus.CounterVec   // Jumlah query GORM yang gagal per operasi dan tabel
+
+	logins         prometheus.Counter     // Jumlah login yang berhasil
+	loginFailures  *prometheus.CounterVec // Jumlah login yang gagal per alasan
+	registrations  prometheus.Counter     // Jumlah registrasi user baru
+	rejectedTokens *prometheus.CounterVec // Jumlah access token yang ditolak per alasan
+}
+
+// New membuat Metrics beserta registry yang sudah berisi metrik runtime Go dan proses
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total number of HTTP requests by method, route and status.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request latency by method, route and status.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		dbDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "db_query_duration_seconds",
+			Help:    "Database query latency by operation and table.",
+			Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation", "table"}),
+		dbErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "db_query_errors_total",
+			Help: "Total number of failed database queries by operation and table.",
+		}, []string{"operation", "table"}),
+		logins: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "auth_logins_total",
+			Help: "Total number of successful logins.",
+		}),
+		loginFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "auth_login_failures_total",
+			Help: "Total number of failed logins by reason.",
+		}, []string{"reason"}),
+		registrations: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "auth_registrations_total",
+			Help: "Total number of registered users.",
+		}),
+		rejectedTokens: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "auth_rejected_tokens_total",
+			Help: "Total number of rejected access tokens by reason.",
+		}, []string{"reason"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests, m.httpDuration,
+		m.dbDuration, m.dbErrors,
+		m.logins, m.loginFailures, m.registrations, m.rejectedTokens,
+	)
+	return m
+}
+
+// Handler mengembalikan handler HTTP yang menampilkan metrik dalam format teks Prometheus
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveRequest mencatat satu request HTTP yang sudah selesai
+func (m *Metrics) ObserveRequest(method, route string, status int, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	code := strconv.Itoa(status)
+	m.httpRequests.WithLabelValues(method, route, code).Inc()
+	m.httpDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+}
+
+// LoginSucceeded mencatat login yang berhasil
+func (m *Metrics) LoginSucceeded() {
+	if m == nil {
+		return
+	}
+	m.logins.Inc()
+}
+
+// LoginFailed mencatat login yang gagal, reason adalah kode error seperti "invalid_credential"
+func (m *Metrics) LoginFailed(reason string) {
+	if m == nil {
+		return
+	}
+	m.loginFailures.WithLabelValues(reason).Inc()
+}
+
+// UserRegistered mencatat registrasi user baru
+func (m *Metrics) UserRegistered() {
+	if m == nil {
+		return
+	}
+	m.registrations.Inc()
+}
+
+// TokenRejected mencatat access token yang ditolak, reason adalah kode error seperti "token_revoked"
+func (m *Metrics) TokenRejected(reason string) {
+	if m == nil {
+		return
+	}
+	m.rejectedTokens.WithLabelValues(reason).Inc()
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"strconv" // Mengimport package strconv untuk menulis user ID
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror" // Mengimport package apperror untuk error domain
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/metrics"  // Mengimport package metrics untuk mencatat API key yang ditolak
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service"  // Mengimport package service untuk APIKeyService
+	"github.com/gin-gonic/gin"                                // Mengimport package gin untuk web framework
+)
+
+// APIKeyHeader adalah header tempat client mengirim API key
+const APIKeyHeader = "X-API-Key"
+
+// ContextScopesKey menyimpan scope credential yang dipakai, yaitu API key atau access token client OAuth2.
+// Tidak diisi untuk token login, yang boleh mengakses semua scope.
+const ContextScopesKey = "scopes"
+
+// Authenticate adalah middleware yang menerima API key dari header X-API-Key, atau token JWT lewat jwtAuth (AuthorizeJWT atau
+// AuthorizeDelegatedJWT) jika header tersebut kosong.
+// Request dengan API key bertindak sebagai pemilik key dengan hak akses yang dibatasi oleh scope key tersebut (lihat RequireScope).
+func Authenticate(jwtAuth gin.HandlerFunc, apiKeys service.APIKeyService, m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(APIKeyHeader)
+		if key == "" {
+			jwtAuth(c)
+			return
+		}
+		user, record, err := apiKeys.Authenticate(c.Request.Context(), key)
+		if err != nil {
+			if apperror.KindOf(err) == apperror.KindUnauthorized {
+				m.TokenRejected(apperror.CodeOf(err))
+			}
+			AbortWithError(c, err)
+			return
+		}
+		setIdentity(c, strconv.FormatUint(user.ID, 10), user.Role)
+		c.Set(ContextScopesKey, service.ParseScopes(record.Scopes)) // Menyimpan scope untuk middleware RequireScope
+	}
+}
+
+// RequireScope adalah middleware yang menolak request dengan API key atau access token client OAuth2 tanpa scope.
+// Middleware ini harus dipasang setelah Authenticate karena membaca scope yang disimpan olehnya.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, ok := c.Get(ContextScopesKey)
+		if !ok { // Token login tidak dibatasi scope
+			return
+		}
+		if scopes, _ := value.([]string); !service.HasScope(scopes, scope) {
+			AbortWithError(c, apperror.Forbidden("credential is missing the "+scope+" scope").WithCode("insufficient_scope"))
+		}
+	}
+}
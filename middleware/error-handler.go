@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"fmt"           // Mengimport package fmt untuk mengubah nilai panic menjadi error
+	"math"          // Mengimport package math untuk membulatkan waktu tunggu
+	"runtime/debug" // Mengimport package debug untuk mencatat stack trace panic
+	"strconv"       // Mengimport package strconv untuk header Retry-After
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror" // Mengimport package apperror untuk jenis dan kode error
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/helper"   // Mengimport package helper untuk fungsi bantuan
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/logging"  // Mengimport package logging untuk logger per request
+	"github.com/gin-gonic/gin"                                // Mengimport package gin untuk web framework
+)
+
+// errorMessages adalah pesan respons untuk setiap jenis error
+var errorMessages = map[apperror.Kind]string{
+	apperror.KindInvalid:      "Failed to process request",
+	apperror.KindUnauthorized: "Please check again your credential",
+	apperror.KindForbidden:    "You dont have permission",
+	apperror.KindNotFound:     "Data not found",
+	apperror.KindConflict:     "Failed to process request",
+	apperror.KindTooMany:      "Too many requests, please try again later",
+	apperror.KindInternal:     "Failed to process request",
+}
+
+// ErrorHandler adalah middleware yang mengubah error dari handler (lewat c.Error) dan panic menjadi respons error JSON.
+// Middleware ini harus dipasang paling awal agar membungkus semua middleware dan handler lain.
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if rec := recover(); rec != nil { // Panic tidak boleh menghentikan server
+				logging.FromContext(c.Request.Context()).Error("panic recovered", "method", c.Request.Method, "path", c.Request.URL.Path, "panic", fmt.Sprint(rec), "stack", string(debug.Stack()))
+				writeError(c, apperror.Internal("panic recovered", fmt.Errorf("%v", rec)))
+			}
+		}()
+		c.Next()
+		if len(c.Errors) > 0 {
+			writeError(c, c.Errors.Last().Err) // Error terakhir yang menentukan respons
+		}
+	}
+}
+
+// AbortWithError menghentikan request dan menyerahkan err ke ErrorHandler untuk ditampilkan
+func AbortWithError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
+
+// writeError mengirimkan respons error sesuai jenis error, kecuali respons sudah terlanjur dikirim
+func writeError(c *gin.Context, err error) {
+	if c.Writer.Written() {
+		return
+	}
+	kind := apperror.KindOf(err)
+	detail := err.Error()
+	if kind == apperror.KindInternal { // Detail error internal hanya dicatat di log agar informasi database tidak bocor ke client
+		logging.FromContext(c.Request.Context()).Error("internal error", "method", c.Request.Method, "path", c.Request.URL.Path, "error", err.Error())
+		detail = "internal server error"
+	}
+	if retryAfter := apperror.RetryAfterOf(err); retryAfter > 0 { // Memberi tahu client kapan boleh mencoba lagi, dibulatkan ke atas dalam detik
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	}
+	response := helper.BuildCodedErrorResponse(apperror.CodeOf(err), errorMessages[kind], detail)
+	c.AbortWithStatusJSON(apperror.HTTPStatus(err), response)
+}
@@ -1,34 +1,112 @@
 package middleware
 
 import (
-	"log"      // Mengimport package log untuk logging
-	"net/http" // Mengimport package http untuk handling HTTP requests
+	"errors"  // Mengimport package errors untuk membuat error validasi token
+	"fmt"     // Mengimport package fmt untuk formatting claim
+	"strconv" // Mengimport package strconv untuk membaca user ID sebagai angka
+	"strings" // Mengimport package strings untuk membuang awalan Bearer
 
-	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/helper"  // Mengimport package helper untuk fungsi bantuan
-	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service" // Mengimport package service untuk JWTService
-	"github.com/dgrijalva/jwt-go"                            // Mengimport package jwt-go untuk JWT (JSON Web Token)
-	"github.com/gin-gonic/gin"                               // Mengimport package gin untuk web framework
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror" // Mengimport package apperror untuk error domain
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/logging"  // Mengimport package logging untuk menambahkan user ID ke log request
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/metrics"  // Mengimport package metrics untuk mencatat token yang ditolak
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service"  // Mengimport package service untuk JWTService
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/tracing"  // Mengimport package tracing untuk attribute span
+	"github.com/gin-gonic/gin"                                // Mengimport package gin untuk web framework
+	"github.com/golang-jwt/jwt/v4"                            // Mengimport package golang-jwt untuk JWT (JSON Web Token)
+	"go.opentelemetry.io/otel/trace"                          // Mengimport API trace untuk span request
 )
 
-// AuthorizeJWT adalah middleware untuk validasi token JWT yang diberikan oleh user
-func AuthorizeJWT(jwtService service.JWTService) gin.HandlerFunc {
+// Key yang dipakai untuk menyimpan data token di gin.Context
+const (
+	ContextUserIDKey    = "user_id"    // ContextUserIDKey menyimpan user ID dari claim token
+	ContextRoleKey      = "role"       // ContextRoleKey menyimpan role dari claim token
+	ContextTokenKey     = "token"      // ContextTokenKey menyimpan *jwt.Token yang sudah divalidasi
+	ContextClientIDKey  = "client_id"  // ContextClientIDKey menyimpan client OAuth2 pemegang token, kosong untuk token login user
+	ContextSessionIDKey = "session_id" // ContextSessionIDKey menyimpan ID sesi login dari claim sid, kosong untuk token tanpa sesi
+)
+
+// AuthorizeJWT adalah middleware untuk validasi token JWT yang diberikan oleh user.
+// Access token milik client OAuth2 ditolak, sehingga endpoint dengan middleware ini hanya bisa dipakai user sendiri.
+// Token milik sesi login ditolak setelah sesi tersebut berakhir, dan setiap pemakaiannya memperbarui waktu terakhir sesi dipakai.
+// Setiap token yang ditolak dicatat pada m berdasarkan kode error-nya.
+func AuthorizeJWT(jwtService service.JWTService, revocationService service.TokenRevocationService, sessionService service.SessionService, m *metrics.Metrics) gin.HandlerFunc {
+	return authorizeJWT(jwtService, revocationService, sessionService, m, false)
+}
+
+// AuthorizeDelegatedJWT sama dengan AuthorizeJWT, tetapi juga menerima access token milik client OAuth2
+// yang hak aksesnya dibatasi oleh scope token tersebut (lihat RequireScope)
+func AuthorizeDelegatedJWT(jwtService service.JWTService, revocationService service.TokenRevocationService, sessionService service.SessionService, m *metrics.Metrics) gin.HandlerFunc {
+	return authorizeJWT(jwtService, revocationService, sessionService, m, true)
+}
+
+// authorizeJWT adalah implementasi AuthorizeJWT dan AuthorizeDelegatedJWT
+func authorizeJWT(jwtService service.JWTService, revocationService service.TokenRevocationService, sessionService service.SessionService, m *metrics.Metrics, delegated bool) gin.HandlerFunc {
+	reject := func(c *gin.Context, err *apperror.Error) {
+		m.TokenRejected(err.Code)
+		AbortWithError(c, err)
+	}
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization") // Mengambil header Authorization dari request
 		if authHeader == "" {                      // Jika header Authorization tidak ditemukan
-			response := helper.BuildErrorResponse("Failed to process request", "no token found", nil) // Membangun respons error
-			c.AbortWithStatusJSON(http.StatusBadRequest, response)                                    // Mengirim respons error dengan status 400 Bad Request
-			return                                                                                    // Menghentikan eksekusi middleware
+			reject(c, apperror.Invalid("no token found").WithCode("token_missing"))
+			return
 		}
 
-		token, err := jwtService.ValidateToken(authHeader) // Validasi token JWT
-		if token.Valid {                                   // Jika token valid
-			claims := token.Claims.(jwt.MapClaims)              // Mengambil claims dari token
-			log.Println("claim[user_id] : ", claims["user_id"]) // Menampilkan user_id dari claims
-			log.Println("Claim[issuer] :", claims["issuer"])    // Menampilkan issuer dari claims
-		} else { // Jika token tidak valid
-			log.Println(err)                                                              // Log pesan error
-			response := helper.BuildErrorResponse("Token is not valid", err.Error(), nil) // Membangun respons error
-			c.AbortWithStatusJSON(http.StatusUnauthorized, response)                      // Mengirim respons error dengan status 401 Unauthorized
+		token, err := jwtService.ValidateToken(strings.TrimPrefix(authHeader, "Bearer ")) // Validasi token JWT, awalan Bearer (RFC 6750) boleh dipakai
+		if err != nil || !token.Valid {                                                   // Token yang rusak dikembalikan sebagai nil, bukan token yang tidak valid
+			if err == nil {
+				err = errors.New("token is not valid")
+			}
+			reject(c, apperror.Wrap(apperror.KindUnauthorized, "Token is not valid", err).WithCode("token_invalid"))
+			return
 		}
+		revoked, err := revocationService.IsRevoked(c.Request.Context(), token) // Memeriksa apakah token sudah dicabut (logout)
+		if err != nil {
+			AbortWithError(c, err)
+			return
+		}
+		if revoked {
+			reject(c, apperror.Unauthorized("token has been revoked").WithCode("token_revoked"))
+			return
+		}
+		claims := token.Claims.(jwt.MapClaims) // Mengambil claims dari token
+		if clientID, _ := claims["client_id"].(string); clientID != "" {
+			if !delegated { // Client OAuth2 tidak boleh mengelola akun, 2FA, API key, atau memakai endpoint admin
+				reject(c, apperror.Forbidden("this endpoint does not accept tokens issued to third-party applications").WithCode("delegated_token_not_allowed"))
+				return
+			}
+			scope, _ := claims["scope"].(string)
+			c.Set(ContextScopesKey, service.ParseScopes(scope)) // Menyimpan scope untuk middleware RequireScope
+			c.Set(ContextClientIDKey, clientID)
+		}
+		if sessionID, _ := claims["sid"].(string); sessionID != "" {
+			id, err := strconv.ParseUint(sessionID, 10, 64)
+			if err != nil {
+				reject(c, apperror.Wrap(apperror.KindUnauthorized, "Token is not valid", err).WithCode("token_invalid"))
+				return
+			}
+			err = sessionService.Check(c.Request.Context(), id) // Memeriksa sesi belum dicabut dan mencatat waktu terakhir dipakai
+			if errors.Is(err, service.ErrSessionRevoked) {
+				reject(c, service.ErrSessionRevoked)
+				return
+			}
+			if err != nil {
+				AbortWithError(c, err)
+				return
+			}
+			c.Set(ContextSessionIDKey, sessionID) // Menyimpan ID sesi untuk menandai sesi yang sedang dipakai
+		}
+		setIdentity(c, fmt.Sprintf("%v", claims["user_id"]), fmt.Sprintf("%v", claims["role"]))
+		c.Set(ContextTokenKey, token) // Menyimpan token untuk handler yang perlu mencabutnya
+	}
+}
+
+// setIdentity menyimpan user yang memanggil untuk handler dan middleware berikutnya
+func setIdentity(c *gin.Context, userID string, role string) {
+	c.Set(ContextUserIDKey, userID) // Menyimpan user ID untuk handler berikutnya
+	if id, err := strconv.ParseUint(userID, 10, 64); err == nil {
+		trace.SpanFromContext(c.Request.Context()).SetAttributes(tracing.UserID(id)) // Span request mencatat user yang memanggil
 	}
+	c.Request = c.Request.WithContext(logging.With(c.Request.Context(), "user_id", userID)) // Semua log berikutnya pada request ini berisi user ID
+	c.Set(ContextRoleKey, role)                                                             // Menyimpan role untuk middleware RequireRole
 }
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"time" // Mengimport package time untuk mengukur durasi request
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/metrics" // Mengimport package metrics untuk metrik Prometheus
+	"github.com/gin-gonic/gin"                               // Mengimport package gin untuk web framework
+)
+
+// Metrics adalah middleware yang mencatat jumlah dan durasi request per route dan status.
+// Route diambil dari pola gin (misalnya "/api/books/:id") agar jumlah label tetap terbatas.
+func Metrics(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		route := c.FullPath()
+		if route == "" { // Request ke path yang tidak terdaftar digabung dalam satu label
+			route = "unmatched"
+		}
+		m.ObserveRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"strconv" // Mengimport package strconv untuk membaca user ID
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror" // Mengimport package apperror untuk error domain
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service"  // Mengimport package service untuk status 2FA
+	"github.com/gin-gonic/gin"                                // Mengimport package gin untuk web framework
+)
+
+// RequireMFA adalah middleware yang menolak user dengan salah satu roles yang belum mengaktifkan 2FA.
+// Status 2FA dibaca dari database, bukan dari token, agar langsung berlaku setelah user mengkonfirmasi 2FA.
+// Middleware ini harus dipasang setelah AuthorizeJWT karena membaca user ID dan role yang disimpan olehnya.
+func RequireMFA(mfa service.MFAService, roles []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString(ContextRoleKey)
+		required := false
+		for _, r := range roles {
+			if role == r {
+				required = true
+				break
+			}
+		}
+		if !required { // Role ini tidak wajib memakai 2FA, database tidak perlu dibaca
+			return
+		}
+		userID, err := strconv.ParseUint(c.GetString(ContextUserIDKey), 10, 64)
+		if err != nil {
+			AbortWithError(c, apperror.Unauthorized("token does not contain a valid user id").WithCode("token_invalid"))
+			return
+		}
+		enabled, err := mfa.IsEnabled(c.Request.Context(), userID)
+		if err != nil {
+			AbortWithError(c, err)
+			return
+		}
+		if !enabled {
+			AbortWithError(c, service.ErrMFARequired)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"strconv" // Mengimport package strconv untuk header rate limit
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror"  // Mengimport package apperror untuk error domain
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/ratelimit" // Mengimport package ratelimit untuk token bucket
+	"github.com/gin-gonic/gin"                                 // Mengimport package gin untuk web framework
+)
+
+// ErrRateLimited dikembalikan ketika client melewati rate limit
+var ErrRateLimited = apperror.TooMany("rate limit exceeded").WithCode("rate_limited")
+
+// RateKeyFunc menentukan bucket yang dipakai sebuah request. Key kosong berarti request tidak dibatasi.
+type RateKeyFunc func(c *gin.Context) string
+
+// ByIP membatasi request berdasarkan alamat IP client.
+// IP diambil dari header X-Forwarded-For hanya jika request datang dari proxy yang dipercaya (lihat HTTP_TRUSTED_PROXIES).
+func ByIP(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// ByUser membatasi request berdasarkan user yang login.
+// Fungsi ini harus dipakai setelah AuthorizeJWT karena membaca user ID yang disimpan olehnya.
+func ByUser(c *gin.Context) string {
+	userID := c.GetString(ContextUserIDKey)
+	if userID == "" {
+		return ""
+	}
+	return "user:" + userID
+}
+
+// RateLimit adalah middleware token bucket yang menolak request dengan status 429 ketika bucket milik key kosong.
+// scope membedakan bucket antar pemasangan middleware, misalnya "global" dan "login", agar tidak saling menghabiskan.
+func RateLimit(store ratelimit.Store, scope string, limit ratelimit.Limit, key RateKeyFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		k := key(c)
+		if !limit.Enabled() || k == "" {
+			return
+		}
+		result, err := store.Take(c.Request.Context(), scope+":"+k, limit)
+		if err != nil {
+			AbortWithError(c, apperror.Internal("failed to check rate limit", err))
+			return
+		}
+		c.Header("X-RateLimit-Limit", strconv.Itoa(limit.Burst))          // Kapasitas bucket
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining)) // Sisa request yang boleh dilakukan saat ini
+		if !result.Allowed {
+			AbortWithError(c, ErrRateLimited.WithRetryAfter(result.RetryAfter))
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/helper"  // Mengimport package helper untuk membuat ID acak
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/logging" // Mengimport package logging untuk logger per request
+	"github.com/gin-gonic/gin"                               // Mengimport package gin untuk web framework
+)
+
+// Header dan key gin.Context untuk request ID
+const (
+	RequestIDHeader     = "X-Request-ID" // RequestIDHeader dibaca dari request dan selalu dikirim kembali pada respons
+	ContextRequestIDKey = "request_id"   // ContextRequestIDKey menyimpan request ID di gin.Context
+)
+
+// maxRequestIDLength adalah panjang maksimum request ID dari client, ID yang lebih panjang diganti
+const maxRequestIDLength = 128
+
+// RequestID adalah middleware yang memakai X-Request-ID dari client (misalnya dari load balancer)
+// atau membuat ID baru, mengirimkannya kembali pada respons, dan menambahkannya ke logger request
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id, _ = helper.GenerateRandomToken(12) // Gagal membaca sumber acak hanya membuat request tanpa ID
+		}
+		c.Set(ContextRequestIDKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Request = c.Request.WithContext(logging.With(c.Request.Context(), "request_id", id))
+		c.Next()
+	}
+}
+
+// validRequestID memeriksa bahwa request ID dari client tidak kosong, tidak terlalu panjang,
+// dan hanya berisi karakter yang aman ditulis ke log
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.' || r == ':') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"log/slog" // Mengimport package slog untuk structured logging
+	"time"     // Mengimport package time untuk mengukur durasi request
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/logging" // Mengimport package logging untuk logger per request
+	"github.com/gin-gonic/gin"                               // Mengimport package gin untuk web framework
+)
+
+// RequestLogger adalah middleware yang mencatat satu baris log untuk setiap request,
+// menggantikan gin.Logger. Baris log memakai logger request sehingga berisi request_id,
+// dan user_id jika token valid. Header hanya dicatat pada level debug, dengan Authorization disamarkan.
+// Query string tidak dicatat karena bisa berisi token.
+func RequestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		ctx := c.Request.Context()
+		logger := logging.FromContext(ctx)
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("route", c.FullPath()),
+			slog.Int("status", status),
+			slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.Int("bytes", c.Writer.Size()),
+			slog.String("client_ip", c.ClientIP()),
+			slog.String("user_agent", c.Request.UserAgent()),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("error", c.Errors.Last().Error()))
+		}
+		if logger.Enabled(ctx, slog.LevelDebug) {
+			attrs = append(attrs, logging.Headers(c.Request.Header))
+		}
+		logger.LogAttrs(ctx, level, "request", attrs...)
+	}
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror" // Mengimport package apperror untuk error domain
+	"github.com/gin-gonic/gin"                                // Mengimport package gin untuk web framework
+)
+
+// RequireRole adalah middleware untuk membatasi endpoint hanya untuk role tertentu.
+// Middleware ini harus dipasang setelah AuthorizeJWT karena membaca role yang disimpan olehnya.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString(ContextRoleKey) // Mengambil role dari claim token
+		for _, allowed := range roles {
+			if role == allowed { // Role diizinkan, lanjut ke handler berikutnya
+				c.Next()
+				return
+			}
+		}
+		AbortWithError(c, apperror.Forbidden("Insufficient role").WithCode("insufficient_role")) // Mengirim respons error dengan status 403 Forbidden
+	}
+}
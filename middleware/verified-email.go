@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"strconv" // Mengimport package strconv untuk membaca user ID
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror" // Mengimport package apperror untuk error domain
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service"  // Mengimport package service untuk verifikasi email
+	"github.com/gin-gonic/gin"                                // Mengimport package gin untuk web framework
+)
+
+// RequireVerifiedEmail adalah middleware yang menolak action dari user yang belum memverifikasi email jika policy melarangnya.
+// Status verifikasi dibaca dari database, bukan dari token, agar langsung berlaku setelah user membuka link verifikasi.
+// Middleware ini harus dipasang setelah AuthorizeJWT karena membaca user ID yang disimpan olehnya.
+func RequireVerifiedEmail(verification service.EmailVerificationService, policy service.UnverifiedPolicy, action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if policy.Allows(action) { // Aksi tidak dibatasi, database tidak perlu dibaca
+			return
+		}
+		userID, err := strconv.ParseUint(c.GetString(ContextUserIDKey), 10, 64)
+		if err != nil {
+			AbortWithError(c, apperror.Unauthorized("token does not contain a valid user id").WithCode("token_invalid"))
+			return
+		}
+		verified, err := verification.IsVerified(c.Request.Context(), userID)
+		if err != nil {
+			AbortWithError(c, err)
+			return
+		}
+		if !verified {
+			AbortWithError(c, service.ErrEmailNotVerified)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"        // Mengimport package context untuk batas waktu migrasi
+	"errors"         // Mengimport package errors untuk membuat error penggunaan
+	"fmt"            // Mengimport package fmt untuk mencetak status migrasi
+	"os"             // Mengimport package os untuk output tabel status
+	"strconv"        // Mengimport package strconv untuk membaca jumlah langkah
+	"text/tabwriter" // Mengimport package tabwriter untuk menampilkan tabel status
+	"time"           // Mengimport package time untuk batas waktu migrasi
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/config" // Mengimport konfigurasi aplikasi
+)
+
+// errMigrateUsage dikembalikan ketika argumen perintah migrate tidak dikenal
+var errMigrateUsage = errors.New("usage: migrate up | down [steps] | status")
+
+// runMigrate menjalankan perintah "migrate up", "migrate down [steps]" (default 1 langkah), atau "migrate status"
+func runMigrate(args []string) error {
+	cfg, err := loadConfig("migrate", nil, args, true)
+	if err != nil {
+		return err
+	}
+	args = cfg.Args()
+	if len(args) == 0 {
+		return errMigrateUsage
+	}
+	dbConfig := cfg.Database
+	dbConfig.AutoMigrate = false // Perintah migrate sendiri yang menentukan migrasi yang dijalankan
+	db := config.SetupDatabaseConnection(dbConfig)
+	defer config.CloseDatabaseConnection(db)
+	migrator := config.NewMigrator(db)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	switch args[0] {
+	case "up":
+		done, errUp := migrator.Up(ctx)
+		err = errUp
+		fmt.Printf("%d migration(s) applied\n", len(done))
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps <= 0 {
+				return fmt.Errorf("invalid number of steps %q", args[1])
+			}
+		}
+		done, errDown := migrator.Down(ctx, steps)
+		err = errDown
+		fmt.Printf("%d migration(s) reverted\n", len(done))
+	case "status":
+		statuses, errStatus := migrator.Status(ctx)
+		err = errStatus
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			if status.Missing {
+				appliedAt += " (missing file)"
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		w.Flush()
+	default:
+		return errMigrateUsage
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", args[0], err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package migration
+
+import (
+	"context"  // Mengimport package context untuk batas waktu menunggu lock
+	"errors"   // Mengimport package errors untuk pengecekan error
+	"fmt"      // Mengimport package fmt untuk formatting error
+	"log/slog" // Mengimport package slog untuk mencatat proses menunggu lock
+	"time"     // Mengimport package time untuk waktu lock
+
+	"gorm.io/gorm"        // Mengimport package gorm untuk ORM
+	"gorm.io/gorm/logger" // Mengimport logger gorm agar percobaan mengambil lock tidak dicatat sebagai error
+)
+
+// lockID adalah satu-satunya baris pada tabel lock
+const lockID = 1
+
+// migrationLock adalah baris pada tabel schema_migrations_lock. Baris hanya ada selama
+// sebuah instance menjalankan migrasi, sehingga primary key mencegah dua instance migrasi bersamaan.
+type migrationLock struct {
+	ID       uint      `gorm:"primaryKey;autoIncrement:false"` // ID selalu lockID
+	Owner    string    `gorm:"type:varchar(64);not null"`      // Owner adalah identitas instance pemegang lock
+	LockedAt time.Time `gorm:"not null"`                       // LockedAt adalah waktu lock diambil
+}
+
+// TableName mengembalikan nama tabel lock
+func (migrationLock) TableName() string {
+	return "schema_migrations_lock"
+}
+
+// acquireLock mengambil lock migrasi, menunggu jika instance lain sedang memegangnya.
+// Lock yang tidak diperbarui lebih lama dari staleAfter dianggap ditinggalkan (misalnya proses mati) dan diambil alih.
+// Selama lock dipegang, heartbeat memperbarui LockedAt sehingga migrasi yang berjalan lama tidak dianggap ditinggalkan.
+// Duplicate key dikenali lewat gorm.ErrDuplicatedKey, sehingga db harus dibuka dengan TranslateError.
+func (m *Migrator) acquireLock(ctx context.Context) error {
+	if err := m.ensureTable(ctx, &migrationLock{}); err != nil {
+		return fmt.Errorf("create migration lock table: %w", err)
+	}
+	waiting := false
+	for {
+		lock := migrationLock{ID: lockID, Owner: m.owner, LockedAt: time.Now()}
+		err := m.db.WithContext(ctx).Session(&gorm.Session{Logger: logger.Discard}).Create(&lock).Error // Duplicate key berarti lock dipegang instance lain
+		if err == nil {
+			m.startHeartbeat()
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrDuplicatedKey) {
+			return fmt.Errorf("acquire migration lock: %w", err)
+		}
+
+		var holder migrationLock
+		err = m.db.WithContext(ctx).Take(&holder, lockID).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound): // Lock baru saja dilepas, coba lagi setelah jeda
+		case err != nil:
+			return fmt.Errorf("read migration lock: %w", err)
+		case time.Since(holder.LockedAt) > m.staleAfter:
+			slog.Warn("taking over stale migration lock", "owner", holder.Owner, "locked_at", holder.LockedAt.Format(time.RFC3339))
+			cutoff := time.Now().Add(-m.staleAfter) // Lock yang baru diperbarui heartbeat tidak ikut terhapus
+			err := m.db.WithContext(ctx).Where("id = ? AND owner = ? AND locked_at < ?", lockID, holder.Owner, cutoff).Delete(&migrationLock{}).Error
+			if err != nil {
+				return fmt.Errorf("remove stale migration lock: %w", err)
+			}
+		case !waiting:
+			slog.Info("waiting for migration lock", "owner", holder.Owner)
+			waiting = true
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("acquire migration lock: %w", ctx.Err())
+		case <-time.After(m.pollInterval):
+		}
+	}
+}
+
+// startHeartbeat memperbarui LockedAt secara berkala sampai releaseLock dipanggil
+func (m *Migrator) startHeartbeat() {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	m.stopHeartbeat = func() {
+		close(stop)
+		<-done
+	}
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(m.staleAfter / 3) // Beberapa heartbeat boleh gagal sebelum lock dianggap ditinggalkan
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			res := m.db.Model(&migrationLock{}).Where("id = ? AND owner = ?", lockID, m.owner).Update("locked_at", time.Now())
+			switch {
+			case res.Error != nil:
+				slog.Warn("failed to refresh migration lock", "error", res.Error)
+			case res.RowsAffected == 0:
+				slog.Error("migration lock was taken over by another instance", "owner", m.owner)
+			}
+		}
+	}()
+}
+
+// releaseLock menghentikan heartbeat lalu melepas lock migrasi milik instance ini
+func (m *Migrator) releaseLock() {
+	if m.stopHeartbeat != nil {
+		m.stopHeartbeat()
+		m.stopHeartbeat = nil
+	}
+	err := m.db.Where("id = ? AND owner = ?", lockID, m.owner).Delete(&migrationLock{}).Error
+	if err != nil {
+		slog.Error("failed to release migration lock", "error", err)
+	}
+}
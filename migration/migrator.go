@@ -0,0 +1,190 @@
+package migration
+
+import (
+	"context"  // Mengimport package context untuk pembatalan proses migrasi
+	"fmt"      // Mengimport package fmt untuk formatting error
+	"log/slog" // Mengimport package slog untuk mencatat migrasi yang dijalankan
+	"os"       // Mengimport package os untuk identitas instance
+	"time"     // Mengimport package time untuk waktu migrasi
+
+	"gorm.io/gorm" // Mengimport package gorm untuk ORM
+)
+
+// schemaMigration adalah baris pada tabel schema_migrations yang mencatat migrasi yang sudah diterapkan
+type schemaMigration struct {
+	Version   uint64    `gorm:"primaryKey;autoIncrement:false"` // Version adalah nomor migrasi
+	Name      string    `gorm:"type:varchar(255);not null"`     // Name adalah nama migrasi
+	AppliedAt time.Time `gorm:"not null"`                       // AppliedAt adalah waktu migrasi diterapkan
+}
+
+// TableName mengembalikan nama tabel pencatat migrasi
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Status adalah status satu migrasi pada database
+type Status struct {
+	Version   uint64     // Version adalah nomor migrasi
+	Name      string     // Name adalah nama migrasi
+	AppliedAt *time.Time // AppliedAt diisi jika migrasi sudah diterapkan
+	Missing   bool       // Missing bernilai true jika migrasi tercatat di database tetapi filenya tidak ada di binary ini
+}
+
+// Migrator menjalankan migrasi SQL bernomor untuk satu koneksi database
+type Migrator struct {
+	db            *gorm.DB      // Koneksi database menggunakan gorm
+	migrations    []Migration   // Daftar migrasi untuk dialect database ini, urut berdasarkan versi
+	owner         string        // Identitas instance ini pada tabel lock
+	staleAfter    time.Duration // Lock yang tidak diperbarui lebih lama dari ini dianggap ditinggalkan
+	pollInterval  time.Duration // Jeda antar percobaan mengambil lock
+	stopHeartbeat func()        // Menghentikan heartbeat lock, diisi selama lock dipegang
+}
+
+// New membuat Migrator untuk db dengan migrasi sesuai dialect database tersebut
+func New(db *gorm.DB) (*Migrator, error) {
+	migrations, err := Load(db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	host, _ := os.Hostname()
+	return &Migrator{
+		db:           db,
+		migrations:   migrations,
+		owner:        fmt.Sprintf("%s:%d", host, os.Getpid()),
+		staleAfter:   15 * time.Minute,
+		pollInterval: time.Second,
+	}, nil
+}
+
+// Up menerapkan semua migrasi yang belum diterapkan secara berurutan dan mengembalikan migrasi yang dijalankan
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	if err := m.acquireLock(ctx); err != nil {
+		return nil, err
+	}
+	defer m.releaseLock()
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var done []Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		err := m.run(ctx, migration.Up, func(tx *gorm.DB) error {
+			return tx.Create(&schemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+		slog.Info("applied migration", "version", migration.Version, "name", migration.Name)
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Down membatalkan steps migrasi terakhir yang sudah diterapkan, dari versi terbesar
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if err := m.acquireLock(ctx); err != nil {
+		return nil, err
+	}
+	defer m.releaseLock()
+
+	var rows []schemaMigration
+	if err := m.db.WithContext(ctx).Order("version DESC").Limit(steps).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	var done []Migration
+	for _, row := range rows {
+		migration, ok := m.find(row.Version)
+		if !ok {
+			return done, fmt.Errorf("migration %04d_%s is not known to this binary", row.Version, row.Name)
+		}
+		if migration.Down == "" {
+			return done, fmt.Errorf("migration %04d_%s cannot be reverted", row.Version, row.Name)
+		}
+		err := m.run(ctx, migration.Down, func(tx *gorm.DB) error {
+			return tx.Delete(&schemaMigration{}, row.Version).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("revert migration %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+		slog.Info("reverted migration", "version", migration.Version, "name", migration.Name)
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Status mengembalikan status semua migrasi, termasuk migrasi di database yang tidak dikenal binary ini
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if row, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &row.AppliedAt
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, row := range applied { // Sisa migrasi yang tercatat tetapi tidak ada filenya
+		row := row
+		statuses = append(statuses, Status{Version: row.Version, Name: row.Name, AppliedAt: &row.AppliedAt, Missing: true})
+	}
+	return statuses, nil
+}
+
+// applied mengembalikan migrasi yang sudah tercatat di tabel schema_migrations
+func (m *Migrator) applied(ctx context.Context) (map[uint64]schemaMigration, error) {
+	if err := m.ensureTable(ctx, &schemaMigration{}); err != nil {
+		return nil, fmt.Errorf("create schema_migrations table: %w", err)
+	}
+	var rows []schemaMigration
+	if err := m.db.WithContext(ctx).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	applied := make(map[uint64]schemaMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+// run menjalankan perintah SQL lalu record dalam satu transaksi.
+// Catatan: MySQL melakukan commit otomatis untuk DDL, sehingga migrasi yang gagal di tengah harus diperbaiki manual.
+func (m *Migrator) run(ctx context.Context, sql string, record func(tx *gorm.DB) error) error {
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, statement := range statements(sql) {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return record(tx)
+	})
+}
+
+// find mencari migrasi berdasarkan versi
+func (m *Migrator) find(version uint64) (Migration, bool) {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration, true
+		}
+	}
+	return Migration{}, false
+}
+
+// ensureTable membuat tabel pencatat milik migrator jika belum ada
+func (m *Migrator) ensureTable(ctx context.Context, model interface{}) error {
+	migrator := m.db.WithContext(ctx).Migrator()
+	if migrator.HasTable(model) {
+		return nil
+	}
+	if err := migrator.CreateTable(model); err != nil && !migrator.HasTable(model) { // Instance lain mungkin membuatnya bersamaan
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+-- Skema awal. Database yang sebelumnya dibuat oleh AutoMigrate sudah memiliki sebagian tabel,
+-- sehingga tabel dibuat dengan IF NOT EXISTS lalu kolom yang belum ada ditambahkan di akhir file.
+CREATE TABLE IF NOT EXISTS `users` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `name` varchar(255),
+  `email` varchar(255),
+  `password` longtext NOT NULL,
+  `role` varchar(20) NOT NULL DEFAULT 'reader',
+  `suspended` boolean NOT NULL DEFAULT false,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_users_email` (`email`)
+);
+
+CREATE TABLE IF NOT EXISTS `books` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `title` varchar(255),
+  `description` text,
+  `user_id` bigint unsigned NOT NULL,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_books_created_at` (`created_at`),
+  FULLTEXT INDEX `idx_books_fulltext` (`title`, `description`),
+  CONSTRAINT `fk_users_books` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS `refresh_tokens` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` bigint unsigned NOT NULL,
+  `family_id` varchar(64) NOT NULL,
+  `token_hash` varchar(64) NOT NULL,
+  `expires_at` datetime(3) NOT NULL,
+  `revoked_at` datetime(3) NULL,
+  `replaced_by` bigint unsigned,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_refresh_tokens_user_id` (`user_id`),
+  INDEX `idx_refresh_tokens_family_id` (`family_id`),
+  UNIQUE INDEX `idx_refresh_tokens_token_hash` (`token_hash`)
+);
+
+CREATE TABLE IF NOT EXISTS `revoked_tokens` (
+  `jti` varchar(64) NOT NULL,
+  `expires_at` datetime(3) NOT NULL,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`jti`),
+  INDEX `idx_revoked_tokens_expires_at` (`expires_at`)
+);
+
+CREATE TABLE IF NOT EXISTS `user_revocations` (
+  `user_id` bigint unsigned NOT NULL,
+  `revoked_before` datetime(3) NOT NULL,
+  PRIMARY KEY (`user_id`)
+);
+
+-- Database dari versi sebelum role (users) dan pagination (books) belum memiliki kolom berikut.
+-- MySQL tidak mendukung ADD COLUMN IF NOT EXISTS, sehingga ALTER TABLE hanya dijalankan jika kolomnya belum ada.
+-- Index FULLTEXT tidak ditambahkan di sini karena dibuat saat aplikasi start jika SEARCH_BACKEND memakai mysql.
+SET @ddl = IF(
+  (SELECT COUNT(*) FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'users' AND COLUMN_NAME = 'role') = 0,
+  'ALTER TABLE `users` ADD COLUMN `role` varchar(20) NOT NULL DEFAULT ''reader'', ADD COLUMN `suspended` boolean NOT NULL DEFAULT false',
+  'DO 0');
+PREPARE ddl FROM @ddl;
+EXECUTE ddl;
+DEALLOCATE PREPARE ddl;
+
+SET @ddl = IF(
+  (SELECT COUNT(*) FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'books' AND COLUMN_NAME = 'created_at') = 0,
+  'ALTER TABLE `books` ADD COLUMN `created_at` datetime(3) NULL, ADD COLUMN `updated_at` datetime(3) NULL, ADD INDEX `idx_books_created_at` (`created_at`)',
+  'DO 0');
+PREPARE ddl FROM @ddl;
+EXECUTE ddl;
+DEALLOCATE PREPARE ddl;
@@ -0,0 +1,4 @@
+-- Menyimpan jumlah login gagal berturut-turut dan batas waktu penguncian akun.
+ALTER TABLE `users`
+  ADD COLUMN `failed_logins` int NOT NULL DEFAULT 0,
+  ADD COLUMN `locked_until` datetime(3) NULL;
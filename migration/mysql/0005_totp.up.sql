@@ -0,0 +1,16 @@
+-- Menyimpan secret TOTP, status 2FA, dan time step kode terakhir yang dipakai, serta kode pemulihan 2FA.
+ALTER TABLE `users`
+  ADD COLUMN `totp_secret` varchar(64) NOT NULL DEFAULT '',
+  ADD COLUMN `totp_enabled` boolean NOT NULL DEFAULT false,
+  ADD COLUMN `totp_last_step` bigint NOT NULL DEFAULT 0;
+CREATE TABLE `recovery_codes` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` bigint unsigned NOT NULL,
+  `code_hash` varchar(64) NOT NULL,
+  `used_at` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_recovery_codes_user_id` (`user_id`),
+  UNIQUE INDEX `idx_recovery_codes_code_hash` (`code_hash`),
+  CONSTRAINT `fk_recovery_codes_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+);
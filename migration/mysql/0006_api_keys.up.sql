@@ -0,0 +1,16 @@
+CREATE TABLE `api_keys` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` bigint unsigned NOT NULL,
+  `name` varchar(100) NOT NULL,
+  `prefix` varchar(16) NOT NULL,
+  `key_hash` varchar(64) NOT NULL,
+  `scopes` varchar(255) NOT NULL,
+  `expires_at` datetime(3) NULL,
+  `last_used_at` datetime(3) NULL,
+  `revoked_at` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_api_keys_user_id` (`user_id`),
+  UNIQUE INDEX `idx_api_keys_key_hash` (`key_hash`),
+  CONSTRAINT `fk_api_keys_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+);
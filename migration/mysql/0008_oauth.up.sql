@@ -0,0 +1,50 @@
+CREATE TABLE `oauth_clients` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `client_id` varchar(64) NOT NULL,
+  `secret_hash` varchar(64),
+  `name` varchar(100) NOT NULL,
+  `redirect_uris` text NOT NULL,
+  `scopes` varchar(255) NOT NULL,
+  `created_by` bigint unsigned NOT NULL,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_oauth_clients_client_id` (`client_id`)
+);
+CREATE TABLE `oauth_authorization_codes` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `code_hash` varchar(64) NOT NULL,
+  `client_id` bigint unsigned NOT NULL,
+  `user_id` bigint unsigned NOT NULL,
+  `family_id` varchar(64) NOT NULL,
+  `redirect_uri` text NOT NULL,
+  `scopes` varchar(255) NOT NULL,
+  `code_challenge` varchar(128) NOT NULL,
+  `expires_at` datetime(3) NOT NULL,
+  `used_at` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_oauth_authorization_codes_code_hash` (`code_hash`),
+  INDEX `idx_oauth_authorization_codes_client_id` (`client_id`),
+  INDEX `idx_oauth_authorization_codes_user_id` (`user_id`),
+  CONSTRAINT `fk_oauth_authorization_codes_client` FOREIGN KEY (`client_id`) REFERENCES `oauth_clients` (`id`) ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT `fk_oauth_authorization_codes_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE TABLE `oauth_refresh_tokens` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `client_id` bigint unsigned NOT NULL,
+  `user_id` bigint unsigned NOT NULL,
+  `family_id` varchar(64) NOT NULL,
+  `token_hash` varchar(64) NOT NULL,
+  `scopes` varchar(255) NOT NULL,
+  `expires_at` datetime(3) NOT NULL,
+  `revoked_at` datetime(3) NULL,
+  `replaced_by` bigint unsigned NULL,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_oauth_refresh_tokens_token_hash` (`token_hash`),
+  INDEX `idx_oauth_refresh_tokens_client_id` (`client_id`),
+  INDEX `idx_oauth_refresh_tokens_user_id` (`user_id`),
+  INDEX `idx_oauth_refresh_tokens_family_id` (`family_id`),
+  CONSTRAINT `fk_oauth_refresh_tokens_client` FOREIGN KEY (`client_id`) REFERENCES `oauth_clients` (`id`) ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT `fk_oauth_refresh_tokens_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+);
@@ -0,0 +1,14 @@
+CREATE TABLE `sessions` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` bigint unsigned NOT NULL,
+  `family_id` varchar(64) NOT NULL,
+  `device_name` varchar(100),
+  `user_agent` varchar(255),
+  `ip_address` varchar(45),
+  `created_at` datetime(3) NULL,
+  `last_seen_at` datetime(3) NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_sessions_user_id` (`user_id`),
+  UNIQUE INDEX `idx_sessions_family_id` (`family_id`),
+  CONSTRAINT `fk_sessions_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+);
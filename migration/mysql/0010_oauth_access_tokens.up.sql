@@ -0,0 +1,13 @@
+CREATE TABLE `oauth_access_tokens` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `client_id` bigint unsigned NOT NULL,
+  `family_id` varchar(64) NOT NULL,
+  `jti` varchar(64) NOT NULL,
+  `expires_at` datetime(3) NOT NULL,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_oauth_access_tokens_client_id` (`client_id`),
+  INDEX `idx_oauth_access_tokens_family_id` (`family_id`),
+  INDEX `idx_oauth_access_tokens_expires_at` (`expires_at`),
+  CONSTRAINT `fk_oauth_access_tokens_client` FOREIGN KEY (`client_id`) REFERENCES `oauth_clients` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+);
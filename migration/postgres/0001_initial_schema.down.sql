@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "user_revocations";
+DROP TABLE IF EXISTS "revoked_tokens";
+DROP TABLE IF EXISTS "refresh_tokens";
+DROP TABLE IF EXISTS "books";
+DROP TABLE IF EXISTS "users";
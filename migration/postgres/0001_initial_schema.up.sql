@@ -0,0 +1,53 @@
+-- Skema awal. Database yang sebelumnya dibuat oleh AutoMigrate sudah memiliki sebagian tabel,
+-- sehingga tabel dibuat dengan IF NOT EXISTS lalu kolom yang ditambahkan setelah tabel dibuat
+-- dipastikan ada dengan ADD COLUMN IF NOT EXISTS.
+CREATE TABLE IF NOT EXISTS "users" (
+  "id" bigserial PRIMARY KEY,
+  "name" varchar(255),
+  "email" varchar(255),
+  "password" text NOT NULL,
+  "role" varchar(20) NOT NULL DEFAULT 'reader',
+  "suspended" boolean NOT NULL DEFAULT false
+);
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "role" varchar(20) NOT NULL DEFAULT 'reader';
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "suspended" boolean NOT NULL DEFAULT false;
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_email" ON "users" ("email");
+
+CREATE TABLE IF NOT EXISTS "books" (
+  "id" bigserial PRIMARY KEY,
+  "title" varchar(255),
+  "description" text,
+  "user_id" bigint NOT NULL,
+  "created_at" timestamptz,
+  "updated_at" timestamptz,
+  CONSTRAINT "fk_users_books" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+ALTER TABLE "books" ADD COLUMN IF NOT EXISTS "created_at" timestamptz;
+ALTER TABLE "books" ADD COLUMN IF NOT EXISTS "updated_at" timestamptz;
+CREATE INDEX IF NOT EXISTS "idx_books_created_at" ON "books" ("created_at");
+
+CREATE TABLE IF NOT EXISTS "refresh_tokens" (
+  "id" bigserial PRIMARY KEY,
+  "user_id" bigint NOT NULL,
+  "family_id" varchar(64) NOT NULL,
+  "token_hash" varchar(64) NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "revoked_at" timestamptz,
+  "replaced_by" bigint,
+  "created_at" timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_refresh_tokens_token_hash" ON "refresh_tokens" ("token_hash");
+CREATE INDEX IF NOT EXISTS "idx_refresh_tokens_family_id" ON "refresh_tokens" ("family_id");
+CREATE INDEX IF NOT EXISTS "idx_refresh_tokens_user_id" ON "refresh_tokens" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "revoked_tokens" (
+  "jti" varchar(64) PRIMARY KEY,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz
+);
+CREATE INDEX IF NOT EXISTS "idx_revoked_tokens_expires_at" ON "revoked_tokens" ("expires_at");
+
+CREATE TABLE IF NOT EXISTS "user_revocations" (
+  "user_id" bigint PRIMARY KEY,
+  "revoked_before" timestamptz NOT NULL
+);
@@ -0,0 +1,11 @@
+CREATE TABLE "password_reset_tokens" (
+  "id" bigserial PRIMARY KEY,
+  "user_id" bigint NOT NULL,
+  "token_hash" varchar(64) NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "used_at" timestamptz,
+  "created_at" timestamptz,
+  CONSTRAINT "fk_password_reset_tokens_user" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX "idx_password_reset_tokens_token_hash" ON "password_reset_tokens" ("token_hash");
+CREATE INDEX "idx_password_reset_tokens_user_id" ON "password_reset_tokens" ("user_id");
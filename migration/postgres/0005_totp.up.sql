@@ -0,0 +1,15 @@
+-- Menyimpan secret TOTP, status 2FA, dan time step kode terakhir yang dipakai, serta kode pemulihan 2FA.
+ALTER TABLE "users"
+  ADD COLUMN "totp_secret" varchar(64) NOT NULL DEFAULT '',
+  ADD COLUMN "totp_enabled" boolean NOT NULL DEFAULT false,
+  ADD COLUMN "totp_last_step" bigint NOT NULL DEFAULT 0;
+CREATE TABLE "recovery_codes" (
+  "id" bigserial PRIMARY KEY,
+  "user_id" bigint NOT NULL,
+  "code_hash" varchar(64) NOT NULL,
+  "used_at" timestamptz,
+  "created_at" timestamptz,
+  CONSTRAINT "fk_recovery_codes_user" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX "idx_recovery_codes_code_hash" ON "recovery_codes" ("code_hash");
+CREATE INDEX "idx_recovery_codes_user_id" ON "recovery_codes" ("user_id");
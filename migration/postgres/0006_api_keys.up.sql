@@ -0,0 +1,15 @@
+CREATE TABLE "api_keys" (
+  "id" bigserial PRIMARY KEY,
+  "user_id" bigint NOT NULL,
+  "name" varchar(100) NOT NULL,
+  "prefix" varchar(16) NOT NULL,
+  "key_hash" varchar(64) NOT NULL,
+  "scopes" varchar(255) NOT NULL,
+  "expires_at" timestamptz,
+  "last_used_at" timestamptz,
+  "revoked_at" timestamptz,
+  "created_at" timestamptz,
+  CONSTRAINT "fk_api_keys_user" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX "idx_api_keys_key_hash" ON "api_keys" ("key_hash");
+CREATE INDEX "idx_api_keys_user_id" ON "api_keys" ("user_id");
@@ -0,0 +1,12 @@
+CREATE TABLE "oauth_access_tokens" (
+  "id" bigserial PRIMARY KEY,
+  "client_id" bigint NOT NULL,
+  "family_id" varchar(64) NOT NULL,
+  "jti" varchar(64) NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz,
+  CONSTRAINT "fk_oauth_access_tokens_client" FOREIGN KEY ("client_id") REFERENCES "oauth_clients" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE INDEX "idx_oauth_access_tokens_client_id" ON "oauth_access_tokens" ("client_id");
+CREATE INDEX "idx_oauth_access_tokens_family_id" ON "oauth_access_tokens" ("family_id");
+CREATE INDEX "idx_oauth_access_tokens_expires_at" ON "oauth_access_tokens" ("expires_at");
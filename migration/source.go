@@ -0,0 +1,105 @@
+package migration
+
+import (
+	"embed"   // Mengimport package embed untuk menyertakan file SQL ke dalam binary
+	"fmt"     // Mengimport package fmt untuk formatting error
+	"io/fs"   // Mengimport package fs untuk membaca file migrasi
+	"path"    // Mengimport package path untuk nama file di dalam embed.FS
+	"sort"    // Mengimport package sort untuk mengurutkan migrasi
+	"strconv" // Mengimport package strconv untuk membaca nomor versi
+	"strings" // Mengimport package strings untuk memecah nama file dan isi SQL
+)
+
+// files berisi migrasi untuk setiap dialect, dengan nama file <versi>_<nama>.up.sql dan <versi>_<nama>.down.sql
+//
+//go:embed mysql postgres sqlite
+var files embed.FS
+
+// Migration adalah satu perubahan skema bernomor beserta SQL untuk menerapkan dan membatalkannya
+type Migration struct {
+	Version uint64 // Version adalah nomor urut migrasi
+	Name    string // Name adalah nama singkat migrasi
+	Up      string // Up adalah SQL untuk menerapkan migrasi
+	Down    string // Down adalah SQL untuk membatalkan migrasi, kosong jika migrasi tidak bisa dibatalkan
+}
+
+// Load membaca semua migrasi untuk dialect tertentu ("mysql", "postgres", atau "sqlite"), urut berdasarkan versi
+func Load(dialect string) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, dialect)
+	if err != nil {
+		return nil, fmt.Errorf("no migrations for dialect %q", dialect)
+	}
+	byVersion := map[uint64]*Migration{}
+	for _, entry := range entries {
+		name := entry.Name()
+		base, direction, ok := splitFileName(name)
+		if !ok {
+			return nil, fmt.Errorf("migration %s: expected <version>_<name>.up.sql or .down.sql", name)
+		}
+		versionText, title, _ := strings.Cut(base, "_")
+		version, err := strconv.ParseUint(versionText, 10, 64)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("migration %s: invalid version %q", name, versionText)
+		}
+		data, err := fs.ReadFile(files, path.Join(dialect, name))
+		if err != nil {
+			return nil, err
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: title}
+			byVersion[version] = m
+		} else if m.Name != title {
+			return nil, fmt.Errorf("migration version %d is used by both %q and %q", version, m.Name, title)
+		}
+		if direction == "up" {
+			m.Up = string(data)
+		} else {
+			m.Down = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// splitFileName memisahkan "0001_init.up.sql" menjadi "0001_init" dan "up"
+func splitFileName(name string) (string, string, bool) {
+	for _, direction := range []string{"up", "down"} {
+		if base, ok := strings.CutSuffix(name, "."+direction+".sql"); ok {
+			return base, direction, true
+		}
+	}
+	return "", "", false
+}
+
+// statements memecah isi file SQL menjadi perintah-perintah terpisah karena tidak semua driver
+// (misalnya MySQL) mengizinkan beberapa perintah dalam satu Exec. Perintah dipisah dengan ";"
+// di akhir baris, dan baris komentar "--" diabaikan.
+func statements(sql string) []string {
+	var result []string
+	var current strings.Builder
+	for _, line := range strings.Split(sql, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			result = append(result, strings.TrimSpace(current.String()))
+			current.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		result = append(result, rest)
+	}
+	return result
+}
@@ -0,0 +1,50 @@
+-- Skema awal. IF NOT EXISTS membuat migrasi ini aman dijalankan pada database SQLite
+-- yang sebelumnya dibuat oleh AutoMigrate: SQLite baru didukung setelah semua kolom di bawah ada
+-- di entity, sehingga tabel yang sudah ada tidak kekurangan kolom. SQLite tidak mendukung
+-- ADD COLUMN IF NOT EXISTS, jadi kolom baru harus ditambahkan lewat migrasi bernomor berikutnya.
+CREATE TABLE IF NOT EXISTS `users` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `name` varchar(255),
+  `email` varchar(255),
+  `password` text NOT NULL,
+  `role` varchar(20) NOT NULL DEFAULT 'reader',
+  `suspended` numeric NOT NULL DEFAULT false
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_users_email` ON `users` (`email`);
+
+CREATE TABLE IF NOT EXISTS `books` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `title` varchar(255),
+  `description` text,
+  `user_id` integer NOT NULL,
+  `created_at` datetime,
+  `updated_at` datetime,
+  CONSTRAINT `fk_users_books` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS `idx_books_created_at` ON `books` (`created_at`);
+
+CREATE TABLE IF NOT EXISTS `refresh_tokens` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `user_id` integer NOT NULL,
+  `family_id` varchar(64) NOT NULL,
+  `token_hash` varchar(64) NOT NULL,
+  `expires_at` datetime NOT NULL,
+  `revoked_at` datetime,
+  `replaced_by` integer,
+  `created_at` datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_refresh_tokens_token_hash` ON `refresh_tokens` (`token_hash`);
+CREATE INDEX IF NOT EXISTS `idx_refresh_tokens_family_id` ON `refresh_tokens` (`family_id`);
+CREATE INDEX IF NOT EXISTS `idx_refresh_tokens_user_id` ON `refresh_tokens` (`user_id`);
+
+CREATE TABLE IF NOT EXISTS `revoked_tokens` (
+  `jti` varchar(64) PRIMARY KEY,
+  `expires_at` datetime NOT NULL,
+  `created_at` datetime
+);
+CREATE INDEX IF NOT EXISTS `idx_revoked_tokens_expires_at` ON `revoked_tokens` (`expires_at`);
+
+CREATE TABLE IF NOT EXISTS `user_revocations` (
+  `user_id` integer PRIMARY KEY,
+  `revoked_before` datetime NOT NULL
+);
@@ -0,0 +1,2 @@
+ALTER TABLE `users` DROP COLUMN `locked_until`;
+ALTER TABLE `users` DROP COLUMN `failed_logins`;
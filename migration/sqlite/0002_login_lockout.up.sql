@@ -0,0 +1,4 @@
+-- Menyimpan jumlah login gagal berturut-turut dan batas waktu penguncian akun.
+-- SQLite hanya menerima satu kolom untuk setiap ALTER TABLE.
+ALTER TABLE `users` ADD COLUMN `failed_logins` integer NOT NULL DEFAULT 0;
+ALTER TABLE `users` ADD COLUMN `locked_until` datetime;
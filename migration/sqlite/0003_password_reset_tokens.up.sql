@@ -0,0 +1,11 @@
+CREATE TABLE `password_reset_tokens` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `user_id` integer NOT NULL,
+  `token_hash` varchar(64) NOT NULL,
+  `expires_at` datetime NOT NULL,
+  `used_at` datetime,
+  `created_at` datetime,
+  CONSTRAINT `fk_password_reset_tokens_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX `idx_password_reset_tokens_token_hash` ON `password_reset_tokens` (`token_hash`);
+CREATE INDEX `idx_password_reset_tokens_user_id` ON `password_reset_tokens` (`user_id`);
@@ -0,0 +1,3 @@
+-- User yang sudah ada dianggap terverifikasi agar tidak kehilangan akses.
+ALTER TABLE `users` ADD COLUMN `email_verified_at` datetime;
+UPDATE `users` SET `email_verified_at` = CURRENT_TIMESTAMP;
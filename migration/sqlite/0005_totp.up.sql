@@ -0,0 +1,15 @@
+-- Menyimpan secret TOTP, status 2FA, dan time step kode terakhir yang dipakai, serta kode pemulihan 2FA.
+-- SQLite hanya menerima satu kolom untuk setiap ALTER TABLE.
+ALTER TABLE `users` ADD COLUMN `totp_secret` varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE `users` ADD COLUMN `totp_enabled` numeric NOT NULL DEFAULT false;
+ALTER TABLE `users` ADD COLUMN `totp_last_step` integer NOT NULL DEFAULT 0;
+CREATE TABLE `recovery_codes` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `user_id` integer NOT NULL,
+  `code_hash` varchar(64) NOT NULL,
+  `used_at` datetime,
+  `created_at` datetime,
+  CONSTRAINT `fk_recovery_codes_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX `idx_recovery_codes_code_hash` ON `recovery_codes` (`code_hash`);
+CREATE INDEX `idx_recovery_codes_user_id` ON `recovery_codes` (`user_id`);
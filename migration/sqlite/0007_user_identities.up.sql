@@ -0,0 +1,11 @@
+CREATE TABLE `user_identities` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `user_id` integer NOT NULL,
+  `provider` varchar(50) NOT NULL,
+  `subject` varchar(255) NOT NULL,
+  `email` varchar(255),
+  `created_at` datetime,
+  CONSTRAINT `fk_user_identities_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX `idx_user_identities_subject` ON `user_identities` (`provider`, `subject`);
+CREATE INDEX `idx_user_identities_user_id` ON `user_identities` (`user_id`);
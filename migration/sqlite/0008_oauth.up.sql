@@ -0,0 +1,47 @@
+CREATE TABLE `oauth_clients` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `client_id` varchar(64) NOT NULL,
+  `secret_hash` varchar(64),
+  `name` varchar(100) NOT NULL,
+  `redirect_uris` text NOT NULL,
+  `scopes` varchar(255) NOT NULL,
+  `created_by` integer NOT NULL,
+  `created_at` datetime
+);
+CREATE UNIQUE INDEX `idx_oauth_clients_client_id` ON `oauth_clients` (`client_id`);
+CREATE TABLE `oauth_authorization_codes` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `code_hash` varchar(64) NOT NULL,
+  `client_id` integer NOT NULL,
+  `user_id` integer NOT NULL,
+  `family_id` varchar(64) NOT NULL,
+  `redirect_uri` text NOT NULL,
+  `scopes` varchar(255) NOT NULL,
+  `code_challenge` varchar(128) NOT NULL,
+  `expires_at` datetime NOT NULL,
+  `used_at` datetime,
+  `created_at` datetime,
+  CONSTRAINT `fk_oauth_authorization_codes_client` FOREIGN KEY (`client_id`) REFERENCES `oauth_clients` (`id`) ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT `fk_oauth_authorization_codes_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX `idx_oauth_authorization_codes_code_hash` ON `oauth_authorization_codes` (`code_hash`);
+CREATE INDEX `idx_oauth_authorization_codes_client_id` ON `oauth_authorization_codes` (`client_id`);
+CREATE INDEX `idx_oauth_authorization_codes_user_id` ON `oauth_authorization_codes` (`user_id`);
+CREATE TABLE `oauth_refresh_tokens` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `client_id` integer NOT NULL,
+  `user_id` integer NOT NULL,
+  `family_id` varchar(64) NOT NULL,
+  `token_hash` varchar(64) NOT NULL,
+  `scopes` varchar(255) NOT NULL,
+  `expires_at` datetime NOT NULL,
+  `revoked_at` datetime,
+  `replaced_by` integer,
+  `created_at` datetime,
+  CONSTRAINT `fk_oauth_refresh_tokens_client` FOREIGN KEY (`client_id`) REFERENCES `oauth_clients` (`id`) ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT `fk_oauth_refresh_tokens_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX `idx_oauth_refresh_tokens_token_hash` ON `oauth_refresh_tokens` (`token_hash`);
+CREATE INDEX `idx_oauth_refresh_tokens_client_id` ON `oauth_refresh_tokens` (`client_id`);
+CREATE INDEX `idx_oauth_refresh_tokens_user_id` ON `oauth_refresh_tokens` (`user_id`);
+CREATE INDEX `idx_oauth_refresh_tokens_family_id` ON `oauth_refresh_tokens` (`family_id`);
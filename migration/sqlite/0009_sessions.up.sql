@@ -0,0 +1,13 @@
+CREATE TABLE `sessions` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `user_id` integer NOT NULL,
+  `family_id` varchar(64) NOT NULL,
+  `device_name` varchar(100),
+  `user_agent` varchar(255),
+  `ip_address` varchar(45),
+  `created_at` datetime,
+  `last_seen_at` datetime NOT NULL,
+  CONSTRAINT `fk_sessions_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX `idx_sessions_family_id` ON `sessions` (`family_id`);
+CREATE INDEX `idx_sessions_user_id` ON `sessions` (`user_id`);
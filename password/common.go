@@ -0,0 +1,27 @@
+package password
+
+import (
+	_ "embed" // Mengimport package embed untuk menyertakan daftar password umum di dalam binary
+	"strings" // Mengimport package strings untuk memecah daftar per baris
+)
+
+// commonPasswords adalah daftar password yang paling sering dipakai dan muncul di kebocoran data
+//
+//go:embed common-passwords.txt
+var commonPasswords string
+
+// CommonPasswords mengembalikan daftar password umum bawaan, satu password per elemen
+func CommonPasswords() []string {
+	return ParseList(commonPasswords)
+}
+
+// ParseList membaca daftar password dengan satu password per baris, baris kosong dilewati
+func ParseList(list string) []string {
+	var passwords []string
+	for _, line := range strings.Split(list, "\n") {
+		if line = strings.TrimRight(line, "\r"); line != "" {
+			passwords = append(passwords, line)
+		}
+	}
+	return passwords
+}
@@ -0,0 +1,149 @@
+// Package password berisi hashing password user. Hash yang tersimpan memuat algoritma dan
+// parameternya, sehingga hash lama tetap bisa diverifikasi setelah algoritma atau cost diganti
+// dan bisa diganti dengan hash baru saat user berhasil login.
+package password
+
+import (
+	"crypto/rand"     // Mengimport package crypto/rand untuk salt acak
+	"crypto/subtle"   // Mengimport package subtle untuk membandingkan hash tanpa kebocoran waktu
+	"encoding/base64" // Mengimport package base64 untuk format hash argon2id
+	"errors"          // Mengimport package errors untuk error format hash
+	"fmt"             // Mengimport package fmt untuk menyusun dan membaca format hash argon2id
+	"strings"         // Mengimport package strings untuk memecah format hash
+
+	"golang.org/x/crypto/argon2" // Mengimport package argon2 untuk hashing argon2id
+	"golang.org/x/crypto/bcrypt" // Mengimport package bcrypt untuk hashing bcrypt
+)
+
+// Nama algoritma yang bisa dipilih lewat konfigurasi
+const (
+	AlgorithmArgon2id = "argon2id" // Argon2id, direkomendasikan untuk password baru
+	AlgorithmBcrypt   = "bcrypt"   // Bcrypt, untuk deployment yang belum bisa memakai argon2id
+)
+
+// BcryptMaxBytes adalah panjang maksimum password dalam byte yang bisa dihash bcrypt
+const BcryptMaxBytes = 72
+
+const (
+	argon2SaltLength = 16 // Panjang salt argon2id dalam byte
+	argon2KeyLength  = 32 // Panjang hash argon2id dalam byte
+)
+
+// errMalformedHash dikembalikan ketika hash tersimpan tidak bisa dibaca
+var errMalformedHash = errors.New("malformed password hash")
+
+// Hasher adalah interface untuk menghash dan memverifikasi password
+type Hasher interface {
+	Hash(password string) (string, error)                       // Fungsi untuk menghash password baru
+	Verify(hash string, password string) (ok bool, rehash bool) // Fungsi untuk memeriksa password, rehash true jika hash sebaiknya dibuat ulang dengan parameter saat ini
+}
+
+// Argon2idHasher menghash password dengan argon2id dalam format PHC, misalnya $argon2id$v=19$m=19456,t=2,p=1$salt$hash
+type Argon2idHasher struct {
+	Memory      uint32 // Memori yang dipakai dalam KiB
+	Iterations  uint32 // Jumlah iterasi
+	Parallelism uint8  // Jumlah thread
+}
+
+// Hash adalah implementasi fungsi Hash dari Hasher
+func (h Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, h.Iterations, h.Memory, h.Parallelism, argon2KeyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, h.Memory, h.Iterations, h.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Verify adalah implementasi fungsi Verify dari Hasher. Hash yang bukan argon2id selalu tidak cocok.
+func (h Argon2idHasher) Verify(hash string, password string) (bool, bool) {
+	params, salt, key, err := parseArgon2id(hash)
+	if err != nil {
+		return false, false
+	}
+	actual := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+	if subtle.ConstantTimeCompare(actual, key) != 1 {
+		return false, false
+	}
+	return true, params != h || len(salt) != argon2SaltLength || len(key) != argon2KeyLength
+}
+
+// parseArgon2id membaca parameter, salt, dan hash dari format PHC argon2id
+func parseArgon2id(hash string) (Argon2idHasher, []byte, []byte, error) {
+	parts := strings.Split(hash, "$") // "", "argon2id", "v=19", "m=...,t=...,p=...", salt, hash
+	if len(parts) != 6 || parts[1] != AlgorithmArgon2id {
+		return Argon2idHasher{}, nil, nil, errMalformedHash
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return Argon2idHasher{}, nil, nil, errMalformedHash
+	}
+	var params Argon2idHasher
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return Argon2idHasher{}, nil, nil, errMalformedHash
+	}
+	if params.Iterations == 0 || params.Parallelism == 0 {
+		return Argon2idHasher{}, nil, nil, errMalformedHash
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return Argon2idHasher{}, nil, nil, errMalformedHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return Argon2idHasher{}, nil, nil, errMalformedHash
+	}
+	return params, salt, key, nil
+}
+
+// BcryptHasher menghash password dengan bcrypt. Bcrypt menolak password yang lebih panjang dari
+// BcryptMaxBytes, kebijakan password harus membatasi panjangnya jika bcrypt dipakai.
+type BcryptHasher struct {
+	Cost int // Cost bcrypt, setiap kenaikan satu menggandakan waktu hashing
+}
+
+// Hash adalah implementasi fungsi Hash dari Hasher
+func (h BcryptHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.Cost)
+	return string(hash), err
+}
+
+// Verify adalah implementasi fungsi Verify dari Hasher. Hash yang bukan bcrypt selalu tidak cocok.
+func (h BcryptHasher) Verify(hash string, password string) (bool, bool) {
+	if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) != nil {
+		return false, false
+	}
+	cost, err := bcrypt.Cost([]byte(hash))
+	return true, err != nil || cost != h.Cost
+}
+
+// multiHasher menghash dengan satu algoritma dan memverifikasi hash dari semua algoritma yang didukung
+type multiHasher struct {
+	preferred Hasher   // Hasher untuk password baru
+	legacy    []Hasher // Hasher lain yang hash-nya masih bisa diverifikasi
+}
+
+// NewHasher membuat Hasher yang menghash password baru dengan preferred dan tetap menerima hash dari legacy.
+// Password yang cocok dengan hash legacy selalu ditandai perlu di-rehash.
+func NewHasher(preferred Hasher, legacy ...Hasher) Hasher {
+	return &multiHasher{preferred: preferred, legacy: legacy}
+}
+
+// Hash adalah implementasi fungsi Hash dari Hasher
+func (h *multiHasher) Hash(password string) (string, error) {
+	return h.preferred.Hash(password)
+}
+
+// Verify adalah implementasi fungsi Verify dari Hasher
+func (h *multiHasher) Verify(hash string, password string) (bool, bool) {
+	if ok, rehash := h.preferred.Verify(hash, password); ok {
+		return true, rehash
+	}
+	for _, legacy := range h.legacy {
+		if ok, _ := legacy.Verify(hash, password); ok {
+			return true, true
+		}
+	}
+	return false, false
+}
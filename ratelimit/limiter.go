@@ -0,0 +1,37 @@
+package ratelimit
+
+import (
+	"context" // Mengimport package context untuk store yang memakai jaringan
+	"time"    // Mengimport package time untuk durasi
+)
+
+// Limit adalah aturan token bucket: bucket berisi paling banyak Burst token dan
+// bertambah PerMinute token setiap menit. Setiap request memakai satu token.
+type Limit struct {
+	PerMinute int // Jumlah token yang ditambahkan setiap menit, 0 berarti tanpa batas
+	Burst     int // Kapasitas bucket, yaitu jumlah request yang boleh datang bersamaan
+}
+
+// Enabled memeriksa apakah limit berlaku
+func (l Limit) Enabled() bool {
+	return l.PerMinute > 0 && l.Burst > 0
+}
+
+// interval mengembalikan waktu yang dibutuhkan untuk menambah satu token
+func (l Limit) interval() time.Duration {
+	return time.Minute / time.Duration(l.PerMinute)
+}
+
+// Result adalah hasil pengambilan token dari bucket
+type Result struct {
+	Allowed    bool          // Allowed bernilai true jika request boleh diteruskan
+	Remaining  int           // Remaining adalah sisa token setelah request ini
+	RetryAfter time.Duration // RetryAfter adalah waktu tunggu sampai token berikutnya tersedia, diisi jika request ditolak
+}
+
+// Store adalah interface penyimpanan bucket. Implementasi bawaan disimpan di memori proses;
+// untuk beberapa instance di belakang load balancer, implementasikan Store dengan penyimpanan
+// bersama seperti Redis agar limit berlaku untuk semua instance.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error) // Fungsi untuk mengambil satu token dari bucket milik key
+}
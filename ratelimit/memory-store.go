@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"context" // Mengimport package context untuk memenuhi interface Store
+	"sync"    // Mengimport package sync untuk mengamankan akses bersamaan
+	"time"    // Mengimport package time untuk menghitung pengisian token
+)
+
+// sweepInterval adalah jarak waktu antar pembersihan bucket yang sudah penuh kembali
+const sweepInterval = time.Minute
+
+// bucket adalah satu token bucket
+type bucket struct {
+	tokens float64   // Jumlah token yang tersisa
+	last   time.Time // Waktu terakhir token dihitung
+	limit  Limit     // Limit yang dipakai bucket ini, untuk mengetahui kapan bucket penuh kembali
+}
+
+// MemoryStore adalah implementasi Store yang menyimpan bucket di memori proses
+type MemoryStore struct {
+	mu        sync.Mutex         // Mengamankan buckets dari akses bersamaan
+	buckets   map[string]*bucket // Bucket berdasarkan key
+	lastSweep time.Time          // Waktu pembersihan terakhir
+	now       func() time.Time   // Sumber waktu
+}
+
+// NewMemoryStore adalah constructor untuk MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: map[string]*bucket{}, now: time.Now}
+}
+
+// Take adalah implementasi fungsi Take dari Store
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	if !limit.Enabled() {
+		return Result{Allowed: true, Remaining: limit.Burst}, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), last: now}
+		s.buckets[key] = b
+	}
+	b.limit = limit
+	b.tokens += float64(now.Sub(b.last)) / float64(limit.interval()) // Menambah token sesuai waktu yang berlalu
+	if b.tokens > float64(limit.Burst) {
+		b.tokens = float64(limit.Burst)
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) * float64(limit.interval()))
+		return Result{Allowed: false, Remaining: 0, RetryAfter: wait}, nil
+	}
+	b.tokens--
+	return Result{Allowed: true, Remaining: int(b.tokens)}, nil
+}
+
+// sweep menghapus bucket yang sudah penuh kembali karena tidak dipakai,
+// sehingga memori tidak terus bertambah oleh IP yang hanya datang sekali
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, b := range s.buckets {
+		refill := time.Duration((float64(b.limit.Burst) - b.tokens) * float64(b.limit.interval())) // Kekurangan token pecahan ikut dihitung
+		if now.Sub(b.last) >= refill {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context" // Mengimport package context untuk pembatalan dan tenggat waktu query
+	"time"    // Mengimport package time untuk mengelola waktu
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity" // Mengimport package entity untuk model entitas
+	"gorm.io/gorm"                                          // Mengimport package gorm untuk ORM
+)
+
+// APIKeyRepository adalah interface yang mendefinisikan fungsi yang harus diimplementasikan oleh repository APIKey
+type APIKeyRepository interface {
+	InsertKey(ctx context.Context, key entity.APIKey) (entity.APIKey, error) // Fungsi untuk menyimpan API key baru
+	FindByHash(ctx context.Context, keyHash string) (entity.APIKey, error)   // Fungsi untuk mencari API key berdasarkan hash
+	ListForUser(ctx context.Context, userID uint64) ([]entity.APIKey, error) // Fungsi untuk mendapatkan API key milik user yang belum dicabut
+	CountActiveForUser(ctx context.Context, userID uint64) (int64, error)    // Fungsi untuk menghitung API key milik user yang belum dicabut
+	Revoke(ctx context.Context, userID uint64, keyID uint64) error           // Fungsi untuk mencabut API key milik user
+	TouchLastUsed(ctx context.Context, keyID uint64, usedAt time.Time) error // Fungsi untuk mencatat waktu terakhir API key dipakai
+}
+
+// apiKeyConnection adalah implementasi dari APIKeyRepository
+type apiKeyConnection struct {
+	connection *gorm.DB // Koneksi database menggunakan gorm
+}
+
+// NewAPIKeyRepository adalah constructor untuk apiKeyConnection
+func NewAPIKeyRepository(db *gorm.DB) APIKeyRepository {
+	return &apiKeyConnection{
+		connection: db,
+	}
+}
+
+// InsertKey adalah implementasi fungsi InsertKey dari APIKeyRepository
+func (db *apiKeyConnection) InsertKey(ctx context.Context, key entity.APIKey) (entity.APIKey, error) {
+	err := db.connection.WithContext(ctx).Create(&key).Error // Menyimpan API key ke database
+	return key, err
+}
+
+// FindByHash adalah implementasi fungsi FindByHash dari APIKeyRepository
+func (db *apiKeyConnection) FindByHash(ctx context.Context, keyHash string) (entity.APIKey, error) {
+	var key entity.APIKey
+	err := db.connection.WithContext(ctx).Where("key_hash = ?", keyHash).Take(&key).Error // Mengambil API key berdasarkan hash
+	return key, translateError(err, "api key")
+}
+
+// ListForUser adalah implementasi fungsi ListForUser dari APIKeyRepository
+func (db *apiKeyConnection) ListForUser(ctx context.Context, userID uint64) ([]entity.APIKey, error) {
+	keys := []entity.APIKey{}
+	err := db.connection.WithContext(ctx).Where("user_id = ? AND revoked_at IS NULL", userID).Order("id").Find(&keys).Error // Mengambil API key milik user
+	return keys, err
+}
+
+// CountActiveForUser adalah implementasi fungsi CountActiveForUser dari APIKeyRepository
+func (db *apiKeyConnection) CountActiveForUser(ctx context.Context, userID uint64) (int64, error) {
+	var count int64
+	err := db.connection.WithContext(ctx).Model(&entity.APIKey{}).Where("user_id = ? AND revoked_at IS NULL", userID).Count(&count).Error // Menghitung API key milik user
+	return count, err
+}
+
+// Revoke adalah implementasi fungsi Revoke dari APIKeyRepository.
+// Key milik user lain dianggap tidak ada agar user tidak bisa menebak ID key orang lain.
+func (db *apiKeyConnection) Revoke(ctx context.Context, userID uint64, keyID uint64) error {
+	res := db.connection.WithContext(ctx).Model(&entity.APIKey{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", keyID, userID).
+		Update("revoked_at", time.Now()) // Mencabut API key
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return translateError(gorm.ErrRecordNotFound, "api key")
+	}
+	return nil
+}
+
+// TouchLastUsed adalah implementasi fungsi TouchLastUsed dari APIKeyRepository
+func (db *apiKeyConnection) TouchLastUsed(ctx context.Context, keyID uint64, usedAt time.Time) error {
+	return db.connection.WithContext(ctx).Model(&entity.APIKey{}).Where("id = ?", keyID).Update("last_used_at", usedAt).Error // Mencatat waktu terakhir key dipakai
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"strings" // Mengimport package strings untuk menyusun klausa SQL
+	"time"    // Mengimport package time untuk filter tanggal
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/helper" // Mengimport package helper untuk escape pola LIKE
+	"gorm.io/gorm"                                          // Mengimport package gorm untuk ORM
+)
+
+// SortField adalah satu kolom pengurutan pada query daftar buku
+type SortField struct {
+	Column string // Column adalah nama kolom di database
+	Desc   bool   // Desc bernilai true untuk urutan descending
+}
+
+// BookQuery adalah parameter query untuk mengambil daftar buku secara bertahap
+type BookQuery struct {
+	TitleContains string        // TitleContains adalah filter judul yang mengandung teks ini (tidak peka huruf besar-kecil)
+	OwnerID       uint64        // OwnerID adalah filter ID user pemilik buku, 0 berarti tanpa filter
+	CreatedFrom   *time.Time    // CreatedFrom adalah batas awal waktu dibuat (inklusif)
+	CreatedTo     *time.Time    // CreatedTo adalah batas akhir waktu dibuat (inklusif)
+	Sort          []SortField   // Sort adalah urutan data, kolom terakhir harus unik agar cursor stabil
+	After         []interface{} // After adalah nilai kolom Sort dari data terakhir halaman sebelumnya (keyset pagination)
+	Offset        int           // Offset adalah jumlah data yang dilewati (pagination berbasis halaman)
+	Limit         int           // Limit adalah jumlah data maksimal yang diambil
+	CountTotal    bool          // CountTotal bernilai true jika jumlah seluruh data perlu dihitung
+}
+
+// applyFilters menambahkan klausa filter dari BookQuery ke query gorm
+func (q BookQuery) applyFilters(tx *gorm.DB) *gorm.DB {
+	if q.TitleContains != "" {
+		tx = tx.Where("LOWER(title) LIKE ? ESCAPE '!'", "%"+helper.EscapeLike(strings.ToLower(q.TitleContains))+"%")
+	}
+	if q.OwnerID != 0 {
+		tx = tx.Where("user_id = ?", q.OwnerID)
+	}
+	if q.CreatedFrom != nil {
+		tx = tx.Where("created_at >= ?", *q.CreatedFrom)
+	}
+	if q.CreatedTo != nil {
+		tx = tx.Where("created_at <= ?", *q.CreatedTo)
+	}
+	return tx
+}
+
+// applyKeyset menambahkan kondisi "setelah data terakhir" sesuai urutan Sort, misalnya untuk
+// sort (a ASC, b DESC): (a > ?) OR (a = ? AND b < ?)
+func (q BookQuery) applyKeyset(tx *gorm.DB) *gorm.DB {
+	if len(q.After) != len(q.Sort) || len(q.After) == 0 {
+		return tx
+	}
+	var clauses []string
+	var args []interface{}
+	for i, field := range q.Sort {
+		var parts []string
+		for j := 0; j < i; j++ {
+			parts = append(parts, q.Sort[j].Column+" = ?")
+			args = append(args, q.After[j])
+		}
+		op := " > ?"
+		if field.Desc {
+			op = " < ?"
+		}
+		parts = append(parts, field.Column+op)
+		args = append(args, q.After[i])
+		clauses = append(clauses, "("+strings.Join(parts, " AND ")+")")
+	}
+	return tx.Where(strings.Join(clauses, " OR "), args...)
+}
+
+// orderClause menyusun klausa ORDER BY dari Sort
+func (q BookQuery) orderClause() string {
+	parts := make([]string, 0, len(q.Sort))
+	for _, field := range q.Sort {
+		if field.Desc {
+			parts = append(parts, field.Column+" DESC")
+		} else {
+			parts = append(parts, field.Column+" ASC")
+		}
+	}
+	return strings.Join(parts, ", ")
+}
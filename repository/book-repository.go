@@ -1,17 +1,21 @@
 package repository
 
 import (
-	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity" // Mengimport package entity untuk model entitas
-	"gorm.io/gorm"                                          // Mengimport package gorm untuk ORM
+	"context" // Mengimport package context untuk pembatalan dan tenggat waktu query
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror" // Mengimport package apperror untuk error domain
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity"   // Mengimport package entity untuk model entitas
+	"gorm.io/gorm"                                            // Mengimport package gorm untuk ORM
 )
 
 // BookRepository adalah interface yang mendefinisikan fungsi yang harus diimplementasikan oleh repository Book
 type BookRepository interface {
-	InsertBook(b entity.Book) entity.Book // Fungsi untuk menyimpan buku baru
-	UpdateBook(b entity.Book) entity.Book // Fungsi untuk mengupdate buku
-	DeleteBook(b entity.Book)             // Fungsi untuk menghapus buku
-	AllBook() []entity.Book               // Fungsi untuk mendapatkan semua buku
-	FindBookID(bookID uint64) entity.Book // Fungsi untuk mencari buku berdasarkan ID
+	InsertBook(ctx context.Context, b entity.Book) (entity.Book, error)          // Fungsi untuk menyimpan buku baru
+	UpdateBook(ctx context.Context, b entity.Book) (entity.Book, error)          // Fungsi untuk mengupdate buku
+	DeleteBook(ctx context.Context, b entity.Book) error                         // Fungsi untuk menghapus buku
+	PageBook(ctx context.Context, query BookQuery) ([]entity.Book, int64, error) // Fungsi untuk mendapatkan satu halaman buku sesuai filter dan urutan
+	FindBookID(ctx context.Context, bookID uint64) (entity.Book, error)          // Fungsi untuk mencari buku berdasarkan ID
+	FindBooksByIDs(ctx context.Context, bookIDs []uint64) ([]entity.Book, error) // Fungsi untuk mencari beberapa buku sekaligus berdasarkan ID
 }
 
 // bookConnection adalah implementasi dari BookRepository
@@ -27,34 +31,71 @@ func NewBookRepository(dbConn *gorm.DB) BookRepository {
 }
 
 // InsertBook adalah implementasi fungsi InsertBook dari BookRepository
-func (db *bookConnection) InsertBook(b entity.Book) entity.Book {
-	db.connection.Save(&b)                 // Menyimpan buku ke database
-	db.connection.Preload("User").Find(&b) // Mengambil buku yang baru disimpan dengan relasi User
-	return b                               // Mengembalikan buku yang telah disimpan
+func (db *bookConnection) InsertBook(ctx context.Context, b entity.Book) (entity.Book, error) {
+	tx := db.connection.WithContext(ctx)
+	if err := tx.Create(&b).Error; err != nil { // Menyimpan buku ke database
+		return entity.Book{}, translateError(err, "book")
+	}
+	err := tx.Preload("User").Take(&b, b.ID).Error // Mengambil buku yang baru disimpan dengan relasi User
+	return b, translateError(err, "book")          // Mengembalikan buku yang telah disimpan
 }
 
 // UpdateBook adalah implementasi fungsi UpdateBook dari BookRepository
-func (db *bookConnection) UpdateBook(b entity.Book) entity.Book {
-	db.connection.Save(&b)                 // Mengupdate buku ke database
-	db.connection.Preload("User").Find(&b) // Mengambil buku yang telah diupdate dengan relasi User
-	return b                               // Mengembalikan buku yang telah diupdate
+func (db *bookConnection) UpdateBook(ctx context.Context, b entity.Book) (entity.Book, error) {
+	tx := db.connection.WithContext(ctx)
+	err := tx.Model(&b).Select("Title", "Description", "UserID").Updates(&b).Error // Mengupdate buku ke database tanpa mengubah waktu dibuat
+	if err != nil {
+		return entity.Book{}, translateError(err, "book")
+	}
+	err = tx.Preload("User").Take(&b, b.ID).Error // Mengambil buku yang telah diupdate, error not found jika buku tidak ada
+	return b, translateError(err, "book")         // Mengembalikan buku yang telah diupdate
 }
 
 // DeleteBook adalah implementasi fungsi DeleteBook dari BookRepository
-func (db *bookConnection) DeleteBook(b entity.Book) {
-	db.connection.Delete(&b) // Menghapus buku dari database
+func (db *bookConnection) DeleteBook(ctx context.Context, b entity.Book) error {
+	res := db.connection.WithContext(ctx).Delete(&b) // Menghapus buku dari database
+	if res.Error != nil {
+		return translateError(res.Error, "book")
+	}
+	if res.RowsAffected == 0 {
+		return apperror.NotFound("book not found").WithCode(errorCode("book", "not_found"))
+	}
+	return nil
 }
 
 // FindBookID adalah implementasi fungsi FindBookID dari BookRepository
-func (db *bookConnection) FindBookID(bookID uint64) entity.Book {
+func (db *bookConnection) FindBookID(ctx context.Context, bookID uint64) (entity.Book, error) {
 	var book entity.Book
-	db.connection.Preload("User").Find(&book, bookID) // Mengambil buku berdasarkan ID dengan relasi User
-	return book                                       // Mengembalikan buku yang ditemukan
+	err := db.connection.WithContext(ctx).Preload("User").Take(&book, bookID).Error // Mengambil buku berdasarkan ID dengan relasi User
+	return book, translateError(err, "book")                                        // Mengembalikan buku yang ditemukan
 }
 
-// AllBook adalah implementasi fungsi AllBook dari BookRepository
-func (db *bookConnection) AllBook() []entity.Book {
+// PageBook adalah implementasi fungsi PageBook dari BookRepository.
+// Total hanya dihitung jika query.CountTotal bernilai true karena COUNT pada tabel besar cukup mahal.
+func (db *bookConnection) PageBook(ctx context.Context, query BookQuery) ([]entity.Book, int64, error) {
+	tx := db.connection.WithContext(ctx)
+	var total int64
+	if query.CountTotal {
+		if err := query.applyFilters(tx.Model(&entity.Book{})).Count(&total).Error; err != nil { // Menghitung jumlah seluruh buku yang cocok dengan filter
+			return nil, 0, err
+		}
+	}
 	var books []entity.Book
-	db.connection.Preload("User").Find(&books) // Mengambil semua buku dengan relasi User
-	return books                               // Mengembalikan semua buku yang ditemukan
+	err := query.applyKeyset(query.applyFilters(tx)).
+		Preload("User").
+		Order(query.orderClause()).
+		Offset(query.Offset).
+		Limit(query.Limit).
+		Find(&books).Error // Mengambil satu halaman buku dengan relasi User
+	return books, total, err
+}
+
+// FindBooksByIDs adalah implementasi fungsi FindBooksByIDs dari BookRepository
+func (db *bookConnection) FindBooksByIDs(ctx context.Context, bookIDs []uint64) ([]entity.Book, error) {
+	var books []entity.Book
+	if len(bookIDs) == 0 {
+		return books, nil
+	}
+	err := db.connection.WithContext(ctx).Preload("User").Where("id IN ?", bookIDs).Find(&books).Error // Mengambil buku berdasarkan daftar ID dengan relasi User
+	return books, err                                                                                  // Mengembalikan buku yang ditemukan
 }
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"errors"  // Mengimport package errors untuk pengecekan error
+	"strings" // Mengimport package strings untuk menyusun kode error
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror" // Mengimport package apperror untuk error domain
+	"gorm.io/gorm"                                            // Mengimport package gorm untuk error bawaan GORM
+)
+
+// translateError mengubah error GORM menjadi error domain. Error lain (misalnya koneksi database
+// terputus) dikembalikan apa adanya dan dianggap error internal oleh lapisan di atasnya.
+func translateError(err error, name string) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return apperror.NotFound(name + " not found").WithCode(errorCode(name, "not_found"))
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return apperror.Wrap(apperror.KindConflict, name+" already exists", err).WithCode(errorCode(name, "already_exists"))
+	default:
+		return err
+	}
+}
+
+// errorCode menyusun kode error seperti "book_not_found" dari nama data dan jenis kegagalan
+func errorCode(name string, suffix string) string {
+	return strings.ReplaceAll(name, " ", "_") + "_" + suffix
+}
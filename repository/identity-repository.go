@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context" // Mengimport package context untuk pembatalan dan tenggat waktu query
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity" // Mengimport package entity untuk model entitas
+	"gorm.io/gorm"                                          // Mengimport package gorm untuk ORM
+)
+
+// IdentityRepository adalah interface yang mendefinisikan fungsi yang harus diimplementasikan oleh repository UserIdentity
+type IdentityRepository interface {
+	FindBySubject(ctx context.Context, provider string, subject string) (entity.UserIdentity, error) // Fungsi untuk mencari identitas berdasarkan provider dan subject
+	InsertIdentity(ctx context.Context, identity entity.UserIdentity) (entity.UserIdentity, error)   // Fungsi untuk menghubungkan identitas baru ke user
+}
+
+// identityConnection adalah implementasi dari IdentityRepository
+type identityConnection struct {
+	connection *gorm.DB // Koneksi database menggunakan gorm
+}
+
+// NewIdentityRepository adalah constructor untuk identityConnection
+func NewIdentityRepository(db *gorm.DB) IdentityRepository {
+	return &identityConnection{
+		connection: db,
+	}
+}
+
+// FindBySubject adalah implementasi fungsi FindBySubject dari IdentityRepository
+func (db *identityConnection) FindBySubject(ctx context.Context, provider string, subject string) (entity.UserIdentity, error) {
+	var identity entity.UserIdentity
+	err := db.connection.WithContext(ctx).Where("provider = ? AND subject = ?", provider, subject).Take(&identity).Error // Mengambil identitas berdasarkan provider dan subject
+	return identity, translateError(err, "identity")
+}
+
+// InsertIdentity adalah implementasi fungsi InsertIdentity dari IdentityRepository
+func (db *identityConnection) InsertIdentity(ctx context.Context, identity entity.UserIdentity) (entity.UserIdentity, error) {
+	err := db.connection.WithContext(ctx).Create(&identity).Error // Menyimpan identitas ke database
+	return identity, translateError(err, "identity")
+}
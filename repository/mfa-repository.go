@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context" // Mengimport package context untuk pembatalan dan tenggat waktu query
+	"errors"  // Mengimport package errors untuk membuat error
+	"time"    // Mengimport package time untuk mengelola waktu
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity" // Mengimport package entity untuk model entitas
+	"gorm.io/gorm"                                          // Mengimport package gorm untuk ORM
+)
+
+// ErrTOTPCodeReused dikembalikan ketika kode TOTP dengan time step yang sama atau lebih lama sudah pernah dipakai
+var ErrTOTPCodeReused = errors.New("totp code already used")
+
+// ErrRecoveryCodeUsed dikembalikan ketika kode pemulihan tidak ditemukan atau sudah dipakai
+var ErrRecoveryCodeUsed = errors.New("recovery code not found or already used")
+
+// ErrTOTPAlreadyEnabled dikembalikan ketika enrollment diubah padahal 2FA user sudah aktif
+var ErrTOTPAlreadyEnabled = errors.New("two-factor authentication already enabled")
+
+// MFARepository adalah interface yang mendefinisikan fungsi yang harus diimplementasikan oleh repository 2FA
+type MFARepository interface {
+	SetPendingSecret(ctx context.Context, userID uint64, secret string) error         // Fungsi untuk menyimpan secret TOTP yang belum dikonfirmasi
+	Enable(ctx context.Context, userID uint64, step int64, codeHashes []string) error // Fungsi untuk mengaktifkan 2FA dan mengganti semua kode pemulihan
+	Disable(ctx context.Context, userID uint64) error                                 // Fungsi untuk mematikan 2FA dan menghapus semua kode pemulihan
+	AdvanceStep(ctx context.Context, userID uint64, step int64) error                 // Fungsi untuk mencatat time step kode TOTP yang dipakai, gagal jika kode sudah pernah dipakai
+	UseRecoveryCode(ctx context.Context, userID uint64, codeHash string) error        // Fungsi untuk menandai kode pemulihan sudah dipakai, gagal jika kode tidak ada atau sudah dipakai
+	CountRecoveryCodes(ctx context.Context, userID uint64) (int64, error)             // Fungsi untuk menghitung kode pemulihan yang belum dipakai
+}
+
+// mfaConnection adalah implementasi dari MFARepository
+type mfaConnection struct {
+	connection *gorm.DB // Koneksi database menggunakan gorm
+}
+
+// NewMFARepository adalah constructor untuk mfaConnection
+func NewMFARepository(db *gorm.DB) MFARepository {
+	return &mfaConnection{
+		connection: db,
+	}
+}
+
+// SetPendingSecret adalah implementasi fungsi SetPendingSecret dari MFARepository.
+// Secret hanya diganti jika 2FA belum aktif, sehingga enrollment ulang tidak bisa mengganti authenticator yang sudah dipakai.
+func (db *mfaConnection) SetPendingSecret(ctx context.Context, userID uint64, secret string) error {
+	res := db.connection.WithContext(ctx).Model(&entity.User{}).
+		Where("id = ? AND totp_enabled = ?", userID, false).
+		Updates(map[string]interface{}{"totp_secret": secret, "totp_last_step": 0}) // Menyimpan secret baru
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 { // 2FA sudah aktif atau user tidak ada, service memeriksa user lebih dulu
+		return ErrTOTPAlreadyEnabled
+	}
+	return nil
+}
+
+// Enable adalah implementasi fungsi Enable dari MFARepository.
+// Status 2FA dan kode pemulihan diubah dalam satu transaksi agar user tidak pernah memiliki 2FA aktif tanpa kode pemulihan.
+func (db *mfaConnection) Enable(ctx context.Context, userID uint64, step int64, codeHashes []string) error {
+	return db.connection.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&entity.User{}).
+			Where("id = ? AND totp_enabled = ?", userID, false).
+			Updates(map[string]interface{}{"totp_enabled": true, "totp_last_step": step}) // Mengaktifkan 2FA
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 { // Request lain sudah mengaktifkan 2FA
+			return ErrTOTPAlreadyEnabled
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&entity.RecoveryCode{}).Error; err != nil { // Menghapus kode pemulihan lama
+			return err
+		}
+		codes := make([]entity.RecoveryCode, len(codeHashes))
+		for i, hash := range codeHashes {
+			codes[i] = entity.RecoveryCode{UserID: userID, CodeHash: hash}
+		}
+		return tx.Create(&codes).Error // Menyimpan kode pemulihan baru
+	})
+}
+
+// Disable adalah implementasi fungsi Disable dari MFARepository
+func (db *mfaConnection) Disable(ctx context.Context, userID uint64) error {
+	return db.connection.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entity.User{}).Where("id = ?", userID).
+			Updates(map[string]interface{}{"totp_enabled": false, "totp_secret": "", "totp_last_step": 0}).Error // Mematikan 2FA dan menghapus secret
+		if err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&entity.RecoveryCode{}).Error // Menghapus semua kode pemulihan
+	})
+}
+
+// AdvanceStep adalah implementasi fungsi AdvanceStep dari MFARepository.
+// Time step hanya bisa maju, sehingga dua request yang memakai kode yang sama secara bersamaan tidak bisa sama-sama berhasil.
+func (db *mfaConnection) AdvanceStep(ctx context.Context, userID uint64, step int64) error {
+	res := db.connection.WithContext(ctx).Model(&entity.User{}).
+		Where("id = ? AND totp_last_step < ?", userID, step).
+		Update("totp_last_step", step) // Mencatat time step kode yang dipakai
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 { // Kode ini atau kode yang lebih baru sudah pernah dipakai
+		return ErrTOTPCodeReused
+	}
+	return nil
+}
+
+// UseRecoveryCode adalah implementasi fungsi UseRecoveryCode dari MFARepository
+func (db *mfaConnection) UseRecoveryCode(ctx context.Context, userID uint64, codeHash string) error {
+	res := db.connection.WithContext(ctx).Model(&entity.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now()) // Menandai kode sudah dipakai
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrRecoveryCodeUsed
+	}
+	return nil
+}
+
+// CountRecoveryCodes adalah implementasi fungsi CountRecoveryCodes dari MFARepository
+func (db *mfaConnection) CountRecoveryCodes(ctx context.Context, userID uint64) (int64, error) {
+	var count int64
+	err := db.connection.WithContext(ctx).Model(&entity.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).Count(&count).Error // Menghitung kode pemulihan yang belum dipakai
+	return count, err
+}
@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"context" // Mengimport package context untuk pembatalan dan tenggat waktu query
+	"errors"  // Mengimport package errors untuk membuat error
+	"time"    // Mengimport package time untuk mengelola waktu
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity" // Mengimport package entity untuk model entitas
+	"gorm.io/gorm"                                          // Mengimport package gorm untuk ORM
+)
+
+var (
+	// ErrAuthorizationCodeUsed dikembalikan ketika authorization code sudah ditukar oleh request lain
+	ErrAuthorizationCodeUsed = errors.New("authorization code already used")
+	// ErrOAuthTokenAlreadyRotated dikembalikan ketika refresh token OAuth2 sudah dirotasi oleh request lain
+	ErrOAuthTokenAlreadyRotated = errors.New("oauth refresh token already rotated")
+)
+
+// OAuthGrant adalah ringkasan akses yang masih dimiliki satu client atas data user
+type OAuthGrant struct {
+	ClientID  string    `json:"client_id"`  // ClientID adalah identitas publik client
+	Name      string    `json:"name"`       // Name adalah nama aplikasi client
+	Scopes    string    `json:"scopes"`     // Scopes adalah scope yang disetujui pada persetujuan terakhir
+	CreatedAt time.Time `json:"created_at"` // CreatedAt adalah waktu token aktif terakhir diterbitkan
+}
+
+// OAuthRepository adalah interface yang mendefinisikan fungsi yang harus diimplementasikan oleh repository OAuth2
+type OAuthRepository interface {
+	InsertClient(ctx context.Context, client entity.OAuthClient) (entity.OAuthClient, error)                   // Fungsi untuk mendaftarkan client baru
+	FindClient(ctx context.Context, clientID string) (entity.OAuthClient, error)                               // Fungsi untuk mencari client berdasarkan client ID publik
+	AllClients(ctx context.Context) ([]entity.OAuthClient, error)                                              // Fungsi untuk mendapatkan semua client
+	DeleteClient(ctx context.Context, clientID string) error                                                   // Fungsi untuk menghapus client beserta code dan token miliknya
+	InsertCode(ctx context.Context, code entity.OAuthAuthorizationCode) error                                  // Fungsi untuk menyimpan authorization code baru
+	FindCodeByHash(ctx context.Context, codeHash string) (entity.OAuthAuthorizationCode, error)                // Fungsi untuk mencari authorization code berdasarkan hash
+	MarkCodeUsed(ctx context.Context, codeID uint64) error                                                     // Fungsi untuk menandai code sudah ditukar, gagal jika sudah ditukar sebelumnya
+	InsertToken(ctx context.Context, token entity.OAuthRefreshToken) error                                     // Fungsi untuk menyimpan refresh token baru
+	FindTokenByHash(ctx context.Context, tokenHash string) (entity.OAuthRefreshToken, error)                   // Fungsi untuk mencari refresh token berdasarkan hash
+	RotateToken(ctx context.Context, old entity.OAuthRefreshToken, next entity.OAuthRefreshToken) error        // Fungsi untuk mengganti refresh token lama dengan yang baru
+	RevokeFamily(ctx context.Context, familyID string) error                                                   // Fungsi untuk mencabut semua refresh token dalam satu family
+	InsertAccessToken(ctx context.Context, token entity.OAuthAccessToken) error                                // Fungsi untuk mencatat access token yang diterbitkan dalam satu family
+	FamilyAccessTokens(ctx context.Context, familyID string, now time.Time) ([]entity.OAuthAccessToken, error) // Fungsi untuk mendapatkan access token family yang belum kedaluwarsa
+	PurgeExpiredAccessTokens(ctx context.Context, now time.Time) (int64, error)                                // Fungsi untuk menghapus catatan access token yang sudah kedaluwarsa
+	RevokeGrant(ctx context.Context, userID uint64, clientID uint64) (int64, error)                            // Fungsi untuk mencabut semua refresh token milik client untuk user
+	ActiveGrants(ctx context.Context, userID uint64) ([]OAuthGrant, error)                                     // Fungsi untuk mendapatkan client yang masih memiliki refresh token aktif milik user
+}
+
+// oauthConnection adalah implementasi dari OAuthRepository
+type oauthConnection struct {
+	connection *gorm.DB // Koneksi database menggunakan gorm
+}
+
+// NewOAuthRepository adalah constructor untuk oauthConnection
+func NewOAuthRepository(db *gorm.DB) OAuthRepository {
+	return &oauthConnection{
+		connection: db,
+	}
+}
+
+// InsertClient adalah implementasi fungsi InsertClient dari OAuthRepository
+func (db *oauthConnection) InsertClient(ctx context.Context, client entity.OAuthClient) (entity.OAuthClient, error) {
+	err := db.connection.WithContext(ctx).Create(&client).Error // Menyimpan client ke database
+	return client, translateError(err, "oauth client")
+}
+
+// FindClient adalah implementasi fungsi FindClient dari OAuthRepository
+func (db *oauthConnection) FindClient(ctx context.Context, clientID string) (entity.OAuthClient, error) {
+	var client entity.OAuthClient
+	err := db.connection.WithContext(ctx).Where("client_id = ?", clientID).Take(&client).Error // Mengambil client berdasarkan client ID publik
+	return client, translateError(err, "oauth client")
+}
+
+// AllClients adalah implementasi fungsi AllClients dari OAuthRepository
+func (db *oauthConnection) AllClients(ctx context.Context) ([]entity.OAuthClient, error) {
+	clients := []entity.OAuthClient{}
+	err := db.connection.WithContext(ctx).Order("id").Find(&clients).Error // Mengambil semua client
+	return clients, err
+}
+
+// DeleteClient adalah implementasi fungsi DeleteClient dari OAuthRepository.
+// Code, refresh token, dan catatan access token milik client ikut terhapus oleh foreign key ON DELETE CASCADE.
+func (db *oauthConnection) DeleteClient(ctx context.Context, clientID string) error {
+	res := db.connection.WithContext(ctx).Where("client_id = ?", clientID).Delete(&entity.OAuthClient{}) // Menghapus client
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return translateError(gorm.ErrRecordNotFound, "oauth client")
+	}
+	return nil
+}
+
+// InsertCode adalah implementasi fungsi InsertCode dari OAuthRepository
+func (db *oauthConnection) InsertCode(ctx context.Context, code entity.OAuthAuthorizationCode) error {
+	return db.connection.WithContext(ctx).Create(&code).Error // Menyimpan authorization code ke database
+}
+
+// FindCodeByHash adalah implementasi fungsi FindCodeByHash dari OAuthRepository
+func (db *oauthConnection) FindCodeByHash(ctx context.Context, codeHash string) (entity.OAuthAuthorizationCode, error) {
+	var code entity.OAuthAuthorizationCode
+	err := db.connection.WithContext(ctx).Where("code_hash = ?", codeHash).Take(&code).Error // Mengambil authorization code berdasarkan hash
+	return code, translateError(err, "authorization code")
+}
+
+// MarkCodeUsed adalah implementasi fungsi MarkCodeUsed dari OAuthRepository.
+// Code hanya ditandai jika belum pernah ditukar, sehingga dua request yang menukar code yang sama
+// secara bersamaan tidak bisa sama-sama berhasil.
+func (db *oauthConnection) MarkCodeUsed(ctx context.Context, codeID uint64) error {
+	res := db.connection.WithContext(ctx).Model(&entity.OAuthAuthorizationCode{}).
+		Where("id = ? AND used_at IS NULL", codeID).
+		Update("used_at", time.Now()) // Menandai code sudah ditukar
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 { // Code sudah ditukar oleh request lain
+		return ErrAuthorizationCodeUsed
+	}
+	return nil
+}
+
+// InsertToken adalah implementasi fungsi InsertToken dari OAuthRepository
+func (db *oauthConnection) InsertToken(ctx context.Context, token entity.OAuthRefreshToken) error {
+	return db.connection.WithContext(ctx).Create(&token).Error // Menyimpan refresh token ke database
+}
+
+// FindTokenByHash adalah implementasi fungsi FindTokenByHash dari OAuthRepository
+func (db *oauthConnection) FindTokenByHash(ctx context.Context, tokenHash string) (entity.OAuthRefreshToken, error) {
+	var token entity.OAuthRefreshToken
+	err := db.connection.WithContext(ctx).Where("token_hash = ?", tokenHash).Take(&token).Error // Mengambil refresh token berdasarkan hash
+	return token, translateError(err, "refresh token")
+}
+
+// RotateToken adalah implementasi fungsi RotateToken dari OAuthRepository, dengan aturan yang sama seperti RefreshTokenRepository.RotateToken
+func (db *oauthConnection) RotateToken(ctx context.Context, old entity.OAuthRefreshToken, next entity.OAuthRefreshToken) error {
+	return db.connection.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&next).Error; err != nil { // Menyimpan token pengganti terlebih dahulu untuk mendapatkan ID
+			return err
+		}
+		res := tx.Model(&entity.OAuthRefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", old.ID).
+			Updates(map[string]interface{}{"revoked_at": time.Now(), "replaced_by": next.ID}) // Menandai token lama sebagai sudah dirotasi
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 { // Token lama sudah dipakai oleh request lain
+			return ErrOAuthTokenAlreadyRotated
+		}
+		return nil
+	})
+}
+
+// RevokeFamily adalah implementasi fungsi RevokeFamily dari OAuthRepository
+func (db *oauthConnection) RevokeFamily(ctx context.Context, familyID string) error {
+	return db.connection.WithContext(ctx).Model(&entity.OAuthRefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error // Mencabut semua token aktif dalam family
+}
+
+// InsertAccessToken adalah implementasi fungsi InsertAccessToken dari OAuthRepository
+func (db *oauthConnection) InsertAccessToken(ctx context.Context, token entity.OAuthAccessToken) error {
+	return db.connection.WithContext(ctx).Create(&token).Error // Menyimpan catatan access token ke database
+}
+
+// FamilyAccessTokens adalah implementasi fungsi FamilyAccessTokens dari OAuthRepository
+func (db *oauthConnection) FamilyAccessTokens(ctx context.Context, familyID string, now time.Time) ([]entity.OAuthAccessToken, error) {
+	var tokens []entity.OAuthAccessToken
+	err := db.connection.WithContext(ctx).Where("family_id = ? AND expires_at > ?", familyID, now).Find(&tokens).Error // Token yang sudah kedaluwarsa tidak perlu dicabut
+	return tokens, err
+}
+
+// PurgeExpiredAccessTokens adalah implementasi fungsi PurgeExpiredAccessTokens dari OAuthRepository, mengembalikan jumlah catatan yang dihapus
+func (db *oauthConnection) PurgeExpiredAccessTokens(ctx context.Context, now time.Time) (int64, error) {
+	res := db.connection.WithContext(ctx).Where("expires_at < ?", now).Delete(&entity.OAuthAccessToken{})
+	return res.RowsAffected, res.Error
+}
+
+// RevokeGrant adalah implementasi fungsi RevokeGrant dari OAuthRepository, mengembalikan jumlah token yang dicabut
+func (db *oauthConnection) RevokeGrant(ctx context.Context, userID uint64, clientID uint64) (int64, error) {
+	res := db.connection.WithContext(ctx).Model(&entity.OAuthRefreshToken{}).
+		Where("user_id = ? AND client_id = ? AND revoked_at IS NULL", userID, clientID).
+		Update("revoked_at", time.Now()) // Mencabut semua token aktif milik client untuk user
+	return res.RowsAffected, res.Error
+}
+
+// ActiveGrants adalah implementasi fungsi ActiveGrants dari OAuthRepository
+func (db *oauthConnection) ActiveGrants(ctx context.Context, userID uint64) ([]OAuthGrant, error) {
+	grants := []OAuthGrant{}
+	err := db.connection.WithContext(ctx).Table("oauth_refresh_tokens AS t").
+		Select("c.client_id, c.name, t.scopes, t.created_at").
+		Joins("JOIN oauth_clients AS c ON c.id = t.client_id").
+		Where("t.user_id = ? AND t.revoked_at IS NULL AND t.expires_at > ?", userID, time.Now()).
+		Order("t.created_at DESC").
+		Scan(&grants).Error // Mengambil token aktif terbaru beserta client-nya
+	return dedupeGrants(grants), err
+}
+
+// dedupeGrants menyisakan satu grant terbaru untuk setiap client, grants harus urut dari yang terbaru
+func dedupeGrants(grants []OAuthGrant) []OAuthGrant {
+	seen := map[string]bool{}
+	result := []OAuthGrant{}
+	for _, g := range grants {
+		if !seen[g.ClientID] {
+			seen[g.ClientID] = true
+			result = append(result, g)
+		}
+	}
+	return result
+}
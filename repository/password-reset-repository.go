@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context" // Mengimport package context untuk pembatalan dan tenggat waktu query
+	"errors"  // Mengimport package errors untuk membuat error
+	"time"    // Mengimport package time untuk mengelola waktu
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity" // Mengimport package entity untuk model entitas
+	"gorm.io/gorm"                                          // Mengimport package gorm untuk ORM
+)
+
+// ErrPasswordResetTokenUsed dikembalikan ketika token reset password sudah dipakai oleh request lain
+var ErrPasswordResetTokenUsed = errors.New("password reset token already used")
+
+// PasswordResetRepository adalah interface yang mendefinisikan fungsi yang harus diimplementasikan oleh repository PasswordResetToken
+type PasswordResetRepository interface {
+	InsertToken(ctx context.Context, token entity.PasswordResetToken) (entity.PasswordResetToken, error) // Fungsi untuk menyimpan token reset password baru
+	FindByHash(ctx context.Context, tokenHash string) (entity.PasswordResetToken, error)                 // Fungsi untuk mencari token berdasarkan hash
+	MarkUsed(ctx context.Context, tokenID uint64) error                                                  // Fungsi untuk menandai token sudah dipakai, gagal jika sudah dipakai sebelumnya
+	InvalidateAllForUser(ctx context.Context, userID uint64) error                                       // Fungsi untuk membatalkan semua token milik user yang belum dipakai
+}
+
+// passwordResetConnection adalah implementasi dari PasswordResetRepository
+type passwordResetConnection struct {
+	connection *gorm.DB // Koneksi database menggunakan gorm
+}
+
+// NewPasswordResetRepository adalah constructor untuk passwordResetConnection
+func NewPasswordResetRepository(db *gorm.DB) PasswordResetRepository {
+	return &passwordResetConnection{
+		connection: db,
+	}
+}
+
+// InsertToken adalah implementasi fungsi InsertToken dari PasswordResetRepository
+func (db *passwordResetConnection) InsertToken(ctx context.Context, token entity.PasswordResetToken) (entity.PasswordResetToken, error) {
+	err := db.connection.WithContext(ctx).Create(&token).Error // Menyimpan token reset password ke database
+	return token, err
+}
+
+// FindByHash adalah implementasi fungsi FindByHash dari PasswordResetRepository
+func (db *passwordResetConnection) FindByHash(ctx context.Context, tokenHash string) (entity.PasswordResetToken, error) {
+	var token entity.PasswordResetToken
+	err := db.connection.WithContext(ctx).Where("token_hash = ?", tokenHash).Take(&token).Error // Mengambil token berdasarkan hash
+	return token, translateError(err, "password reset token")
+}
+
+// MarkUsed adalah implementasi fungsi MarkUsed dari PasswordResetRepository.
+// Token hanya ditandai jika belum pernah dipakai, sehingga dua request yang memakai token yang sama
+// secara bersamaan tidak bisa sama-sama berhasil.
+func (db *passwordResetConnection) MarkUsed(ctx context.Context, tokenID uint64) error {
+	res := db.connection.WithContext(ctx).Model(&entity.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", tokenID).
+		Update("used_at", time.Now()) // Menandai token sudah dipakai
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 { // Token sudah dipakai oleh request lain
+		return ErrPasswordResetTokenUsed
+	}
+	return nil
+}
+
+// InvalidateAllForUser adalah implementasi fungsi InvalidateAllForUser dari PasswordResetRepository
+func (db *passwordResetConnection) InvalidateAllForUser(ctx context.Context, userID uint64) error {
+	return db.connection.WithContext(ctx).Model(&entity.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", time.Now()).Error // Membatalkan semua token yang belum dipakai
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context" // Mengimport package context untuk pembatalan dan tenggat waktu query
+	"errors"  // Mengimport package errors untuk membuat error
+	"time"    // Mengimport package time untuk mengelola waktu
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity" // Mengimport package entity untuk model entitas
+	"gorm.io/gorm"                                          // Mengimport package gorm untuk ORM
+)
+
+// ErrRefreshTokenAlreadyRotated dikembalikan ketika refresh token yang akan dirotasi sudah pernah dipakai atau dicabut
+var ErrRefreshTokenAlreadyRotated = errors.New("refresh token already rotated")
+
+// RefreshTokenRepository adalah interface yang mendefinisikan fungsi yang harus diimplementasikan oleh repository RefreshToken
+type RefreshTokenRepository interface {
+	InsertToken(ctx context.Context, token entity.RefreshToken) (entity.RefreshToken, error)                         // Fungsi untuk menyimpan refresh token baru
+	FindByHash(ctx context.Context, tokenHash string) (entity.RefreshToken, error)                                   // Fungsi untuk mencari refresh token berdasarkan hash
+	RotateToken(ctx context.Context, old entity.RefreshToken, next entity.RefreshToken) (entity.RefreshToken, error) // Fungsi untuk mengganti token lama dengan token baru secara atomik
+	RevokeFamily(ctx context.Context, familyID string) error                                                         // Fungsi untuk mencabut seluruh token dalam satu family
+	RevokeAllForUser(ctx context.Context, userID uint64) error                                                       // Fungsi untuk mencabut seluruh token milik user
+}
+
+// refreshTokenConnection adalah implementasi dari RefreshTokenRepository
+type refreshTokenConnection struct {
+	connection *gorm.DB // Koneksi database menggunakan gorm
+}
+
+// NewRefreshTokenRepository adalah constructor untuk refreshTokenConnection
+func NewRefreshTokenRepository(db *gorm.DB) RefreshTokenRepository {
+	return &refreshTokenConnection{
+		connection: db,
+	}
+}
+
+// InsertToken adalah implementasi fungsi InsertToken dari RefreshTokenRepository
+func (db *refreshTokenConnection) InsertToken(ctx context.Context, token entity.RefreshToken) (entity.RefreshToken, error) {
+	err := db.connection.WithContext(ctx).Create(&token).Error // Menyimpan refresh token ke database
+	return token, err
+}
+
+// FindByHash adalah implementasi fungsi FindByHash dari RefreshTokenRepository
+func (db *refreshTokenConnection) FindByHash(ctx context.Context, tokenHash string) (entity.RefreshToken, error) {
+	var token entity.RefreshToken
+	err := db.connection.WithContext(ctx).Where("token_hash = ?", tokenHash).Take(&token).Error // Mengambil refresh token berdasarkan hash
+	return token, translateError(err, "refresh token")
+}
+
+// RotateToken adalah implementasi fungsi RotateToken dari RefreshTokenRepository.
+// Token lama hanya ditandai dicabut jika belum pernah dicabut, sehingga dua request rotasi
+// yang berjalan bersamaan dengan token yang sama tidak bisa sama-sama berhasil.
+func (db *refreshTokenConnection) RotateToken(ctx context.Context, old entity.RefreshToken, next entity.RefreshToken) (entity.RefreshToken, error) {
+	err := db.connection.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&next).Error; err != nil { // Menyimpan token pengganti terlebih dahulu untuk mendapatkan ID
+			return err
+		}
+		res := tx.Model(&entity.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", old.ID).
+			Updates(map[string]interface{}{"revoked_at": time.Now(), "replaced_by": next.ID}) // Menandai token lama sebagai sudah dirotasi
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 { // Token lama sudah dipakai oleh request lain
+			return ErrRefreshTokenAlreadyRotated
+		}
+		return nil
+	})
+	return next, err
+}
+
+// RevokeFamily adalah implementasi fungsi RevokeFamily dari RefreshTokenRepository
+func (db *refreshTokenConnection) RevokeFamily(ctx context.Context, familyID string) error {
+	return db.connection.WithContext(ctx).Model(&entity.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error // Mencabut semua token aktif dalam family
+}
+
+// RevokeAllForUser adalah implementasi fungsi RevokeAllForUser dari RefreshTokenRepository
+func (db *refreshTokenConnection) RevokeAllForUser(ctx context.Context, userID uint64) error {
+	return db.connection.WithContext(ctx).Model(&entity.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error // Mencabut semua token aktif milik user
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context" // Mengimport package context untuk pembatalan dan tenggat waktu query
+	"errors"  // Mengimport package errors untuk pengecekan error
+	"sync"    // Mengimport package sync untuk sinkronisasi akses map
+	"time"    // Mengimport package time untuk mengelola waktu
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity" // Mengimport package entity untuk model entitas
+	"gorm.io/gorm"                                          // Mengimport package gorm untuk ORM
+	"gorm.io/gorm/clause"                                   // Mengimport package clause untuk upsert
+)
+
+// RevocationStore adalah interface yang mendefinisikan penyimpanan daftar token yang sudah dicabut
+type RevocationStore interface {
+	RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error      // Fungsi untuk mencabut satu token berdasarkan jti
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)                // Fungsi untuk memeriksa apakah token dengan jti tertentu sudah dicabut
+	RevokeAllForUser(ctx context.Context, userID uint64, before time.Time) error // Fungsi untuk mencabut semua token user yang diterbitkan sebelum waktu tertentu
+	RevokedBefore(ctx context.Context, userID uint64) (time.Time, error)         // Fungsi untuk mendapatkan batas waktu pencabutan token user (zero jika tidak ada)
+	PurgeExpired(ctx context.Context, now time.Time) (int64, error)              // Fungsi untuk menghapus token yang sudah kedaluwarsa dan mengembalikan jumlahnya
+}
+
+// revocationConnection adalah implementasi RevocationStore yang disimpan di database
+type revocationConnection struct {
+	connection *gorm.DB // Koneksi database menggunakan gorm
+}
+
+// NewRevocationStore adalah constructor untuk revocationConnection
+func NewRevocationStore(db *gorm.DB) RevocationStore {
+	return &revocationConnection{
+		connection: db,
+	}
+}
+
+// RevokeToken adalah implementasi fungsi RevokeToken dari RevocationStore
+func (db *revocationConnection) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	revoked := entity.RevokedToken{JTI: jti, ExpiresAt: expiresAt}
+	return db.connection.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&revoked).Error // Menyimpan jti, abaikan jika sudah pernah dicabut
+}
+
+// PurgeExpired adalah implementasi fungsi PurgeExpired dari RevocationStore.
+// Token yang sudah kedaluwarsa ditolak oleh validasi JWT, sehingga catatan pencabutannya tidak diperlukan lagi.
+func (db *revocationConnection) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	res := db.connection.WithContext(ctx).Where("expires_at < ?", now).Delete(&entity.RevokedToken{})
+	return res.RowsAffected, res.Error
+}
+
+// IsTokenRevoked adalah implementasi fungsi IsTokenRevoked dari RevocationStore
+func (db *revocationConnection) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	var count int64
+	err := db.connection.WithContext(ctx).Model(&entity.RevokedToken{}).Where("jti = ?", jti).Count(&count).Error
+	return count > 0, err
+}
+
+// RevokeAllForUser adalah implementasi fungsi RevokeAllForUser dari RevocationStore
+func (db *revocationConnection) RevokeAllForUser(ctx context.Context, userID uint64, before time.Time) error {
+	revocation := entity.UserRevocation{UserID: userID, RevokedBefore: before}
+	return db.connection.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"revoked_before"}),
+	}).Create(&revocation).Error // Menyimpan atau memperbarui batas waktu pencabutan
+}
+
+// RevokedBefore adalah implementasi fungsi RevokedBefore dari RevocationStore
+func (db *revocationConnection) RevokedBefore(ctx context.Context, userID uint64) (time.Time, error) {
+	var revocation entity.UserRevocation
+	err := db.connection.WithContext(ctx).Where("user_id = ?", userID).Take(&revocation).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) { // User belum pernah logout dari semua sesi
+		return time.Time{}, nil
+	}
+	return revocation.RevokedBefore, err
+}
+
+// memoryRevocationStore adalah implementasi RevocationStore yang disimpan di memori.
+// Cocok untuk pengembangan lokal atau deployment satu instance.
+type memoryRevocationStore struct {
+	mu       sync.RWMutex         // Mutex untuk melindungi akses map
+	tokens   map[string]time.Time // Map jti ke waktu kedaluwarsa token
+	users    map[uint64]time.Time // Map user ID ke batas waktu pencabutan
+	tokenTTL time.Duration        // Masa berlaku access token terlama, batas pencabutan user dihapus setelah lewat selama ini
+}
+
+// NewMemoryRevocationStore adalah constructor untuk memoryRevocationStore. tokenTTL adalah masa berlaku access token terlama.
+func NewMemoryRevocationStore(tokenTTL time.Duration) RevocationStore {
+	return &memoryRevocationStore{
+		tokens:   make(map[string]time.Time),
+		users:    make(map[uint64]time.Time),
+		tokenTTL: tokenTTL,
+	}
+}
+
+// RevokeToken adalah implementasi fungsi RevokeToken dari RevocationStore
+func (m *memoryRevocationStore) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokens[jti] = expiresAt
+	return nil
+}
+
+// PurgeExpired adalah implementasi fungsi PurgeExpired dari RevocationStore.
+// Batas pencabutan user ikut dihapus setelah lewat tokenTTL, karena semua token yang terbit sebelumnya sudah kedaluwarsa.
+func (m *memoryRevocationStore) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var purged int64
+	for id, exp := range m.tokens {
+		if exp.Before(now) {
+			delete(m.tokens, id)
+			purged++
+		}
+	}
+	for userID, before := range m.users {
+		if before.Add(m.tokenTTL).Before(now) {
+			delete(m.users, userID)
+		}
+	}
+	return purged, nil
+}
+
+// IsTokenRevoked adalah implementasi fungsi IsTokenRevoked dari RevocationStore
+func (m *memoryRevocationStore) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.tokens[jti]
+	return ok, nil
+}
+
+// RevokeAllForUser adalah implementasi fungsi RevokeAllForUser dari RevocationStore
+func (m *memoryRevocationStore) RevokeAllForUser(ctx context.Context, userID uint64, before time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.users[userID] = before
+	return nil
+}
+
+// RevokedBefore adalah implementasi fungsi RevokedBefore dari RevocationStore
+func (m *memoryRevocationStore) RevokedBefore(ctx context.Context, userID uint64) (time.Time, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.users[userID], nil
+}
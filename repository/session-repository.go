@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context" // Mengimport package context untuk pembatalan dan tenggat waktu query
+	"time"    // Mengimport package time untuk mengelola waktu
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity" // Mengimport package entity untuk model entitas
+	"gorm.io/gorm"                                          // Mengimport package gorm untuk ORM
+)
+
+// SessionRepository adalah interface yang mendefinisikan fungsi yang harus diimplementasikan oleh repository Session
+type SessionRepository interface {
+	InsertSession(ctx context.Context, session entity.Session) (entity.Session, error)             // Fungsi untuk menyimpan sesi baru
+	FindByFamily(ctx context.Context, familyID string) (entity.Session, error)                     // Fungsi untuk mencari sesi berdasarkan family refresh token
+	FindActive(ctx context.Context, sessionID uint64) (entity.Session, error)                      // Fungsi untuk mencari sesi yang masih aktif berdasarkan ID
+	ActiveForUser(ctx context.Context, userID uint64) ([]entity.Session, error)                    // Fungsi untuk mendapatkan semua sesi aktif milik user
+	TouchLastSeen(ctx context.Context, sessionID uint64, seenAt time.Time, before time.Time) error // Fungsi untuk mencatat waktu terakhir sesi dipakai
+}
+
+// sessionConnection adalah implementasi dari SessionRepository
+type sessionConnection struct {
+	connection *gorm.DB // Koneksi database menggunakan gorm
+}
+
+// NewSessionRepository adalah constructor untuk sessionConnection
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionConnection{
+		connection: db,
+	}
+}
+
+// InsertSession adalah implementasi fungsi InsertSession dari SessionRepository
+func (db *sessionConnection) InsertSession(ctx context.Context, session entity.Session) (entity.Session, error) {
+	err := db.connection.WithContext(ctx).Create(&session).Error // Menyimpan sesi ke database
+	return session, translateError(err, "session")
+}
+
+// FindByFamily adalah implementasi fungsi FindByFamily dari SessionRepository
+func (db *sessionConnection) FindByFamily(ctx context.Context, familyID string) (entity.Session, error) {
+	var session entity.Session
+	err := db.connection.WithContext(ctx).Where("family_id = ?", familyID).Take(&session).Error // Mengambil sesi berdasarkan family refresh token
+	return session, translateError(err, "session")
+}
+
+// FindActive adalah implementasi fungsi FindActive dari SessionRepository.
+// Sesi yang family refresh token-nya sudah dicabut atau kedaluwarsa dianggap tidak ada.
+func (db *sessionConnection) FindActive(ctx context.Context, sessionID uint64) (entity.Session, error) {
+	var session entity.Session
+	err := db.active(ctx).Where("id = ?", sessionID).Take(&session).Error // Mengambil sesi aktif berdasarkan ID
+	return session, translateError(err, "session")
+}
+
+// ActiveForUser adalah implementasi fungsi ActiveForUser dari SessionRepository
+func (db *sessionConnection) ActiveForUser(ctx context.Context, userID uint64) ([]entity.Session, error) {
+	sessions := []entity.Session{}
+	err := db.active(ctx).Where("user_id = ?", userID).Order("last_seen_at DESC").Find(&sessions).Error // Mengambil sesi aktif milik user, yang terakhir dipakai lebih dulu
+	return sessions, err
+}
+
+// TouchLastSeen adalah implementasi fungsi TouchLastSeen dari SessionRepository.
+// Waktu hanya diperbarui jika masih lebih lama dari before, sehingga request bersamaan tidak menulis berulang kali.
+func (db *sessionConnection) TouchLastSeen(ctx context.Context, sessionID uint64, seenAt time.Time, before time.Time) error {
+	return db.connection.WithContext(ctx).Model(&entity.Session{}).
+		Where("id = ? AND last_seen_at < ?", sessionID, before).
+		Update("last_seen_at", seenAt).Error // Mencatat waktu terakhir sesi dipakai
+}
+
+// active membuat query sesi yang family-nya masih memiliki refresh token aktif.
+// Logout, logout dari semua sesi, reset password, dan deteksi pemakaian ulang refresh token
+// semuanya mencabut refresh token, sehingga sesi ikut berakhir tanpa perlu diperbarui satu per satu.
+func (db *sessionConnection) active(ctx context.Context) *gorm.DB {
+	return db.connection.WithContext(ctx).Model(&entity.Session{}).
+		Where("EXISTS (SELECT 1 FROM refresh_tokens WHERE refresh_tokens.family_id = sessions.family_id AND refresh_tokens.revoked_at IS NULL AND refresh_tokens.expires_at > ?)", time.Now())
+}
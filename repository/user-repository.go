@@ -1,91 +1,197 @@
 package repository
 
 import (
-	"log" // Mengimport package log untuk logging
+	"context" // Mengimport package context untuk pembatalan dan tenggat waktu query
+	"time"    // Mengimport package time untuk batas waktu penguncian akun
 
-	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity" // Mengimport package entity untuk model entitas
-	"golang.org/x/crypto/bcrypt"                            // Mengimport package bcrypt untuk hashing password
-	"gorm.io/gorm"                                          // Mengimport package gorm untuk ORM
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity"   // Mengimport package entity untuk model entitas
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/password" // Mengimport package password untuk hashing password
+	"gorm.io/gorm"                                            // Mengimport package gorm untuk ORM
 )
 
 // UserRepository adalah interface yang mendefinisikan fungsi yang harus diimplementasikan oleh repository User
 type UserRepository interface {
-	InsertUser(user entity.User) entity.User                    // Fungsi untuk menyimpan user baru
-	UpdateUser(user entity.User) entity.User                    // Fungsi untuk mengupdate user
-	VerifyCredential(email string, password string) interface{} // Fungsi untuk verifikasi credential user
-	IsDuplicateEmail(email string) (tx *gorm.DB)                // Fungsi untuk memeriksa apakah email sudah digunakan
-	FindByEmail(email string) entity.User                       // Fungsi untuk mencari user berdasarkan email
-	ProfileUser(userID string) entity.User                      // Fungsi untuk mendapatkan profil user berdasarkan ID
+	InsertUser(ctx context.Context, user entity.User) (entity.User, error)                              // Fungsi untuk menyimpan user baru
+	UpdateUser(ctx context.Context, user entity.User) (entity.User, error)                              // Fungsi untuk mengupdate user
+	IsDuplicateEmail(ctx context.Context, email string) (bool, error)                                   // Fungsi untuk memeriksa apakah email sudah digunakan
+	FindByEmail(ctx context.Context, email string) (entity.User, error)                                 // Fungsi untuk mencari user berdasarkan email
+	ProfileUser(ctx context.Context, userID uint64) (entity.User, error)                                // Fungsi untuk mendapatkan profil user berdasarkan ID
+	FindByID(ctx context.Context, userID uint64) (entity.User, error)                                   // Fungsi untuk mencari user berdasarkan ID tanpa relasi
+	AllUser(ctx context.Context) ([]entity.User, error)                                                 // Fungsi untuk mendapatkan semua user
+	UpdateRole(ctx context.Context, userID uint64, role string) (entity.User, error)                    // Fungsi untuk mengubah role user
+	UpdateSuspended(ctx context.Context, userID uint64, suspended bool) (entity.User, error)            // Fungsi untuk memblokir atau membuka blokir user
+	UpdatePassword(ctx context.Context, userID uint64, password string) error                           // Fungsi untuk mengganti password user
+	UpdateEmail(ctx context.Context, userID uint64, email string) error                                 // Fungsi untuk mengganti email user dan menandainya belum terverifikasi
+	RehashPassword(ctx context.Context, userID uint64, oldHash string, password string) (string, error) // Fungsi untuk mengganti hash lama dengan hash baru dari password yang sama, kosong jika password sudah diganti
+	IncrementFailedLogins(ctx context.Context, userID uint64) (int, error)                              // Fungsi untuk menambah jumlah login gagal dan mengembalikan jumlah terbarunya
+	SetLockedUntil(ctx context.Context, userID uint64, until time.Time) error                           // Fungsi untuk mengunci akun sampai waktu tertentu
+	ResetFailedLogins(ctx context.Context, userID uint64) error                                         // Fungsi untuk menghapus jumlah login gagal dan membuka kunci akun
+	SetEmailVerified(ctx context.Context, userID uint64, verifiedAt *time.Time) error                   // Fungsi untuk menandai email user terverifikasi, nil berarti belum terverifikasi
 }
 
 // userConnection adalah implementasi dari UserRepository
 type userConnection struct {
-	connection *gorm.DB // Koneksi database menggunakan gorm
+	connection *gorm.DB        // Koneksi database menggunakan gorm
+	hasher     password.Hasher // Hasher untuk password yang disimpan
 }
 
 // NewUserRepository adalah constructor untuk userConnection
-func NewUserRepository(db *gorm.DB) UserRepository {
+func NewUserRepository(db *gorm.DB, hasher password.Hasher) UserRepository {
 	return &userConnection{
 		connection: db,
+		hasher:     hasher,
 	}
 }
 
 // InsertUser adalah implementasi fungsi InsertUser dari UserRepository
-func (db *userConnection) InsertUser(user entity.User) entity.User {
-	user.Password = hashAndSalt([]byte(user.Password)) // Menghash password sebelum disimpan
-	db.connection.Save(&user)                          // Menyimpan user ke database
-	return user                                        // Mengembalikan user yang telah disimpan
-}
-
-// UpdateUser adalah implementasi fungsi UpdateUser dari UserRepository
-func (db *userConnection) UpdateUser(user entity.User) entity.User {
-	if user.Password != "" { // Jika password diinput, menghash password baru
-		user.Password = hashAndSalt([]byte(user.Password))
-	} else { // Jika tidak ada input password, menggunakan password yang ada di database
-		var tempUser entity.User
-		db.connection.Find(&tempUser, user.ID)
-		user.Password = tempUser.Password
+func (db *userConnection) InsertUser(ctx context.Context, user entity.User) (entity.User, error) {
+	hash, err := db.hasher.Hash(user.Password) // Menghash password sebelum disimpan
+	if err != nil {
+		return entity.User{}, err
 	}
-	db.connection.Save(&user) // Menyimpan perubahan data user ke database
-	return user               // Mengembalikan user yang telah diupdate
+	user.Password = hash
+	err = db.connection.WithContext(ctx).Create(&user).Error // Menyimpan user ke database
+	return user, translateError(err, "user")                 // Mengembalikan user yang telah disimpan
 }
 
-// VerifyCredential adalah implementasi fungsi VerifyCredential dari UserRepository
-func (db *userConnection) VerifyCredential(email string, password string) interface{} {
-	var user entity.User
-	res := db.connection.Where("email = ?", email).Take(&user) // Mengambil user berdasarkan email dari database
-	if res.Error == nil {                                      // Jika tidak ada error, mengembalikan data user
-		return user
+// UpdateUser adalah implementasi fungsi UpdateUser dari UserRepository
+func (db *userConnection) UpdateUser(ctx context.Context, user entity.User) (entity.User, error) {
+	tx := db.connection.WithContext(ctx)
+	// Hanya nama yang diubah: email dan password punya fungsi sendiri, role dan status blokir hanya bisa diubah admin
+	if err := tx.Model(&user).Select("Name").Updates(&user).Error; err != nil { // Menyimpan perubahan data user ke database
+		return entity.User{}, translateError(err, "user")
 	}
-	return nil // Jika error, mengembalikan nil
+	return db.FindByID(ctx, user.ID) // Mengambil ulang user agar role dan status blokir ikut dikembalikan
 }
 
 // IsDuplicateEmail adalah implementasi fungsi IsDuplicateEmail dari UserRepository
-func (db *userConnection) IsDuplicateEmail(email string) (tx *gorm.DB) {
-	var user entity.User
-	return db.connection.Where("email = ?", email).Take(&user) // Mengambil user berdasarkan email dari database
+func (db *userConnection) IsDuplicateEmail(ctx context.Context, email string) (bool, error) {
+	var count int64
+	err := db.connection.WithContext(ctx).Model(&entity.User{}).Where("email = ?", email).Count(&count).Error // Menghitung user dengan email tersebut
+	return count > 0, err
 }
 
 // FindByEmail adalah implementasi fungsi FindByEmail dari UserRepository
-func (db *userConnection) FindByEmail(email string) entity.User {
+func (db *userConnection) FindByEmail(ctx context.Context, email string) (entity.User, error) {
 	var user entity.User
-	db.connection.Where("email = ?", email).Take(&user) // Mengambil user berdasarkan email dari database
-	return user                                         // Mengembalikan data user yang ditemukan
+	err := db.connection.WithContext(ctx).Where("email = ?", email).Take(&user).Error // Mengambil user berdasarkan email dari database
+	return user, translateError(err, "user")                                          // Mengembalikan data user yang ditemukan
 }
 
 // ProfileUser adalah implementasi fungsi ProfileUser dari UserRepository
-func (db *userConnection) ProfileUser(userID string) entity.User {
+func (db *userConnection) ProfileUser(ctx context.Context, userID uint64) (entity.User, error) {
+	var user entity.User
+	err := db.connection.WithContext(ctx).Preload("Books").Preload("Books.User").Take(&user, userID).Error // Mengambil data user dan relasi Books dari database
+	return user, translateError(err, "user")                                                               // Mengembalikan profil user yang ditemukan
+}
+
+// FindByID adalah implementasi fungsi FindByID dari UserRepository
+func (db *userConnection) FindByID(ctx context.Context, userID uint64) (entity.User, error) {
 	var user entity.User
-	db.connection.Preload("Books").Preload("Books.User").Find(&user, userID) // Mengambil data user dan relasi Books dari database
-	return user                                                              // Mengembalikan profil user yang ditemukan
+	err := db.connection.WithContext(ctx).Take(&user, userID).Error // Mengambil user berdasarkan ID dari database
+	return user, translateError(err, "user")                        // Mengembalikan data user yang ditemukan
+}
+
+// AllUser adalah implementasi fungsi AllUser dari UserRepository
+func (db *userConnection) AllUser(ctx context.Context) ([]entity.User, error) {
+	var users []entity.User
+	err := db.connection.WithContext(ctx).Find(&users).Error // Mengambil semua user dari database
+	return users, err                                        // Mengembalikan semua user yang ditemukan
+}
+
+// UpdateRole adalah implementasi fungsi UpdateRole dari UserRepository
+func (db *userConnection) UpdateRole(ctx context.Context, userID uint64, role string) (entity.User, error) {
+	err := db.connection.WithContext(ctx).Model(&entity.User{}).Where("id = ?", userID).Update("role", role).Error // Mengubah role user di database
+	if err != nil {
+		return entity.User{}, err
+	}
+	return db.FindByID(ctx, userID) // Mengembalikan data user terbaru
+}
+
+// UpdateSuspended adalah implementasi fungsi UpdateSuspended dari UserRepository
+func (db *userConnection) UpdateSuspended(ctx context.Context, userID uint64, suspended bool) (entity.User, error) {
+	err := db.connection.WithContext(ctx).Model(&entity.User{}).Where("id = ?", userID).Update("suspended", suspended).Error // Mengubah status blokir user di database
+	if err != nil {
+		return entity.User{}, err
+	}
+	return db.FindByID(ctx, userID) // Mengembalikan data user terbaru
 }
 
-// hashAndSalt adalah fungsi untuk menghash password menggunakan bcrypt
-func hashAndSalt(pwd []byte) string {
-	hash, err := bcrypt.GenerateFromPassword(pwd, bcrypt.MinCost) // Menghasilkan hash password dengan cost minimum
+// UpdatePassword adalah implementasi fungsi UpdatePassword dari UserRepository
+func (db *userConnection) UpdatePassword(ctx context.Context, userID uint64, password string) error {
+	hash, err := db.hasher.Hash(password) // Menghash password baru sebelum disimpan
 	if err != nil {
-		log.Print(err)                     // Jika terjadi error, log pesan error
-		panic("Failed to hash a password") // Panic jika gagal menghash password
+		return err
 	}
-	return string(hash) // Mengembalikan hash password dalam bentuk string
+	res := db.connection.WithContext(ctx).Model(&entity.User{}).Where("id = ?", userID).Update("password", hash) // Mengganti password user di database
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return translateError(gorm.ErrRecordNotFound, "user")
+	}
+	return nil
+}
+
+// UpdateEmail adalah implementasi fungsi UpdateEmail dari UserRepository
+func (db *userConnection) UpdateEmail(ctx context.Context, userID uint64, email string) error {
+	res := db.connection.WithContext(ctx).Model(&entity.User{}).Where("id = ?", userID).
+		Updates(map[string]interface{}{"email": email, "email_verified_at": nil}) // Email baru harus diverifikasi ulang
+	if res.Error != nil {
+		return translateError(res.Error, "user") // Conflict jika email sudah dipakai user lain
+	}
+	if res.RowsAffected == 0 {
+		return translateError(gorm.ErrRecordNotFound, "user")
+	}
+	return nil
+}
+
+// RehashPassword adalah implementasi fungsi RehashPassword dari UserRepository.
+// Hash hanya diganti jika masih sama dengan oldHash agar password yang baru saja diganti tidak tertimpa.
+func (db *userConnection) RehashPassword(ctx context.Context, userID uint64, oldHash string, password string) (string, error) {
+	hash, err := db.hasher.Hash(password) // Menghash ulang password dengan algoritma dan cost saat ini
+	if err != nil {
+		return "", err
+	}
+	res := db.connection.WithContext(ctx).Model(&entity.User{}).Where("id = ? AND password = ?", userID, oldHash).Update("password", hash)
+	if res.Error != nil {
+		return "", res.Error
+	}
+	if res.RowsAffected == 0 {
+		return "", nil // Password sudah diganti di request lain
+	}
+	return hash, nil
+}
+
+// IncrementFailedLogins adalah implementasi fungsi IncrementFailedLogins dari UserRepository.
+// Penambahan dilakukan di database agar login gagal yang bersamaan tidak saling menimpa.
+func (db *userConnection) IncrementFailedLogins(ctx context.Context, userID uint64) (int, error) {
+	var failures int
+	err := db.connection.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&entity.User{}).Where("id = ?", userID).Update("failed_logins", gorm.Expr("failed_logins + 1")) // Menambah jumlah login gagal
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return translateError(gorm.ErrRecordNotFound, "user")
+		}
+		return tx.Model(&entity.User{}).Where("id = ?", userID).Pluck("failed_logins", &failures).Error // Membaca jumlah terbaru
+	})
+	return failures, err
+}
+
+// SetLockedUntil adalah implementasi fungsi SetLockedUntil dari UserRepository
+func (db *userConnection) SetLockedUntil(ctx context.Context, userID uint64, until time.Time) error {
+	return db.connection.WithContext(ctx).Model(&entity.User{}).Where("id = ?", userID).Update("locked_until", until).Error // Mengunci akun sampai waktu until
+}
+
+// ResetFailedLogins adalah implementasi fungsi ResetFailedLogins dari UserRepository
+func (db *userConnection) ResetFailedLogins(ctx context.Context, userID uint64) error {
+	return db.connection.WithContext(ctx).Model(&entity.User{}).Where("id = ?", userID).
+		Updates(map[string]interface{}{"failed_logins": 0, "locked_until": nil}).Error // Menghapus jumlah login gagal dan membuka kunci akun
+}
+
+// SetEmailVerified adalah implementasi fungsi SetEmailVerified dari UserRepository
+func (db *userConnection) SetEmailVerified(ctx context.Context, userID uint64, verifiedAt *time.Time) error {
+	return db.connection.WithContext(ctx).Model(&entity.User{}).Where("id = ?", userID).Update("email_verified_at", verifiedAt).Error // Mengubah status verifikasi email user
 }
@@ -0,0 +1,97 @@
+package search
+
+import (
+	"context" // Mengimport package context untuk pembatalan dan tenggat waktu pencarian
+	"errors"  // Mengimport package errors untuk pengecekan error
+	"strconv" // Mengimport package strconv untuk konversi ID dokumen
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity" // Mengimport package entity untuk model entitas
+	"github.com/blevesearch/bleve/v2"                       // Mengimport package bleve untuk index full-text lokal
+	"github.com/blevesearch/bleve/v2/analysis/lang/en"      // Mengimport analyzer bahasa Inggris (stemming dan stop words)
+	"github.com/blevesearch/bleve/v2/mapping"               // Mengimport package mapping untuk definisi field index
+)
+
+// bleveBookIndex adalah implementasi BookIndex menggunakan index Bleve yang disimpan di disk lokal
+type bleveBookIndex struct {
+	index bleve.Index // Index Bleve yang menyimpan judul dan deskripsi buku
+}
+
+// OpenBleveBookIndex membuka index Bleve di path, atau membuatnya jika belum ada.
+// Nilai created bernilai true jika index baru dibuat dan perlu diisi dengan data yang sudah ada.
+func OpenBleveBookIndex(path string) (index BookIndex, created bool, err error) {
+	idx, err := bleve.Open(path)
+	if errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
+		idx, err = bleve.New(path, bookIndexMapping())
+		created = true
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return &bleveBookIndex{index: idx}, created, nil
+}
+
+// bookIndexMapping menyusun mapping index untuk field title dan description
+func bookIndexMapping() *mapping.IndexMappingImpl {
+	textField := bleve.NewTextFieldMapping()
+	textField.Store = true // Teks disimpan agar bisa dibuat highlight
+	textField.IncludeTermVectors = true
+	textField.Analyzer = en.AnalyzerName // Analyzer bahasa Inggris agar "dragons" juga cocok dengan "dragon"
+
+	doc := bleve.NewDocumentMapping()
+	doc.AddFieldMappingsAt("title", textField)
+	doc.AddFieldMappingsAt("description", textField)
+
+	indexMapping := bleve.NewIndexMapping()
+	indexMapping.DefaultMapping = doc
+	return indexMapping
+}
+
+// Index adalah implementasi fungsi Index dari BookIndex
+func (b *bleveBookIndex) Index(ctx context.Context, book entity.Book) error {
+	return b.index.Index(strconv.FormatUint(book.ID, 10), map[string]interface{}{
+		"title":       book.Title,
+		"description": book.Description,
+	})
+}
+
+// Delete adalah implementasi fungsi Delete dari BookIndex
+func (b *bleveBookIndex) Delete(ctx context.Context, bookID uint64) error {
+	return b.index.Delete(strconv.FormatUint(bookID, 10))
+}
+
+// Search adalah implementasi fungsi Search dari BookIndex. Kecocokan pada judul diberi bobot dua kali lipat.
+func (b *bleveBookIndex) Search(ctx context.Context, query string, limit, offset int) ([]BookHit, int64, error) {
+	title := bleve.NewMatchQuery(query)
+	title.SetField("title")
+	title.SetBoost(2)
+	description := bleve.NewMatchQuery(query)
+	description.SetField("description")
+
+	req := bleve.NewSearchRequestOptions(bleve.NewDisjunctionQuery(title, description), limit, offset, false)
+	req.Highlight = bleve.NewHighlightWithStyle("html") // Kata yang cocok dibungkus <mark>
+	res, err := b.index.SearchInContext(ctx, req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	hits := make([]BookHit, 0, len(res.Hits))
+	for _, doc := range res.Hits {
+		id, err := strconv.ParseUint(doc.ID, 10, 64)
+		if err != nil {
+			continue
+		}
+		hits = append(hits, BookHit{BookID: id, Score: doc.Score, Highlights: doc.Fragments})
+	}
+	return hits, int64(res.Total), nil
+}
+
+// Ping adalah implementasi fungsi Ping dari BookIndex, index yang sudah ditutup atau rusak mengembalikan error
+func (b *bleveBookIndex) Ping(ctx context.Context) error {
+	_, err := b.index.DocCount()
+	return err
+}
+
+// Close adalah implementasi fungsi Close dari BookIndex
+func (b *bleveBookIndex) Close() error {
+	return b.index.Close()
+}
@@ -0,0 +1,24 @@
+package search
+
+import (
+	"context"                                               // Mengimport package context untuk pembatalan dan tenggat waktu pencarian
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity" // Mengimport package entity untuk model entitas
+)
+
+// BookHit adalah satu hasil pencarian buku
+type BookHit struct {
+	BookID     uint64              // BookID adalah ID buku yang cocok
+	Score      float64             // Score adalah nilai relevansi, semakin besar semakin relevan
+	Highlights map[string][]string // Highlights adalah potongan teks per field dengan kata yang cocok ditandai <mark>
+}
+
+// BookIndex adalah interface yang mendefinisikan index pencarian full-text untuk buku.
+// Implementasi yang tidak perlu sinkronisasi manual (misalnya FULLTEXT MySQL) boleh
+// mengabaikan Index dan Delete.
+type BookIndex interface {
+	Index(ctx context.Context, book entity.Book) error                                     // Fungsi untuk menambahkan atau memperbarui buku di index
+	Delete(ctx context.Context, bookID uint64) error                                       // Fungsi untuk menghapus buku dari index
+	Search(ctx context.Context, query string, limit, offset int) ([]BookHit, int64, error) // Fungsi untuk mencari buku, mengembalikan hasil dan jumlah total yang cocok
+	Ping(ctx context.Context) error                                                        // Fungsi untuk memeriksa bahwa index bisa dipakai, dipanggil oleh readiness check
+	Close() error                                                                          // Fungsi untuk menutup index
+}
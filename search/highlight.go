@@ -0,0 +1,88 @@
+package search
+
+import (
+	"html"    // Mengimport package html untuk escape teks
+	"strings" // Mengimport package strings untuk pencarian kata
+	"unicode" // Mengimport package unicode untuk memisahkan kata
+)
+
+// snippetRadius adalah jumlah karakter di sekitar kata yang cocok yang ditampilkan pada snippet
+const snippetRadius = 80
+
+// queryTerms memecah query menjadi daftar kata dalam huruf kecil
+func queryTerms(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// highlightHit membuat BookHit dengan snippet judul dan deskripsi yang mengandung kata dari query
+func highlightHit(bookID uint64, score float64, title, description string, terms []string) BookHit {
+	hit := BookHit{BookID: bookID, Score: score, Highlights: map[string][]string{}}
+	if snippet, ok := highlight(title, terms); ok {
+		hit.Highlights["title"] = []string{snippet}
+	}
+	if snippet, ok := highlight(description, terms); ok {
+		hit.Highlights["description"] = []string{snippet}
+	}
+	return hit
+}
+
+// highlight membuat snippet dari text di sekitar kata pertama yang cocok dengan terms,
+// dengan semua kata yang cocok dibungkus <mark>. Teks di-escape agar aman ditampilkan sebagai HTML.
+func highlight(text string, terms []string) (string, bool) {
+	runes := []rune(text)
+	lower := []rune(strings.ToLower(text))
+	if len(lower) != len(runes) { // Perubahan huruf kecil mengubah panjang teks, pencocokan posisi tidak aman
+		return "", false
+	}
+	type match struct{ start, end int }
+	var matches []match
+	for i := 0; i < len(lower); i++ {
+		if i > 0 && isWordRune(lower[i-1]) { // Hanya mencocokkan awal kata
+			continue
+		}
+		for _, term := range terms {
+			t := []rune(term)
+			if len(t) > 0 && i+len(t) <= len(lower) && string(lower[i:i+len(t)]) == term {
+				matches = append(matches, match{i, i + len(t)})
+				i += len(t) - 1
+				break
+			}
+		}
+	}
+	if len(matches) == 0 {
+		return "", false
+	}
+	from := matches[0].start - snippetRadius
+	if from < 0 {
+		from = 0
+	}
+	to := matches[0].end + snippetRadius
+	if to > len(runes) {
+		to = len(runes)
+	}
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("…")
+	}
+	pos := from
+	for _, m := range matches {
+		if m.start < from || m.end > to {
+			continue
+		}
+		b.WriteString(html.EscapeString(string(runes[pos:m.start])))
+		b.WriteString("<mark>" + html.EscapeString(string(runes[m.start:m.end])) + "</mark>")
+		pos = m.end
+	}
+	b.WriteString(html.EscapeString(string(runes[pos:to])))
+	if to < len(runes) {
+		b.WriteString("…")
+	}
+	return b.String(), true
+}
+
+// isWordRune memeriksa apakah karakter merupakan bagian dari kata
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
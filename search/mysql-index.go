@@ -0,0 +1,83 @@
+package search
+
+import (
+	"context"                                               // Mengimport package context untuk pembatalan dan tenggat waktu pencarian
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity" // Mengimport package entity untuk model entitas
+	"gorm.io/gorm"                                          // Mengimport package gorm untuk ORM
+)
+
+// mysqlFulltextIndexName adalah nama index FULLTEXT pada tabel books
+const mysqlFulltextIndexName = "idx_books_fulltext"
+
+// mysqlMatchClause adalah ekspresi pencarian FULLTEXT pada judul dan deskripsi buku
+const mysqlMatchClause = "MATCH(title, description) AGAINST (? IN NATURAL LANGUAGE MODE)"
+
+// mysqlBookIndex adalah implementasi BookIndex menggunakan index FULLTEXT MySQL.
+// Index diperbarui otomatis oleh MySQL sehingga Index dan Delete tidak melakukan apa-apa.
+type mysqlBookIndex struct {
+	connection *gorm.DB // Koneksi database menggunakan gorm
+}
+
+// NewMySQLBookIndex adalah constructor untuk mysqlBookIndex, sekaligus membuat index FULLTEXT jika belum ada
+func NewMySQLBookIndex(db *gorm.DB) (BookIndex, error) {
+	if !db.Migrator().HasIndex(&entity.Book{}, mysqlFulltextIndexName) {
+		err := db.Exec("CREATE FULLTEXT INDEX " + mysqlFulltextIndexName + " ON books (title, description)").Error
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &mysqlBookIndex{connection: db}, nil
+}
+
+// Index adalah implementasi fungsi Index dari BookIndex
+func (m *mysqlBookIndex) Index(ctx context.Context, book entity.Book) error {
+	return nil
+}
+
+// Delete adalah implementasi fungsi Delete dari BookIndex
+func (m *mysqlBookIndex) Delete(ctx context.Context, bookID uint64) error {
+	return nil
+}
+
+// Search adalah implementasi fungsi Search dari BookIndex
+func (m *mysqlBookIndex) Search(ctx context.Context, query string, limit, offset int) ([]BookHit, int64, error) {
+	var total int64
+	err := m.connection.WithContext(ctx).Model(&entity.Book{}).Where(mysqlMatchClause, query).Count(&total).Error // Menghitung jumlah buku yang cocok
+	if err != nil || total == 0 {
+		return nil, total, err
+	}
+
+	var rows []struct {
+		ID          uint64
+		Title       string
+		Description string
+		Score       float64
+	}
+	err = m.connection.WithContext(ctx).Model(&entity.Book{}).
+		Select("id, title, description, "+mysqlMatchClause+" AS score", query).
+		Where(mysqlMatchClause, query).
+		Order("score DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&rows).Error // Mengambil buku yang cocok beserta skor relevansinya
+	if err != nil {
+		return nil, 0, err
+	}
+
+	terms := queryTerms(query)
+	hits := make([]BookHit, 0, len(rows))
+	for _, row := range rows {
+		hits = append(hits, highlightHit(row.ID, row.Score, row.Title, row.Description, terms))
+	}
+	return hits, total, nil
+}
+
+// Ping adalah implementasi fungsi Ping dari BookIndex, database sudah diperiksa oleh readiness check sendiri
+func (m *mysqlBookIndex) Ping(ctx context.Context) error {
+	return nil
+}
+
+// Close adalah implementasi fungsi Close dari BookIndex
+func (m *mysqlBookIndex) Close() error {
+	return nil
+}
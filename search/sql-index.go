@@ -0,0 +1,89 @@
+package search
+
+import (
+	"context" // Mengimport package context untuk pembatalan dan tenggat waktu pencarian
+	"strings" // Mengimport package strings untuk menyusun klausa SQL
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity" // Mengimport package entity untuk model entitas
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/helper" // Mengimport package helper untuk escape pola LIKE
+	"gorm.io/gorm"                                          // Mengimport package gorm untuk ORM
+)
+
+// sqlBookIndex adalah implementasi BookIndex dengan LIKE biasa sehingga berjalan di semua database
+// (PostgreSQL, SQLite, MySQL). Buku cocok jika salah satu kata query ada di judul atau deskripsi,
+// dan skor dihitung dari jumlah kata yang cocok dengan bobot judul dua kali deskripsi.
+type sqlBookIndex struct {
+	connection *gorm.DB // Koneksi database menggunakan gorm
+}
+
+// NewSQLBookIndex adalah constructor untuk sqlBookIndex
+func NewSQLBookIndex(db *gorm.DB) BookIndex {
+	return &sqlBookIndex{connection: db}
+}
+
+// Index adalah implementasi fungsi Index dari BookIndex, data dibaca langsung dari tabel books
+func (s *sqlBookIndex) Index(ctx context.Context, book entity.Book) error {
+	return nil
+}
+
+// Delete adalah implementasi fungsi Delete dari BookIndex
+func (s *sqlBookIndex) Delete(ctx context.Context, bookID uint64) error {
+	return nil
+}
+
+// Search adalah implementasi fungsi Search dari BookIndex
+func (s *sqlBookIndex) Search(ctx context.Context, query string, limit, offset int) ([]BookHit, int64, error) {
+	terms := queryTerms(query)
+	if len(terms) == 0 {
+		return nil, 0, nil
+	}
+	var where, score []string
+	var whereArgs, scoreArgs []interface{}
+	for _, term := range terms {
+		pattern := "%" + helper.EscapeLike(term) + "%"
+		where = append(where, "LOWER(title) LIKE ? ESCAPE '!' OR LOWER(description) LIKE ? ESCAPE '!'")
+		whereArgs = append(whereArgs, pattern, pattern)
+		score = append(score, "CASE WHEN LOWER(title) LIKE ? ESCAPE '!' THEN 2 ELSE 0 END + CASE WHEN LOWER(description) LIKE ? ESCAPE '!' THEN 1 ELSE 0 END")
+		scoreArgs = append(scoreArgs, pattern, pattern)
+	}
+	condition := strings.Join(where, " OR ")
+
+	var total int64
+	err := s.connection.WithContext(ctx).Model(&entity.Book{}).Where(condition, whereArgs...).Count(&total).Error // Menghitung jumlah buku yang cocok
+	if err != nil || total == 0 {
+		return nil, total, err
+	}
+
+	var rows []struct {
+		ID          uint64
+		Title       string
+		Description string
+		Score       float64
+	}
+	err = s.connection.WithContext(ctx).Model(&entity.Book{}).
+		Select("id, title, description, ("+strings.Join(score, " + ")+") AS score", scoreArgs...).
+		Where(condition, whereArgs...).
+		Order("score DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&rows).Error // Mengambil buku yang cocok beserta skor relevansinya
+	if err != nil {
+		return nil, 0, err
+	}
+
+	hits := make([]BookHit, 0, len(rows))
+	for _, row := range rows {
+		hits = append(hits, highlightHit(row.ID, row.Score, row.Title, row.Description, terms))
+	}
+	return hits, total, nil
+}
+
+// Ping adalah implementasi fungsi Ping dari BookIndex, database sudah diperiksa oleh readiness check sendiri
+func (s *sqlBookIndex) Ping(ctx context.Context) error {
+	return nil
+}
+
+// Close adalah implementasi fungsi Close dari BookIndex
+func (s *sqlBookIndex) Close() error {
+	return nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context" // Mengimport package context untuk request ke service
+	"fmt"     // Mengimport package fmt untuk mencetak hasil seed
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/app"    // Mengimport container dependency aplikasi
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/dto"    // Mengimport DTO untuk membuat user dan buku
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity" // Mengimport entitas aplikasi untuk daftar role
+)
+
+// seedUser adalah contoh user beserta buku miliknya yang dibuat oleh perintah seed
+type seedUser struct {
+	name  string              // Nama user
+	email string              // Email user, dipakai untuk melewati user yang sudah ada
+	role  string              // Role user
+	books []dto.BookCreateDTO // Buku milik user
+}
+
+// seedUsers adalah data contoh untuk development dan demo
+var seedUsers = []seedUser{
+	{"Admin", "admin@example.com", entity.RoleAdmin, nil},
+	{"Editor", "editor@example.com", entity.RoleEditor, []dto.BookCreateDTO{
+		{Title: "The Go Programming Language", Description: "A thorough introduction to Go by Alan Donovan and Brian Kernighan."},
+		{Title: "Designing Data-Intensive Applications", Description: "Reliable, scalable and maintainable systems by Martin Kleppmann."},
+	}},
+	{"Reader", "reader@example.com", entity.RoleReader, []dto.BookCreateDTO{
+		{Title: "Clean Code", Description: "A handbook of agile software craftsmanship by Robert C. Martin."},
+		{Title: "The Pragmatic Programmer", Description: "Your journey to mastery by David Thomas and Andrew Hunt."},
+		{Title: "Refactoring", Description: "Improving the design of existing code by Martin Fowler."},
+	}},
+}
+
+// runSeed menjalankan perintah "seed". User yang emailnya sudah terdaftar dilewati
+// sehingga perintah ini aman dijalankan berulang kali.
+func runSeed(args []string) error {
+	flags := newFlagSet("seed")
+	password := flags.String("password", "Bookstore-demo1", "password for the sample users")
+	cfg, err := loadConfig("seed", flags, args, false)
+	if err != nil {
+		return err
+	}
+	a := app.New(cfg)
+	defer a.Close()
+
+	ctx := context.Background()
+	for _, seed := range seedUsers {
+		exists, err := a.AuthService.IsDuplicateEmail(ctx, seed.email)
+		if err != nil {
+			return err
+		}
+		if exists {
+			fmt.Printf("skipped %s: already exists\n", seed.email)
+			continue
+		}
+		user, err := createUser(ctx, a, dto.RegisterDTO{Name: seed.name, Email: seed.email, Password: *password}, seed.role)
+		if err != nil {
+			return fmt.Errorf("create %s: %w", seed.email, err)
+		}
+		for _, book := range seed.books {
+			book.UserID = user.ID
+			if _, err := a.BookService.Insert(ctx, book); err != nil {
+				return fmt.Errorf("create book %q: %w", book.Title, err)
+			}
+		}
+		fmt.Printf("created %s (%s) with %d book(s)\n", user.Email, user.Role, len(seed.books))
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"   // Mengimport package context untuk sinyal berhenti
+	"log/slog"  // Mengimport package slog untuk mencatat konfigurasi efektif
+	"os"        // Mengimport package os untuk sinyal interrupt
+	"os/signal" // Mengimport package signal untuk menangkap SIGINT dan SIGTERM
+	"syscall"   // Mengimport package syscall untuk SIGTERM
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/app" // Mengimport container dependency aplikasi
+	"github.com/gin-gonic/gin"                           // Mengimport framework gin untuk mode release
+)
+
+// runServe menjalankan server HTTP sampai menerima SIGINT atau SIGTERM, lalu berhenti dengan graceful
+func runServe(args []string) error {
+	cfg, err := loadConfig("serve", nil, args, false) // Membaca konfigurasi dari file, variabel lingkungan, dan flag
+	if err != nil {
+		return err
+	}
+	slog.Info("effective configuration", "config", cfg) // Mencatat konfigurasi efektif tanpa nilai rahasia saat startup
+	if os.Getenv(gin.EnvGinMode) == "" {                // Output debug gin tidak terstruktur, kecuali diminta lewat GIN_MODE
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	a := app.New(cfg) // Membuat koneksi database, service, dan controller
+	defer a.Close()   // Menutup index pencarian dan koneksi database secara defer
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return a.Serve(ctx) // Menjalankan server pada alamat dari konfigurasi
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context" // Mengimport package context untuk pembatalan dan tenggat waktu request
+	"errors"  // Mengimport package errors untuk membandingkan error
+	"strings" // Mengimport package strings untuk menyimpan daftar scope
+	"time"    // Mengimport package time untuk masa berlaku API key
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror"   // Mengimport package apperror untuk error domain
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/dto"        // Mengimport package dto untuk DTO (Data Transfer Object)
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity"     // Mengimport package entity untuk model entitas
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/helper"     // Mengimport package helper untuk pembuatan dan hashing key
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/repository" // Mengimport package repository untuk interaksi dengan database
+)
+
+const (
+	apiKeyPrefix        = "bsk_"      // Awalan semua API key agar mudah dikenali, misalnya oleh secret scanner
+	apiKeyDisplayLength = 12          // Panjang awal key yang disimpan dan ditampilkan untuk mengenali key
+	apiKeyTouchInterval = time.Minute // Jarak minimum antara dua pencatatan LastUsedAt, agar setiap request tidak menulis ke database
+	maxAPIKeysPerUser   = 25          // Jumlah maksimum API key aktif milik satu user
+)
+
+var (
+	// ErrInvalidAPIKey dikembalikan ketika API key tidak dikenal, sudah dicabut, kedaluwarsa, atau pemiliknya diblokir
+	ErrInvalidAPIKey = apperror.Unauthorized("invalid api key").WithCode("api_key_invalid")
+	// ErrAPIKeyExpiry dikembalikan ketika waktu kedaluwarsa API key baru sudah lewat
+	ErrAPIKeyExpiry = apperror.Invalid("expires_at must be in the future").WithCode("invalid_expiry")
+	// ErrTooManyAPIKeys dikembalikan ketika user sudah memiliki terlalu banyak API key aktif
+	ErrTooManyAPIKeys = apperror.Conflict("too many active api keys, revoke an unused key first").WithCode("api_key_limit")
+)
+
+// APIKeyService adalah interface yang mendefinisikan fungsi yang harus diimplementasikan oleh service API key
+type APIKeyService interface {
+	Create(ctx context.Context, userID uint64, key dto.APIKeyCreateDTO) (dto.APIKeyCreatedDTO, error) // Fungsi untuk membuat API key baru
+	List(ctx context.Context, userID uint64) ([]entity.APIKey, error)                                 // Fungsi untuk mendapatkan API key aktif milik user
+	Revoke(ctx context.Context, userID uint64, keyID uint64) error                                    // Fungsi untuk mencabut API key milik user
+	Authenticate(ctx context.Context, key string) (entity.User, entity.APIKey, error)                 // Fungsi untuk memeriksa API key dan mengembalikan pemiliknya
+}
+
+// apiKeyService adalah implementasi dari APIKeyService
+type apiKeyService struct {
+	apiKeyRepository repository.APIKeyRepository // Menggunakan repository untuk interaksi dengan database API key
+	userRepository   repository.UserRepository   // Menggunakan repository untuk membaca data pemilik API key
+}
+
+// NewAPIKeyService adalah constructor untuk apiKeyService
+func NewAPIKeyService(apiKeyRepo repository.APIKeyRepository, userRepo repository.UserRepository) APIKeyService {
+	return &apiKeyService{
+		apiKeyRepository: apiKeyRepo,
+		userRepository:   userRepo,
+	}
+}
+
+// Create adalah implementasi fungsi Create dari APIKeyService.
+// Key asli hanya dikembalikan di sini, database hanya menyimpan hash-nya.
+func (service *apiKeyService) Create(ctx context.Context, userID uint64, key dto.APIKeyCreateDTO) (dto.APIKeyCreatedDTO, error) {
+	scopes, err := normalizeScopes(key.Scopes)
+	if err != nil {
+		return dto.APIKeyCreatedDTO{}, apperror.Invalid(err.Error()).WithCode("invalid_scope")
+	}
+	if key.ExpiresAt != nil && !key.ExpiresAt.After(time.Now()) {
+		return dto.APIKeyCreatedDTO{}, ErrAPIKeyExpiry
+	}
+	count, err := service.apiKeyRepository.CountActiveForUser(ctx, userID)
+	if err != nil {
+		return dto.APIKeyCreatedDTO{}, err
+	}
+	if count >= maxAPIKeysPerUser {
+		return dto.APIKeyCreatedDTO{}, ErrTooManyAPIKeys
+	}
+	random, err := helper.GenerateRandomToken(32)
+	if err != nil {
+		return dto.APIKeyCreatedDTO{}, err
+	}
+	raw := apiKeyPrefix + random
+	record, err := service.apiKeyRepository.InsertKey(ctx, entity.APIKey{
+		UserID:    userID,
+		Name:      key.Name,
+		Prefix:    raw[:apiKeyDisplayLength],
+		KeyHash:   helper.HashToken(raw),
+		Scopes:    strings.Join(scopes, " "),
+		ExpiresAt: key.ExpiresAt,
+	})
+	if err != nil {
+		return dto.APIKeyCreatedDTO{}, err
+	}
+	return dto.APIKeyCreatedDTO{APIKey: record, Key: raw}, nil
+}
+
+// List adalah implementasi fungsi List dari APIKeyService
+func (service *apiKeyService) List(ctx context.Context, userID uint64) ([]entity.APIKey, error) {
+	return service.apiKeyRepository.ListForUser(ctx, userID) // Memanggil repository untuk mendapatkan API key milik user
+}
+
+// Revoke adalah implementasi fungsi Revoke dari APIKeyService
+func (service *apiKeyService) Revoke(ctx context.Context, userID uint64, keyID uint64) error {
+	return service.apiKeyRepository.Revoke(ctx, userID, keyID) // Memanggil repository untuk mencabut API key
+}
+
+// Authenticate adalah implementasi fungsi Authenticate dari APIKeyService.
+// Role pemilik dibaca dari database pada setiap request, sehingga perubahan role dan blokir langsung berlaku.
+func (service *apiKeyService) Authenticate(ctx context.Context, key string) (entity.User, entity.APIKey, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) { // Bukan API key, database tidak perlu dibaca
+		return entity.User{}, entity.APIKey{}, ErrInvalidAPIKey
+	}
+	record, err := service.apiKeyRepository.FindByHash(ctx, helper.HashToken(key))
+	if errors.Is(err, apperror.ErrNotFound) {
+		return entity.User{}, entity.APIKey{}, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return entity.User{}, entity.APIKey{}, err
+	}
+	now := time.Now()
+	if record.RevokedAt != nil || (record.ExpiresAt != nil && now.After(*record.ExpiresAt)) {
+		return entity.User{}, entity.APIKey{}, ErrInvalidAPIKey
+	}
+	user, err := service.userRepository.FindByID(ctx, record.UserID)
+	if errors.Is(err, apperror.ErrNotFound) {
+		return entity.User{}, entity.APIKey{}, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return entity.User{}, entity.APIKey{}, err
+	}
+	if user.Suspended { // Key milik user yang diblokir tidak bisa dipakai, tetapi berlaku lagi jika blokir dibuka
+		return entity.User{}, entity.APIKey{}, ErrInvalidAPIKey
+	}
+	if record.LastUsedAt == nil || now.Sub(*record.LastUsedAt) >= apiKeyTouchInterval {
+		if err := service.apiKeyRepository.TouchLastUsed(ctx, record.ID, now); err != nil {
+			return entity.User{}, entity.APIKey{}, err
+		}
+		record.LastUsedAt = &now
+	}
+	return user, record, nil
+}
@@ -1,76 +1,156 @@
 package service
 
 import (
-	"log" // Mengimport package log untuk logging
+	"context" // Mengimport package context untuk pembatalan dan tenggat waktu request
+	"time"    // Mengimport package time untuk menghitung lama penguncian akun
 
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror"   // Mengimport package apperror untuk error domain
 	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/dto"        // Mengimport package dto untuk DTO (Data Transfer Object)
 	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity"     // Mengimport package entity untuk model entitas
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/logging"    // Mengimport package logging untuk mencatat rehash yang gagal
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/password"   // Mengimport package password untuk verifikasi hash password
 	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/repository" // Mengimport package repository untuk interaksi dengan database
 	"github.com/mashingan/smapping"                             // Mengimport package smapping untuk mapping struct
-	"golang.org/x/crypto/bcrypt"                                // Mengimport package bcrypt untuk hashing password
 )
 
+// ErrInvalidCredential dikembalikan ketika email atau password tidak cocok
+var ErrInvalidCredential = apperror.Unauthorized("invalid credential").WithCode("invalid_credential")
+
+// ErrAccountLocked dikembalikan ketika akun dikunci sementara karena terlalu banyak login gagal
+var ErrAccountLocked = apperror.TooMany("account temporarily locked after too many failed logins").WithCode("account_locked")
+
+// LockoutPolicy menentukan kapan akun dikunci setelah login gagal berturut-turut.
+// Akun dikunci selama BaseDelay saat kegagalan ke-Threshold, lalu lamanya berlipat dua
+// untuk setiap kegagalan berikutnya sampai MaxDelay. Threshold 0 mematikan penguncian.
+type LockoutPolicy struct {
+	Threshold int           // Jumlah login gagal berturut-turut sebelum akun dikunci
+	BaseDelay time.Duration // Lama penguncian pertama
+	MaxDelay  time.Duration // Lama penguncian maksimum
+}
+
+// lockDuration mengembalikan lama penguncian setelah failures kali login gagal, atau 0 jika akun belum perlu dikunci
+func (p LockoutPolicy) lockDuration(failures int) time.Duration {
+	if p.Threshold <= 0 || failures < p.Threshold {
+		return 0
+	}
+	d := p.BaseDelay
+	for i := p.Threshold; i < failures && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	if d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d
+}
+
 // AuthService adalah interface yang mendefinisikan fungsi yang harus diimplementasikan oleh service Auth
 type AuthService interface {
-	VerifyCredential(email string, password string) interface{} // Fungsi untuk verifikasi credential user
-	CreateUser(user dto.RegisterDTO) entity.User                // Fungsi untuk membuat user baru
-	FindByEmail(email string) entity.User                       // Fungsi untuk mencari user berdasarkan email
-	IsDuplicateEmail(email string) bool                         // Fungsi untuk memeriksa apakah email sudah digunakan
+	VerifyCredential(ctx context.Context, email string, password string) (entity.User, error) // Fungsi untuk verifikasi credential user
+	CreateUser(ctx context.Context, user dto.RegisterDTO) (entity.User, error)                // Fungsi untuk membuat user baru
+	FindByEmail(ctx context.Context, email string) (entity.User, error)                       // Fungsi untuk mencari user berdasarkan email
+	IsDuplicateEmail(ctx context.Context, email string) (bool, error)                         // Fungsi untuk memeriksa apakah email sudah digunakan
+	FindByID(ctx context.Context, userID uint64) (entity.User, error)                         // Fungsi untuk mencari user berdasarkan ID
 }
 
 // authService adalah implementasi dari AuthService
 type authService struct {
 	userRepository repository.UserRepository // Menggunakan repository untuk interaksi dengan database user
+	hasher         password.Hasher           // Hasher untuk memverifikasi password yang tersimpan
+	policy         PasswordPolicy            // Kebijakan password untuk user baru
+	lockout        LockoutPolicy             // Kebijakan penguncian akun setelah login gagal berulang kali
 }
 
 // NewAuthService adalah constructor untuk authService
-func NewAuthService(userRep repository.UserRepository) AuthService {
+func NewAuthService(userRep repository.UserRepository, hasher password.Hasher, policy PasswordPolicy, lockout LockoutPolicy) AuthService {
 	return &authService{
 		userRepository: userRep,
+		hasher:         hasher,
+		policy:         policy,
+		lockout:        lockout,
 	}
 }
 
-// VerifyCredential adalah implementasi fungsi VerifyCredential dari AuthService
-func (service *authService) VerifyCredential(email string, password string) interface{} {
-	res := service.userRepository.VerifyCredential(email, password) // Memanggil repository untuk verifikasi credential
-	if v, ok := res.(entity.User); ok {                             // Memeriksa apakah hasil verifikasi adalah instance dari entity.User
-		comparePassword := comparePassword(v.Password, []byte(password)) // Membandingkan password yang dihash dengan password input
-		if v.Email == email && comparePassword {                         // Jika email dan password cocok
-			return res // Mengembalikan hasil verifikasi
+// VerifyCredential adalah implementasi fungsi VerifyCredential dari AuthService.
+// Email yang tidak terdaftar dan password yang salah sama-sama menghasilkan ErrInvalidCredential
+// agar client tidak bisa menebak email mana yang terdaftar.
+// Akun yang dikunci menghasilkan ErrAccountLocked tanpa memeriksa password, sehingga tebakan password tidak berguna selama masa penguncian.
+// Hash dengan algoritma atau cost lama diganti selagi password asli tersedia; user yang dikembalikan membawa hash yang baru.
+func (service *authService) VerifyCredential(ctx context.Context, email string, password string) (entity.User, error) {
+	user, err := service.userRepository.FindByEmail(ctx, email) // Memanggil repository untuk mencari user berdasarkan email
+	if apperror.KindOf(err) == apperror.KindNotFound {
+		return entity.User{}, ErrInvalidCredential
+	}
+	if err != nil {
+		return entity.User{}, err
+	}
+	now := time.Now()
+	if user.LockedUntil != nil && now.Before(*user.LockedUntil) { // Akun masih dikunci
+		return entity.User{}, ErrAccountLocked.WithRetryAfter(user.LockedUntil.Sub(now))
+	}
+	ok, rehash := service.hasher.Verify(user.Password, password) // Membandingkan password yang dihash dengan password input
+	if !ok {
+		return entity.User{}, recordFailedLogin(ctx, service.userRepository, service.lockout, user.ID, now, ErrInvalidCredential)
+	}
+	if rehash {
+		hash, err := service.userRepository.RehashPassword(ctx, user.ID, user.Password, password)
+		if err != nil { // Login tetap berhasil, hash lama diganti pada login berikutnya
+			logging.FromContext(ctx).Warn("failed to rehash password", "user_id", user.ID, "error", err)
+		} else if hash != "" {
+			user.Password = hash
 		}
-		return false // Jika tidak cocok, mengembalikan false
 	}
-	return res // Mengembalikan hasil verifikasi
+	if user.FailedLogins > 0 || user.LockedUntil != nil { // Login berhasil menghapus catatan login gagal sebelumnya
+		if err := service.userRepository.ResetFailedLogins(ctx, user.ID); err != nil {
+			return entity.User{}, err
+		}
+		user.FailedLogins, user.LockedUntil = 0, nil
+	}
+	return user, nil // Mengembalikan user jika email dan password cocok
+}
+
+// recordFailedLogin mencatat login gagal dan mengunci akun jika batas kebijakan terlampaui.
+// Password dan kode 2FA yang salah dihitung bersama, sehingga penguncian juga membatasi tebakan kode 2FA.
+// Error yang dikembalikan adalah error untuk client: ErrAccountLocked jika akun baru saja dikunci, selain itu failErr.
+func recordFailedLogin(ctx context.Context, userRepo repository.UserRepository, lockout LockoutPolicy, userID uint64, now time.Time, failErr error) error {
+	failures, err := userRepo.IncrementFailedLogins(ctx, userID)
+	if err != nil {
+		return err
+	}
+	d := lockout.lockDuration(failures)
+	if d == 0 {
+		return failErr
+	}
+	if err := userRepo.SetLockedUntil(ctx, userID, now.Add(d)); err != nil {
+		return err
+	}
+	return ErrAccountLocked.WithRetryAfter(d)
 }
 
 // CreateUser adalah implementasi fungsi CreateUser dari AuthService
-func (service *authService) CreateUser(user dto.RegisterDTO) entity.User {
+func (service *authService) CreateUser(ctx context.Context, user dto.RegisterDTO) (entity.User, error) {
 	userToCreate := entity.User{}                                        // Mendeklarasikan variabel untuk menyimpan data user yang akan dibuat
 	err := smapping.FillStruct(&userToCreate, smapping.MapFields(&user)) // Mengisi struct user dengan data dari DTO
 	if err != nil {
-		log.Fatalf("Failed map %v", err) // Jika terjadi error pada mapping, program akan berhenti dan menampilkan pesan error
+		return entity.User{}, apperror.Internal("failed to map user", err) // Error mapping dikembalikan, tidak menghentikan server
+	}
+	if err := service.policy.Validate(user.Password); err != nil {
+		return entity.User{}, err
 	}
-	res := service.userRepository.InsertUser(userToCreate) // Memanggil repository untuk membuat user baru
-	return res                                             // Mengembalikan user yang telah dibuat
+	userToCreate.Role = entity.RoleReader                       // User baru selalu mendapat role reader
+	return service.userRepository.InsertUser(ctx, userToCreate) // Memanggil repository untuk membuat user baru, conflict jika email sudah dipakai
 }
 
 // FindByEmail adalah implementasi fungsi FindByEmail dari AuthService
-func (service *authService) FindByEmail(email string) entity.User {
-	return service.userRepository.FindByEmail(email) // Memanggil repository untuk mencari user berdasarkan email
+func (service *authService) FindByEmail(ctx context.Context, email string) (entity.User, error) {
+	return service.userRepository.FindByEmail(ctx, email) // Memanggil repository untuk mencari user berdasarkan email
 }
 
-// IsDuplicateEmail adalah implementasi fungsi IsDuplicateEmail dari AuthService
-func (service *authService) IsDuplicateEmail(email string) bool {
-	res := service.userRepository.IsDuplicateEmail(email) // Memanggil repository untuk memeriksa apakah email sudah digunakan
-	return !(res.Error == nil)                            // Mengembalikan true jika email sudah digunakan, false jika belum
+// FindByID adalah implementasi fungsi FindByID dari AuthService
+func (service *authService) FindByID(ctx context.Context, userID uint64) (entity.User, error) {
+	return service.userRepository.FindByID(ctx, userID) // Memanggil repository untuk mencari user berdasarkan ID
 }
 
-// comparePassword adalah fungsi untuk membandingkan password yang dihash dengan password input
-func comparePassword(hashedPwd string, plainPassword []byte) bool {
-	byteHash := []byte(hashedPwd)                                 // Mengkonversi password yang dihash menjadi byte slice
-	err := bcrypt.CompareHashAndPassword(byteHash, plainPassword) // Membandingkan password yang dihash dengan password input
-	if err != nil {
-		return false // Jika tidak cocok, mengembalikan false
-	}
-	return true // Jika cocok, mengembalikan true
+// IsDuplicateEmail adalah implementasi fungsi IsDuplicateEmail dari AuthService
+func (service *authService) IsDuplicateEmail(ctx context.Context, email string) (bool, error) {
+	return service.userRepository.IsDuplicateEmail(ctx, email) // Mengembalikan true jika email sudah digunakan
 }
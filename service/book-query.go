@@ -0,0 +1,166 @@
+package service
+
+import (
+	"encoding/base64" // Mengimport package base64 untuk encoding cursor
+	"encoding/json"   // Mengimport package json untuk serialisasi cursor
+	"fmt"             // Mengimport package fmt untuk membungkus error
+	"strings"         // Mengimport package strings untuk parsing parameter sort
+	"time"            // Mengimport package time untuk parsing filter tanggal
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror"   // Mengimport package apperror untuk error domain
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/dto"        // Mengimport package dto untuk parameter query dari client
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity"     // Mengimport package entity untuk model entitas
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/repository" // Mengimport package repository untuk parameter query database
+)
+
+// ErrInvalidBookQuery dikembalikan ketika parameter sort, filter, atau cursor tidak valid
+var ErrInvalidBookQuery = apperror.Invalid("invalid book query").WithCode("invalid_book_query")
+
+const (
+	defaultBooksPerPage = 20  // Jumlah buku per halaman jika per_page tidak diisi
+	maxBooksPerPage     = 100 // Jumlah buku per halaman maksimal
+)
+
+// bookSortColumns adalah daftar kolom yang boleh dipakai pada parameter sort
+var bookSortColumns = map[string]string{
+	"id":         "id",
+	"title":      "title",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// bookCursor adalah isi cursor yang dikirimkan ke client dalam bentuk base64
+type bookCursor struct {
+	Sort   string            `json:"s"` // Sort adalah parameter sort saat cursor dibuat, cursor hanya berlaku untuk sort yang sama
+	Values []json.RawMessage `json:"v"` // Values adalah nilai kolom sort dari data terakhir
+}
+
+// parseBookSort mengubah parameter sort seperti "title,-created_at" menjadi daftar SortField.
+// Kolom id selalu ditambahkan di akhir sebagai penentu urutan yang unik.
+func parseBookSort(sort string) ([]repository.SortField, error) {
+	var fields []repository.SortField
+	hasID := false
+	for _, part := range strings.Split(sort, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		desc := strings.HasPrefix(part, "-")
+		column, ok := bookSortColumns[strings.TrimPrefix(part, "-")]
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot sort by %q", ErrInvalidBookQuery, part)
+		}
+		hasID = hasID || column == "id"
+		fields = append(fields, repository.SortField{Column: column, Desc: desc})
+	}
+	if !hasID {
+		fields = append(fields, repository.SortField{Column: "id"})
+	}
+	return fields, nil
+}
+
+// parseBookDate membaca tanggal dalam format YYYY-MM-DD atau RFC 3339. Jika endOfDay bernilai true,
+// tanggal tanpa jam dianggap sampai akhir hari tersebut agar filter created_to inklusif.
+func parseBookDate(value string, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid date %q", ErrInvalidBookQuery, value)
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return &t, nil
+}
+
+// encodeBookCursor membuat cursor dari data terakhir pada halaman
+func encodeBookCursor(sort string, fields []repository.SortField, last entity.Book) string {
+	cursor := bookCursor{Sort: sort}
+	for _, field := range fields {
+		var value interface{}
+		switch field.Column {
+		case "id":
+			value = last.ID
+		case "title":
+			value = last.Title
+		case "created_at":
+			value = last.CreatedAt
+		case "updated_at":
+			value = last.UpdatedAt
+		}
+		raw, _ := json.Marshal(value)
+		cursor.Values = append(cursor.Values, raw)
+	}
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeBookCursor membaca cursor dan mengubah nilainya ke tipe kolom yang sesuai
+func decodeBookCursor(value string, sort string, fields []repository.SortField) ([]interface{}, error) {
+	invalid := fmt.Errorf("%w: invalid cursor", ErrInvalidBookQuery)
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, invalid
+	}
+	var cursor bookCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || len(cursor.Values) != len(fields) {
+		return nil, invalid
+	}
+	if cursor.Sort != sort { // Cursor dibuat untuk urutan lain
+		return nil, fmt.Errorf("%w: cursor does not match sort", ErrInvalidBookQuery)
+	}
+	values := make([]interface{}, len(fields))
+	for i, field := range fields {
+		var err error
+		switch field.Column {
+		case "id":
+			var id uint64
+			err = json.Unmarshal(cursor.Values[i], &id)
+			values[i] = id
+		case "title":
+			var title string
+			err = json.Unmarshal(cursor.Values[i], &title)
+			values[i] = title
+		default:
+			var t time.Time
+			err = json.Unmarshal(cursor.Values[i], &t)
+			values[i] = t
+		}
+		if err != nil {
+			return nil, invalid
+		}
+	}
+	return values, nil
+}
+
+// buildBookQuery menyusun query repository dari parameter client
+func buildBookQuery(q dto.BookQueryDTO) (repository.BookQuery, []repository.SortField, error) {
+	sort, err := parseBookSort(q.Sort)
+	if err != nil {
+		return repository.BookQuery{}, nil, err
+	}
+	from, err := parseBookDate(q.CreatedFrom, false)
+	if err != nil {
+		return repository.BookQuery{}, nil, err
+	}
+	to, err := parseBookDate(q.CreatedTo, true)
+	if err != nil {
+		return repository.BookQuery{}, nil, err
+	}
+	query := repository.BookQuery{
+		TitleContains: q.Title,
+		OwnerID:       q.OwnerID,
+		CreatedFrom:   from,
+		CreatedTo:     to,
+		Sort:          sort,
+	}
+	if q.Cursor != "" {
+		query.After, err = decodeBookCursor(q.Cursor, q.Sort, sort)
+	}
+	return query, sort, err
+}
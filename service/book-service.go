@@ -1,68 +1,186 @@
 package service
 
 import (
-	"fmt"
-	"log"
+	"context"
 
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror"
 	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/dto"
 	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/helper"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/logging"
 	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/repository"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/search"
 	"github.com/mashingan/smapping"
 )
 
+// ErrNotBookOwner dikembalikan ketika user mencoba mengubah buku milik user lain
+var ErrNotBookOwner = apperror.Forbidden("you are not the owner").WithCode("not_book_owner")
+
 type BookService interface {
-	Insert(b dto.BookCreateDTO) entity.Book
-	Update(b dto.BookUpdateDTO) entity.Book
-	Delete(b entity.Book)
-	All() []entity.Book
-	FIndById(bookID uint64) entity.Book
-	IsAllowedToEdit(userID string, bookID uint64) bool
+	Insert(ctx context.Context, b dto.BookCreateDTO) (entity.Book, error)
+	Update(ctx context.Context, b dto.BookUpdateDTO) (entity.Book, error)
+	Delete(ctx context.Context, b entity.Book) error
+	Page(ctx context.Context, q dto.BookQueryDTO) ([]entity.Book, helper.PageMeta, error)
+	Search(ctx context.Context, q dto.BookSearchDTO) ([]dto.BookSearchResultDTO, helper.PageMeta, error)
+	FindByID(ctx context.Context, bookID uint64) (entity.Book, error)
+	AuthorizeEdit(ctx context.Context, userID uint64, role string, bookID uint64) (entity.Book, error)
 }
 
 type bookService struct {
 	bookRepository repository.BookRepository
+	bookIndex      search.BookIndex
 }
 
-func NewBookService(bookRepo repository.BookRepository) BookService {
+func NewBookService(bookRepo repository.BookRepository, bookIndex search.BookIndex) BookService {
 	return &bookService{
 		bookRepository: bookRepo,
+		bookIndex:      bookIndex,
 	}
 }
 
-func (service *bookService) Insert(b dto.BookCreateDTO) entity.Book {
+func (service *bookService) Insert(ctx context.Context, b dto.BookCreateDTO) (entity.Book, error) {
 	book := entity.Book{}
 	err := smapping.FillStruct(&book, smapping.MapFields(&b))
 	if err != nil {
-		log.Fatalf("Failed map %v:", err)
+		return entity.Book{}, apperror.Internal("failed to map book", err)
+	}
+	res, err := service.bookRepository.InsertBook(ctx, book)
+	if err != nil {
+		return entity.Book{}, err
 	}
-	res := service.bookRepository.InsertBook(book)
-	return res
+	if err := service.bookIndex.Index(ctx, res); err != nil {
+		logging.FromContext(ctx).Warn("failed to index book", "book_id", res.ID, "error", err)
+	}
+	return res, nil
 }
 
-func (service *bookService) Update(b dto.BookUpdateDTO) entity.Book {
+func (service *bookService) Update(ctx context.Context, b dto.BookUpdateDTO) (entity.Book, error) {
 	book := entity.Book{}
 	err := smapping.FillStruct(&book, smapping.MapFields(&b))
 	if err != nil {
-		log.Fatalf("Failed map %v:", err)
+		return entity.Book{}, apperror.Internal("failed to map book", err)
+	}
+	res, err := service.bookRepository.UpdateBook(ctx, book)
+	if err != nil {
+		return entity.Book{}, err
+	}
+	if err := service.bookIndex.Index(ctx, res); err != nil {
+		logging.FromContext(ctx).Warn("failed to index book", "book_id", res.ID, "error", err)
 	}
-	res := service.bookRepository.UpdateBook(book)
-	return res
+	return res, nil
 }
 
-func (service *bookService) Delete(b entity.Book) {
-	service.bookRepository.DeleteBook(b)
+func (service *bookService) Delete(ctx context.Context, b entity.Book) error {
+	if err := service.bookRepository.DeleteBook(ctx, b); err != nil {
+		return err
+	}
+	if err := service.bookIndex.Delete(ctx, b.ID); err != nil {
+		logging.FromContext(ctx).Warn("failed to remove book from index", "book_id", b.ID, "error", err)
+	}
+	return nil
 }
 
-func (service *bookService) All() []entity.Book {
-	return service.bookRepository.AllBook()
+func (service *bookService) Page(ctx context.Context, q dto.BookQueryDTO) ([]entity.Book, helper.PageMeta, error) {
+	query, sort, err := buildBookQuery(q)
+	if err != nil {
+		return nil, helper.PageMeta{}, err
+	}
+	perPage := q.PerPage
+	if perPage <= 0 || perPage > maxBooksPerPage {
+		perPage = defaultBooksPerPage
+	}
+	meta := helper.PageMeta{PerPage: perPage}
+	query.Limit = perPage + 1 // Satu data tambahan untuk mengetahui apakah masih ada halaman berikutnya
+	if q.Cursor == "" {
+		meta.Page = q.Page
+		if meta.Page <= 0 {
+			meta.Page = 1
+		}
+		query.Offset = (meta.Page - 1) * perPage
+		query.CountTotal = true
+	}
+
+	books, total, err := service.bookRepository.PageBook(ctx, query)
+	if err != nil {
+		return nil, helper.PageMeta{}, err
+	}
+	if len(books) > perPage {
+		books = books[:perPage]
+		meta.HasMore = true
+		meta.NextCursor = encodeBookCursor(q.Sort, sort, books[len(books)-1])
+	}
+	if query.CountTotal {
+		totalPages := int((total + int64(perPage) - 1) / int64(perPage))
+		meta.Total = &total
+		meta.TotalPages = &totalPages
+	}
+	if books == nil {
+		books = []entity.Book{} // Mengembalikan array kosong, bukan null
+	}
+	return books, meta, nil
 }
 
-func (service *bookService) FIndById(bookID uint64) entity.Book {
-	return service.bookRepository.FindBookID(bookID)
+func (service *bookService) Search(ctx context.Context, q dto.BookSearchDTO) ([]dto.BookSearchResultDTO, helper.PageMeta, error) {
+	perPage := q.PerPage
+	if perPage <= 0 || perPage > maxBooksPerPage {
+		perPage = defaultBooksPerPage
+	}
+	page := q.Page
+	if page <= 0 {
+		page = 1
+	}
+	hits, total, err := service.bookIndex.Search(ctx, q.Q, perPage, (page-1)*perPage)
+	if err != nil {
+		return nil, helper.PageMeta{}, err
+	}
+
+	ids := make([]uint64, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.BookID)
+	}
+	found, err := service.bookRepository.FindBooksByIDs(ctx, ids)
+	if err != nil {
+		return nil, helper.PageMeta{}, err
+	}
+	books := make(map[uint64]entity.Book, len(found))
+	for _, b := range found {
+		books[b.ID] = b
+	}
+	results := make([]dto.BookSearchResultDTO, 0, len(hits))
+	for _, hit := range hits { // Urutan hasil mengikuti skor relevansi dari index
+		b, ok := books[hit.BookID]
+		if !ok { // Buku sudah dihapus tetapi index belum diperbarui
+			continue
+		}
+		results = append(results, dto.BookSearchResultDTO{Book: dto.NewBookResponse(b), Score: hit.Score, Highlights: hit.Highlights})
+	}
+
+	totalPages := int((total + int64(perPage) - 1) / int64(perPage))
+	meta := helper.PageMeta{
+		Page:       page,
+		PerPage:    perPage,
+		Total:      &total,
+		TotalPages: &totalPages,
+		HasMore:    int64(page*perPage) < total,
+	}
+	return results, meta, nil
+}
+
+func (service *bookService) FindByID(ctx context.Context, bookID uint64) (entity.Book, error) {
+	return service.bookRepository.FindBookID(ctx, bookID)
 }
 
-func (service *bookService) IsAllowedToEdit(userID string, bookID uint64) bool {
-	b := service.bookRepository.FindBookID(bookID)
-	id := fmt.Sprintf("%v", b.UserID)
-	return userID == id
+// AuthorizeEdit memastikan buku ada dan user boleh mengubahnya, lalu mengembalikan buku tersebut
+func (service *bookService) AuthorizeEdit(ctx context.Context, userID uint64, role string, bookID uint64) (entity.Book, error) {
+	b, err := service.bookRepository.FindBookID(ctx, bookID)
+	if err != nil {
+		return entity.Book{}, err
+	}
+	if role == entity.RoleEditor || role == entity.RoleAdmin { // Editor dan admin boleh mengubah buku milik siapa pun
+		return b, nil
+	}
+	if b.UserID != userID {
+		return entity.Book{}, ErrNotBookOwner
+	}
+	return b, nil
 }
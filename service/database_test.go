@@ -0,0 +1,34 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/migration"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDB membuka database SQLite di memori yang sudah dimigrasi, database ditutup saat test selesai
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{TranslateError: true, Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("database handle: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1) // Setiap koneksi ke :memory: adalah database terpisah
+	t.Cleanup(func() { sqlDB.Close() })
+	migrator, err := migration.New(db)
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return db
+}
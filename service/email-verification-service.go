@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context" // Mengimport package context untuk pembatalan dan tenggat waktu request
+	"errors"  // Mengimport package errors untuk membandingkan error
+	"fmt"     // Mengimport package fmt untuk menyusun isi email
+	"strconv" // Mengimport package strconv untuk membaca isi token
+	"strings" // Mengimport package strings untuk memecah token
+	"time"    // Mengimport package time untuk masa berlaku token
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror"   // Mengimport package apperror untuk error domain
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity"     // Mengimport package entity untuk model entitas
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/mail"       // Mengimport package mail untuk mengirim email
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/repository" // Mengimport package repository untuk interaksi dengan database
+)
+
+var (
+	// ErrInvalidVerificationToken dikembalikan ketika token verifikasi email rusak, kedaluwarsa, atau dibuat untuk email lain
+	ErrInvalidVerificationToken = apperror.Invalid("invalid or expired email verification token").WithCode("verification_token_invalid")
+	// ErrEmailNotVerified dikembalikan ketika user yang belum memverifikasi email melakukan aksi yang dibatasi
+	ErrEmailNotVerified = apperror.Forbidden("email address is not verified").WithCode("email_not_verified")
+	// ErrEmailAlreadyVerified dikembalikan ketika user meminta email verifikasi padahal email-nya sudah terverifikasi
+	ErrEmailAlreadyVerified = apperror.Conflict("email address is already verified").WithCode("email_already_verified")
+)
+
+// EmailVerificationService adalah interface yang mendefinisikan fungsi yang harus diimplementasikan oleh service verifikasi email
+type EmailVerificationService interface {
+	SendVerification(ctx context.Context, user entity.User) error  // Fungsi untuk mengirim link verifikasi ke email user
+	Verify(ctx context.Context, token string) (entity.User, error) // Fungsi untuk memverifikasi email dengan token dari link
+	Resend(ctx context.Context, userID uint64) error               // Fungsi untuk mengirim ulang link verifikasi kepada user yang belum terverifikasi
+	IsVerified(ctx context.Context, userID uint64) (bool, error)   // Fungsi untuk memeriksa apakah email user sudah terverifikasi
+	MarkVerified(ctx context.Context, userID uint64) error         // Fungsi untuk menandai email user terverifikasi tanpa token, misalnya user yang dibuat lewat CLI
+}
+
+// emailVerificationService adalah implementasi dari EmailVerificationService.
+// Token tidak disimpan di database, melainkan ditandatangani dengan HMAC dan berisi user ID serta waktu kedaluwarsa.
+// Email user ikut ditandatangani sehingga token otomatis tidak berlaku jika user mengganti email.
+type emailVerificationService struct {
+	userRepository repository.UserRepository // Menggunakan repository untuk interaksi dengan database user
+	mailer         mail.Mailer               // Pengirim email
+	signer         tokenSigner               // Penanda tangan token verifikasi
+	ttl            time.Duration             // Masa berlaku token verifikasi
+	verifyURL      string                    // URL yang menerima token sebagai parameter "token"
+}
+
+// NewEmailVerificationService adalah constructor untuk emailVerificationService, secret dipakai untuk menandatangani token
+func NewEmailVerificationService(userRepo repository.UserRepository, mailer mail.Mailer, secret string, ttl time.Duration, verifyURL string) EmailVerificationService {
+	return &emailVerificationService{
+		userRepository: userRepo,
+		mailer:         mailer,
+		signer:         newTokenSigner(secret, "email-verification"),
+		ttl:            ttl,
+		verifyURL:      verifyURL,
+	}
+}
+
+// SendVerification adalah implementasi fungsi SendVerification dari EmailVerificationService
+func (service *emailVerificationService) SendVerification(ctx context.Context, user entity.User) error {
+	expires := time.Now().Add(service.ttl)
+	token := service.signer.sign(strings.ToLower(user.Email), strconv.FormatUint(user.ID, 10), strconv.FormatInt(expires.Unix(), 10))
+	sendInBackground(ctx, service.mailer, mail.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nPlease confirm that this is your email address by opening this link:\n\n%s\n\nThe link expires in %s.\n",
+			user.Name, withToken(service.verifyURL, token), service.ttl),
+	})
+	return nil
+}
+
+// Verify adalah implementasi fungsi Verify dari EmailVerificationService.
+// Memakai link yang sama lebih dari sekali tidak menghasilkan error.
+func (service *emailVerificationService) Verify(ctx context.Context, token string) (entity.User, error) {
+	parts, ok := service.signer.fields(token, 2) // Format token: <user ID>.<waktu kedaluwarsa>.<tanda tangan>
+	if !ok {
+		return entity.User{}, ErrInvalidVerificationToken
+	}
+	userID, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return entity.User{}, ErrInvalidVerificationToken
+	}
+	expires, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return entity.User{}, ErrInvalidVerificationToken
+	}
+	user, err := service.userRepository.FindByID(ctx, userID)
+	if errors.Is(err, apperror.ErrNotFound) {
+		return entity.User{}, ErrInvalidVerificationToken
+	}
+	if err != nil {
+		return entity.User{}, err
+	}
+	if !service.signer.valid(token, strings.ToLower(user.Email)) { // Tanda tangan dihitung ulang dengan email user saat ini
+		return entity.User{}, ErrInvalidVerificationToken
+	}
+	if user.EmailVerifiedAt != nil {
+		return user, nil
+	}
+	now := time.Now()
+	if err := service.userRepository.SetEmailVerified(ctx, user.ID, &now); err != nil {
+		return entity.User{}, err
+	}
+	user.EmailVerifiedAt = &now
+	return user, nil
+}
+
+// Resend adalah implementasi fungsi Resend dari EmailVerificationService
+func (service *emailVerificationService) Resend(ctx context.Context, userID uint64) error {
+	user, err := service.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerifiedAt != nil {
+		return ErrEmailAlreadyVerified
+	}
+	return service.SendVerification(ctx, user)
+}
+
+// IsVerified adalah implementasi fungsi IsVerified dari EmailVerificationService
+func (service *emailVerificationService) IsVerified(ctx context.Context, userID uint64) (bool, error) {
+	user, err := service.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return user.EmailVerifiedAt != nil, nil
+}
+
+// MarkVerified adalah implementasi fungsi MarkVerified dari EmailVerificationService
+func (service *emailVerificationService) MarkVerified(ctx context.Context, userID uint64) error {
+	now := time.Now()
+	return service.userRepository.SetEmailVerified(ctx, userID, &now)
+}
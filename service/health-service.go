@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"     // Mengimport package context untuk batas waktu pemeriksaan
+	"sync"        // Mengimport package sync untuk menjalankan pemeriksaan secara paralel
+	"sync/atomic" // Mengimport package atomic untuk status shutdown
+	"time"        // Mengimport package time untuk batas waktu pemeriksaan
+)
+
+// healthCheckTimeout adalah batas waktu satu pemeriksaan dependency
+const healthCheckTimeout = 2 * time.Second
+
+// HealthCheck memeriksa satu dependency, misalnya ping ke database
+type HealthCheck func(ctx context.Context) error
+
+// HealthService adalah interface yang mendefinisikan fungsi yang harus diimplementasikan oleh service health check
+type HealthService interface {
+	Ready(ctx context.Context) (map[string]string, bool) // Fungsi untuk memeriksa semua dependency, mengembalikan hasil per dependency dan apakah semuanya siap
+	Drain()                                              // Fungsi untuk menandai server sedang berhenti sehingga tidak lagi menerima traffic baru
+}
+
+// healthService adalah implementasi dari HealthService
+type healthService struct {
+	checks   map[string]HealthCheck // Pemeriksaan dependency berdasarkan nama
+	draining atomic.Bool            // Bernilai true setelah Drain dipanggil
+}
+
+// NewHealthService adalah constructor untuk healthService
+func NewHealthService(checks map[string]HealthCheck) HealthService {
+	return &healthService{checks: checks}
+}
+
+// Ready adalah implementasi fungsi Ready dari HealthService. Semua pemeriksaan dijalankan
+// secara paralel dengan batas waktu masing-masing agar satu dependency yang lambat tidak
+// membuat probe orchestrator timeout.
+func (service *healthService) Ready(ctx context.Context) (map[string]string, bool) {
+	if service.draining.Load() {
+		return map[string]string{"server": "shutting down"}, false
+	}
+	results := make(map[string]string, len(service.checks))
+	ready := true
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range service.checks {
+		wg.Add(1)
+		go func(name string, check HealthCheck) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			defer cancel()
+			err := check(checkCtx)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				results[name] = err.Error()
+				ready = false
+				return
+			}
+			results[name] = "ok"
+		}(name, check)
+	}
+	wg.Wait()
+	return results, ready
+}
+
+// Drain adalah implementasi fungsi Drain dari HealthService
+func (service *healthService) Drain() {
+	service.draining.Store(true)
+}
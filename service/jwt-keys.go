@@ -0,0 +1,105 @@
+package service
+
+import (
+	"crypto"          // Mengimport package crypto untuk tipe public key umum
+	"crypto/ed25519"  // Mengimport package ed25519 untuk kunci EdDSA
+	"crypto/rsa"      // Mengimport package rsa untuk kunci RS256
+	"crypto/sha256"   // Mengimport package sha256 untuk menghitung thumbprint kunci
+	"encoding/base64" // Mengimport package base64 untuk encoding parameter JWK
+	"errors"          // Mengimport package errors untuk membuat error
+	"fmt"             // Mengimport package fmt untuk formatting
+	"math/big"        // Mengimport package big untuk encoding eksponen RSA
+
+	"github.com/golang-jwt/jwt/v4" // Mengimport package golang-jwt untuk parsing kunci PEM
+)
+
+// ErrUnsupportedJWTKey dikembalikan ketika file PEM tidak berisi kunci RSA atau Ed25519
+var ErrUnsupportedJWTKey = errors.New("unsupported JWT key, expected RSA or Ed25519 PEM")
+
+// JWTKey adalah kunci yang dipakai untuk menandatangani dan/atau memverifikasi JWT
+type JWTKey struct {
+	ID        string            // ID adalah kid kunci (thumbprint RFC 7638), kosong untuk kunci HMAC
+	Method    jwt.SigningMethod // Method adalah algoritma signing yang dipakai kunci ini
+	SignKey   interface{}       // SignKey adalah kunci privat, nil jika kunci hanya dipakai untuk verifikasi
+	VerifyKey interface{}       // VerifyKey adalah kunci publik (atau secret untuk HMAC)
+}
+
+// JSONWebKey adalah representasi kunci publik dalam format JWK (RFC 7517)
+type JSONWebKey struct {
+	Kty string `json:"kty"`           // Kty adalah tipe kunci (RSA atau OKP)
+	Kid string `json:"kid"`           // Kid adalah ID kunci yang sama dengan header kid pada token
+	Use string `json:"use"`           // Use adalah kegunaan kunci, selalu "sig"
+	Alg string `json:"alg"`           // Alg adalah algoritma signing kunci
+	N   string `json:"n,omitempty"`   // N adalah modulus kunci RSA
+	E   string `json:"e,omitempty"`   // E adalah eksponen kunci RSA
+	Crv string `json:"crv,omitempty"` // Crv adalah kurva kunci OKP
+	X   string `json:"x,omitempty"`   // X adalah kunci publik Ed25519
+}
+
+// JSONWebKeySet adalah kumpulan kunci publik yang dipublikasikan di endpoint JWKS
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"` // Keys adalah daftar kunci verifikasi yang berlaku
+}
+
+// NewHMACKey membuat kunci HS256 dari shared secret
+func NewHMACKey(secret string) JWTKey {
+	return JWTKey{
+		Method:    jwt.SigningMethodHS256,
+		SignKey:   []byte(secret),
+		VerifyKey: []byte(secret),
+	}
+}
+
+// ParseJWTKeyPEM membaca kunci RSA atau Ed25519 dari PEM. Kunci privat menghasilkan kunci yang bisa
+// menandatangani, kunci publik hanya bisa dipakai untuk verifikasi (misalnya kunci lama saat rotasi).
+func ParseJWTKeyPEM(data []byte) (JWTKey, error) {
+	var key JWTKey
+	if priv, err := jwt.ParseRSAPrivateKeyFromPEM(data); err == nil {
+		key = JWTKey{Method: jwt.SigningMethodRS256, SignKey: priv, VerifyKey: &priv.PublicKey}
+	} else if priv, err := jwt.ParseEdPrivateKeyFromPEM(data); err == nil {
+		key = JWTKey{Method: jwt.SigningMethodEdDSA, SignKey: priv, VerifyKey: priv.(ed25519.PrivateKey).Public()}
+	} else if pub, err := jwt.ParseRSAPublicKeyFromPEM(data); err == nil {
+		key = JWTKey{Method: jwt.SigningMethodRS256, VerifyKey: pub}
+	} else if pub, err := jwt.ParseEdPublicKeyFromPEM(data); err == nil {
+		key = JWTKey{Method: jwt.SigningMethodEdDSA, VerifyKey: pub}
+	} else {
+		return JWTKey{}, ErrUnsupportedJWTKey
+	}
+	jwk, err := publicJWK(key.Method, key.VerifyKey)
+	if err != nil {
+		return JWTKey{}, err
+	}
+	key.ID = jwk.Kid
+	return key, nil
+}
+
+// JWK mengembalikan representasi publik dari kunci, false untuk kunci HMAC yang tidak boleh dipublikasikan
+func (k JWTKey) JWK() (JSONWebKey, bool) {
+	if k.ID == "" {
+		return JSONWebKey{}, false
+	}
+	jwk, err := publicJWK(k.Method, k.VerifyKey)
+	return jwk, err == nil
+}
+
+// publicJWK menyusun JWK dari kunci publik dan mengisi kid dengan thumbprint RFC 7638
+func publicJWK(method jwt.SigningMethod, pub crypto.PublicKey) (JSONWebKey, error) {
+	enc := base64.RawURLEncoding
+	var jwk JSONWebKey
+	var canonical string // Representasi JSON kanonik untuk thumbprint (urutan anggota leksikografis)
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		jwk = JSONWebKey{Kty: "RSA", N: enc.EncodeToString(k.N.Bytes()), E: enc.EncodeToString(big.NewInt(int64(k.E)).Bytes())}
+		canonical = fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`, jwk.E, jwk.N)
+	case ed25519.PublicKey:
+		jwk = JSONWebKey{Kty: "OKP", Crv: "Ed25519", X: enc.EncodeToString(k)}
+		canonical = fmt.Sprintf(`{"crv":"Ed25519","kty":"OKP","x":"%s"}`, jwk.X)
+	default:
+		return JSONWebKey{}, ErrUnsupportedJWTKey
+	}
+	sum := sha256.Sum256([]byte(canonical))
+	jwk.Kid = enc.EncodeToString(sum[:])
+	jwk.Use = "sig"
+	jwk.Alg = method.Alg()
+	return jwk, nil
+}
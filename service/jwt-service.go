@@ -1,72 +1,142 @@
 package service
 
 import (
-	"fmt"  // Mengimport package fmt untuk formatting dan printing
-	"os"   // Mengimport package os untuk mengakses environment variable
-	"time" // Mengimport package time untuk mengelola waktu
+	"fmt"     // Mengimport package fmt untuk formatting dan printing
+	"strings" // Mengimport package strings untuk menyusun claim scope
+	"time"    // Mengimport package time untuk mengelola waktu
 
-	"github.com/dgrijalva/jwt-go" // Mengimport package jwt-go untuk JWT (JSON Web Token)
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/helper" // Mengimport package helper untuk membuat jti acak
+	"github.com/golang-jwt/jwt/v4"                          // Mengimport package golang-jwt untuk JWT (JSON Web Token)
 )
 
 // JWTService adalah interface yang mendefinisikan fungsi yang harus diimplementasikan oleh service JWT
 type JWTService interface {
-	GenerateToken(userID string) string             // Fungsi untuk generate token JWT
-	ValidateToken(token string) (*jwt.Token, error) // Fungsi untuk validasi token JWT
+	GenerateToken(userID string, role string) (string, error)                                                 // Fungsi untuk generate token JWT tanpa sesi, misalnya untuk script
+	GenerateSessionToken(userID string, role string, sessionID string) (string, error)                        // Fungsi untuk generate token JWT milik sesi login, ditolak setelah sesi dicabut
+	GenerateDelegatedToken(userID string, role string, clientID string, scopes []string) (IssuedToken, error) // Fungsi untuk generate token JWT yang dipakai client OAuth2 atas nama user, dibatasi scope
+	ValidateToken(token string) (*jwt.Token, error)                                                           // Fungsi untuk validasi token JWT
+	TokenTTL() time.Duration                                                                                  // Fungsi untuk mendapatkan masa berlaku access token
+	JWKS() JSONWebKeySet                                                                                      // Fungsi untuk mendapatkan kunci publik verifikasi dalam format JWKS
 }
 
 // jwtCustomClaim adalah struct untuk menyimpan custom claim JWT
 type jwtCustomClaim struct {
-	UserID             string `json:"user_id"` // Field untuk user ID dalam claim JWT
-	jwt.StandardClaims        // Field untuk standard claims JWT
+	UserID             string `json:"user_id"`             // Field untuk user ID dalam claim JWT
+	Role               string `json:"role"`                // Field untuk role user dalam claim JWT
+	ClientID           string `json:"client_id,omitempty"` // Field untuk client OAuth2 pemegang token, kosong untuk token login user
+	Scope              string `json:"scope,omitempty"`     // Field untuk scope token client OAuth2 yang dipisahkan spasi
+	SessionID          string `json:"sid,omitempty"`       // Field untuk ID sesi login pemilik token, kosong untuk token tanpa sesi
+	IssuedAtMs         int64  `json:"iat_ms"`              // Field untuk waktu pembuatan token dalam milidetik, iat hanya berpresisi detik
+	jwt.StandardClaims        // Field untuk standard claims JWT (termasuk jti sebagai ID unik token)
+}
+
+// IssuedToken adalah access token yang baru diterbitkan beserta ID dan waktu kedaluwarsanya
+type IssuedToken struct {
+	Token     string    // Token adalah JWT yang dikirim ke client
+	ID        string    // ID adalah claim jti dari token
+	ExpiresAt time.Time // ExpiresAt adalah claim exp dari token
 }
 
 // jwtService adalah implementasi dari JWTService
 type jwtService struct {
-	secretKey string // Kunci rahasia untuk signing JWT
-	issuer    string // Issuer untuk JWT
+	signingKey       JWTKey            // Kunci untuk signing JWT
+	verificationKeys map[string]JWTKey // Kunci verifikasi berdasarkan kid, termasuk kunci lama selama masa rotasi
+	issuer           string            // Issuer untuk JWT
+	ttl              time.Duration     // Masa berlaku access token
 }
 
-// NewJWTService adalah constructor untuk jwtService
-func NewJWTService() JWTService {
+// NewJWTService adalah constructor untuk jwtService. ttl adalah masa berlaku access token.
+// Kunci publik dari signingKey selalu ikut dipakai untuk verifikasi, verificationKeys tambahan
+// dipakai untuk menerima token yang ditandatangani kunci lama selama masa rotasi.
+func NewJWTService(ttl time.Duration, signingKey JWTKey, verificationKeys ...JWTKey) JWTService {
+	keys := map[string]JWTKey{signingKey.ID: signingKey}
+	for _, key := range verificationKeys {
+		keys[key.ID] = key
+	}
 	return &jwtService{
-		issuer:    "ImmanuelPardede", // Set issuer JWT
-		secretKey: getSecretKey(),    // Set kunci rahasia JWT
+		signingKey:       signingKey,
+		verificationKeys: keys,
+		issuer:           "ImmanuelPardede", // Set issuer JWT
+		ttl:              ttl,               // Access token berumur pendek, diperpanjang lewat refresh token
 	}
 }
 
-// getSecretKey adalah fungsi untuk mendapatkan kunci rahasia JWT dari environment variable
-func getSecretKey() string {
-	secretKey := os.Getenv("JWT_SECRET") // Mendapatkan nilai dari environment variable JWT_SECRET
-	if secretKey == "" {                 // Jika nilai tidak ditemukan, gunakan default "ImmanuelPardede"
-		secretKey = "ImmanuelPardede"
-	}
-	return secretKey
+// GenerateToken adalah implementasi fungsi GenerateToken dari JWTService
+func (j *jwtService) GenerateToken(UserID string, role string) (string, error) {
+	return j.generate(&jwtCustomClaim{UserID: UserID, Role: role})
 }
 
-// GenerateToken adalah implementasi fungsi GenerateToken dari JWTService
-func (j *jwtService) GenerateToken(UserID string) string {
-	claims := &jwtCustomClaim{ // Membuat custom claim JWT
-		UserID,
-		jwt.StandardClaims{
-			ExpiresAt: time.Now().AddDate(1, 0, 0).Unix(), // Token akan kedaluwarsa dalam 1 tahun
-			Issuer:    j.issuer,                           // Mengatur issuer JWT
-			IssuedAt:  time.Now().Unix(),                  // Waktu pembuatan token
-		},
+// GenerateSessionToken adalah implementasi fungsi GenerateSessionToken dari JWTService
+func (j *jwtService) GenerateSessionToken(userID string, role string, sessionID string) (string, error) {
+	return j.generate(&jwtCustomClaim{UserID: userID, Role: role, SessionID: sessionID})
+}
+
+// GenerateDelegatedToken adalah implementasi fungsi GenerateDelegatedToken dari JWTService.
+// Claim client_id membedakan token ini dari token login user, lihat middleware AuthorizeJWT.
+// Berbeda dengan token lain, ID dan waktu kedaluwarsa token ikut dikembalikan agar token bisa dicabut tanpa disimpan utuh.
+func (j *jwtService) GenerateDelegatedToken(userID string, role string, clientID string, scopes []string) (IssuedToken, error) {
+	claims := jwtCustomClaim{UserID: userID, Role: role, ClientID: clientID, Scope: strings.Join(scopes, " ")}
+	token, err := j.generate(&claims)
+	if err != nil {
+		return IssuedToken{}, err
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims) // Membuat token JWT
-	t, err := token.SignedString([]byte(j.secretKey))          // Mengesahkan token dengan menggunakan kunci rahasia
+	return IssuedToken{Token: token, ID: claims.Id, ExpiresAt: time.Unix(claims.ExpiresAt, 0)}, nil
+}
+
+// generate melengkapi claims dengan standard claims lalu menandatangani token, pemanggil bisa membaca jti dan exp dari claims
+func (j *jwtService) generate(claims *jwtCustomClaim) (string, error) {
+	jti, err := helper.GenerateRandomToken(16) // Membuat ID unik token agar token bisa dicabut satu per satu
 	if err != nil {
-		panic(err) // Panic jika terjadi error dalam pembuatan token
+		return "", err
 	}
-	return t // Mengembalikan token JWT yang telah dibuat
+	now := time.Now()
+	claims.IssuedAtMs = now.UnixMilli() // Dibandingkan dengan batas pencabutan token user, lihat TokenRevocationService
+	claims.StandardClaims = jwt.StandardClaims{
+		Id:        jti,                   // ID unik token (claim jti)
+		ExpiresAt: now.Add(j.ttl).Unix(), // Token akan kedaluwarsa sesuai masa berlaku access token
+		Issuer:    j.issuer,              // Mengatur issuer JWT
+		IssuedAt:  now.Unix(),            // Waktu pembuatan token
+	}
+	token := jwt.NewWithClaims(j.signingKey.Method, claims) // Membuat token JWT
+	if j.signingKey.ID != "" {
+		token.Header["kid"] = j.signingKey.ID // Menandai kunci yang dipakai agar verifier bisa memilih kunci publik yang tepat
+	}
+	return token.SignedString(j.signingKey.SignKey) // Mengesahkan token dengan menggunakan kunci privat
+}
+
+// TokenTTL adalah implementasi fungsi TokenTTL dari JWTService
+func (j *jwtService) TokenTTL() time.Duration {
+	return j.ttl
 }
 
 // ValidateToken adalah implementasi fungsi ValidateToken dari JWTService
 func (j *jwtService) ValidateToken(token string) (*jwt.Token, error) {
 	return jwt.Parse(token, func(t_ *jwt.Token) (interface{}, error) {
-		if _, ok := t_.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("Unexpected signing method %v", t_.Header["alg"]) // Error jika metode signing tidak sesuai
+		kid, _ := t_.Header["kid"].(string)
+		key, ok := j.verificationKeys[kid] // Memilih kunci verifikasi berdasarkan kid
+		if !ok {
+			return nil, fmt.Errorf("Unknown signing key %v", t_.Header["kid"]) // Error jika kunci tidak dikenal atau sudah dirotasi keluar
+		}
+		if t_.Method.Alg() != key.Method.Alg() {
+			return nil, fmt.Errorf("Unexpected signing method %v", t_.Header["alg"]) // Error jika metode signing tidak sesuai dengan kunci
 		}
-		return []byte(j.secretKey), nil // Mengembalikan kunci rahasia untuk validasi token
+		return key.VerifyKey, nil // Mengembalikan kunci untuk validasi token
 	})
 }
+
+// JWKS adalah implementasi fungsi JWKS dari JWTService
+func (j *jwtService) JWKS() JSONWebKeySet {
+	set := JSONWebKeySet{Keys: []JSONWebKey{}}
+	if jwk, ok := j.signingKey.JWK(); ok { // Kunci signing aktif ditampilkan pertama
+		set.Keys = append(set.Keys, jwk)
+	}
+	for kid, key := range j.verificationKeys {
+		if kid == j.signingKey.ID {
+			continue
+		}
+		if jwk, ok := key.JWK(); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	return set
+}
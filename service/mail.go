@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context" // Mengimport package context untuk batas waktu pengiriman email
+	"net/url" // Mengimport package url untuk menyusun link di dalam email
+	"time"    // Mengimport package time untuk durasi
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/logging" // Mengimport package logging untuk mencatat email yang gagal dikirim
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/mail"    // Mengimport package mail untuk mengirim email
+)
+
+// mailSendTimeout adalah batas waktu mengirim satu email
+const mailSendTimeout = 30 * time.Second
+
+// sendInBackground mengirim email tanpa menunggu hasilnya, sehingga lama respons tidak bergantung pada server email
+// dan tidak membocorkan apakah email benar-benar dikirim. Email tetap terkirim walaupun request sudah selesai,
+// dan kegagalannya dicatat di log request tersebut.
+func sendInBackground(ctx context.Context, mailer mail.Mailer, msg mail.Message) {
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, mailSendTimeout)
+		defer cancel()
+		if err := mailer.Send(ctx, msg); err != nil {
+			logging.FromContext(ctx).Error("failed to send mail", "subject", msg.Subject, "error", err)
+		}
+	}()
+}
+
+// withToken menambahkan token sebagai parameter "token" pada URL
+func withToken(base string, token string) string {
+	u, err := url.Parse(base)
+	if err != nil { // URL sudah divalidasi saat konfigurasi dibaca
+		return base
+	}
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
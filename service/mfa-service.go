@@ -0,0 +1,330 @@
+package service
+
+import (
+	"bytes"           // Mengimport package bytes untuk menampung gambar QR code
+	"context"         // Mengimport package context untuk pembatalan dan tenggat waktu request
+	"crypto/rand"     // Mengimport package crypto/rand untuk membuat kode pemulihan
+	"crypto/subtle"   // Mengimport package subtle untuk membandingkan kode tanpa kebocoran waktu
+	"encoding/base64" // Mengimport package base64 untuk data URL QR code
+	"encoding/hex"    // Mengimport package hex untuk format kode pemulihan
+	"errors"          // Mengimport package errors untuk membandingkan error
+	"image/png"       // Mengimport package png untuk encoding QR code
+	"strconv"         // Mengimport package strconv untuk membaca isi token
+	"strings"         // Mengimport package strings untuk menormalkan kode
+	"time"            // Mengimport package time untuk time step TOTP dan masa berlaku token
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror"   // Mengimport package apperror untuk error domain
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/dto"        // Mengimport package dto untuk DTO (Data Transfer Object)
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity"     // Mengimport package entity untuk model entitas
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/helper"     // Mengimport package helper untuk hashing kode pemulihan
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/repository" // Mengimport package repository untuk interaksi dengan database
+	"github.com/pquerna/otp"                                    // Mengimport package otp untuk parameter TOTP
+	"github.com/pquerna/otp/totp"                               // Mengimport package totp untuk membuat dan memeriksa kode TOTP (RFC 6238)
+)
+
+// Parameter TOTP yang didukung oleh semua aplikasi authenticator umum
+const (
+	totpPeriod            = 30  // Lama satu time step dalam detik
+	totpSkew              = 1   // Jumlah time step sebelum dan sesudah saat ini yang masih diterima
+	recoveryCodeCount     = 10  // Jumlah kode pemulihan yang dibuat saat 2FA dikonfirmasi
+	recoveryCodeBytes     = 8   // Panjang kode pemulihan dalam byte, ditampilkan sebagai 16 karakter hex
+	recoveryCodeGroupSize = 4   // Jumlah karakter hex di antara tanda hubung pada kode pemulihan
+	totpSecretSize        = 20  // Panjang secret TOTP dalam byte, sesuai panjang output HMAC-SHA1
+	totpQRCodeSize        = 200 // Lebar dan tinggi gambar QR code dalam piksel
+)
+
+// totpOpts adalah parameter pembuatan kode TOTP yang sama dengan parameter saat enrollment
+var totpOpts = totp.ValidateOpts{Period: totpPeriod, Digits: otp.DigitsSix, Algorithm: otp.AlgorithmSHA1}
+
+var (
+	// ErrMFAAlreadyEnabled dikembalikan ketika user memulai atau mengkonfirmasi enrollment padahal 2FA sudah aktif
+	ErrMFAAlreadyEnabled = apperror.Conflict("two-factor authentication is already enabled").WithCode("mfa_already_enabled")
+	// ErrMFANotEnrolled dikembalikan ketika user mengkonfirmasi 2FA sebelum memulai enrollment
+	ErrMFANotEnrolled = apperror.Invalid("two-factor enrollment has not been started").WithCode("mfa_not_enrolled")
+	// ErrMFANotEnabled dikembalikan ketika user mematikan 2FA yang tidak aktif
+	ErrMFANotEnabled = apperror.Invalid("two-factor authentication is not enabled").WithCode("mfa_not_enabled")
+	// ErrInvalidMFACode dikembalikan ketika kode TOTP atau kode pemulihan salah atau sudah dipakai
+	ErrInvalidMFACode = apperror.Unauthorized("invalid two-factor code").WithCode("mfa_code_invalid")
+	// ErrInvalidMFAToken dikembalikan ketika token login 2FA rusak, kedaluwarsa, atau sudah tidak berlaku
+	ErrInvalidMFAToken = apperror.Unauthorized("invalid or expired two-factor login token").WithCode("mfa_token_invalid")
+	// ErrMFARequired dikembalikan ketika user dengan role yang wajib memakai 2FA belum mengaktifkannya
+	ErrMFARequired = apperror.Forbidden("two-factor authentication must be enabled for this account").WithCode("mfa_enrollment_required")
+)
+
+// MFAService adalah interface yang mendefinisikan fungsi yang harus diimplementasikan oleh service 2FA
+type MFAService interface {
+	Status(ctx context.Context, userID uint64) (dto.MFAStatusDTO, error)               // Fungsi untuk mendapatkan status 2FA user
+	Enroll(ctx context.Context, userID uint64) (dto.MFAEnrollmentDTO, error)           // Fungsi untuk memulai enrollment dan membuat secret TOTP baru
+	Confirm(ctx context.Context, userID uint64, code string) ([]string, error)         // Fungsi untuk mengaktifkan 2FA dengan kode pertama, mengembalikan kode pemulihan
+	Disable(ctx context.Context, userID uint64, code string) error                     // Fungsi untuk mematikan 2FA dengan kode TOTP atau kode pemulihan
+	Reset(ctx context.Context, userID uint64) error                                    // Fungsi untuk mematikan 2FA tanpa kode (khusus admin)
+	IsEnabled(ctx context.Context, userID uint64) (bool, error)                        // Fungsi untuk memeriksa apakah 2FA user aktif
+	IssueChallenge(user entity.User) dto.MFAChallengeDTO                               // Fungsi untuk membuat token login 2FA setelah password benar
+	CompleteLogin(ctx context.Context, token string, code string) (entity.User, error) // Fungsi untuk menyelesaikan login dengan token login 2FA dan kode
+}
+
+// mfaService adalah implementasi dari MFAService.
+// Token login 2FA tidak disimpan di database, melainkan ditandatangani dengan HMAC dan berisi user ID serta waktu kedaluwarsa.
+// Hash password dan secret TOTP user ikut ditandatangani sehingga token otomatis tidak berlaku jika salah satunya berubah.
+type mfaService struct {
+	userRepository repository.UserRepository // Menggunakan repository untuk interaksi dengan database user
+	mfaRepository  repository.MFARepository  // Menggunakan repository untuk interaksi dengan data 2FA
+	lockout        LockoutPolicy             // Kebijakan penguncian akun, kode 2FA yang salah dihitung sebagai login gagal
+	signer         tokenSigner               // Penanda tangan token login 2FA
+	issuer         string                    // Nama aplikasi yang ditampilkan di aplikasi authenticator
+	pendingTTL     time.Duration             // Masa berlaku token login 2FA
+}
+
+// NewMFAService adalah constructor untuk mfaService, secret dipakai untuk menandatangani token login 2FA
+func NewMFAService(userRepo repository.UserRepository, mfaRepo repository.MFARepository, lockout LockoutPolicy, secret string, issuer string, pendingTTL time.Duration) MFAService {
+	return &mfaService{
+		userRepository: userRepo,
+		mfaRepository:  mfaRepo,
+		lockout:        lockout,
+		signer:         newTokenSigner(secret, "mfa-login"),
+		issuer:         issuer,
+		pendingTTL:     pendingTTL,
+	}
+}
+
+// Status adalah implementasi fungsi Status dari MFAService
+func (service *mfaService) Status(ctx context.Context, userID uint64) (dto.MFAStatusDTO, error) {
+	user, err := service.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return dto.MFAStatusDTO{}, err
+	}
+	status := dto.MFAStatusDTO{Enabled: user.TOTPEnabled, Pending: !user.TOTPEnabled && user.TOTPSecret != ""}
+	if user.TOTPEnabled {
+		status.RecoveryCodesRemaining, err = service.mfaRepository.CountRecoveryCodes(ctx, userID)
+	}
+	return status, err
+}
+
+// Enroll adalah implementasi fungsi Enroll dari MFAService.
+// Memanggil Enroll lagi sebelum konfirmasi mengganti secret sebelumnya.
+func (service *mfaService) Enroll(ctx context.Context, userID uint64) (dto.MFAEnrollmentDTO, error) {
+	user, err := service.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return dto.MFAEnrollmentDTO{}, err
+	}
+	if user.TOTPEnabled {
+		return dto.MFAEnrollmentDTO{}, ErrMFAAlreadyEnabled
+	}
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      service.issuer,
+		AccountName: user.Email,
+		Period:      totpPeriod,
+		SecretSize:  totpSecretSize,
+		Digits:      totpOpts.Digits,
+		Algorithm:   totpOpts.Algorithm,
+	})
+	if err != nil {
+		return dto.MFAEnrollmentDTO{}, apperror.Internal("failed to generate totp secret", err)
+	}
+	img, err := key.Image(totpQRCodeSize, totpQRCodeSize)
+	if err != nil {
+		return dto.MFAEnrollmentDTO{}, apperror.Internal("failed to render qr code", err)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return dto.MFAEnrollmentDTO{}, apperror.Internal("failed to encode qr code", err)
+	}
+	err = service.mfaRepository.SetPendingSecret(ctx, userID, key.Secret())
+	if errors.Is(err, repository.ErrTOTPAlreadyEnabled) { // Request lain mengaktifkan 2FA di antara pemeriksaan dan penyimpanan
+		return dto.MFAEnrollmentDTO{}, ErrMFAAlreadyEnabled
+	}
+	if err != nil {
+		return dto.MFAEnrollmentDTO{}, err
+	}
+	return dto.MFAEnrollmentDTO{
+		Secret: key.Secret(),
+		URI:    key.URL(),
+		QRCode: "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()),
+	}, nil
+}
+
+// Confirm adalah implementasi fungsi Confirm dari MFAService.
+// Kode pemulihan hanya dikembalikan di sini, database hanya menyimpan hash-nya.
+func (service *mfaService) Confirm(ctx context.Context, userID uint64, code string) ([]string, error) {
+	user, err := service.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, ErrMFANotEnrolled
+	}
+	step, ok := matchTOTP(user.TOTPSecret, code, time.Now())
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	err = service.mfaRepository.Enable(ctx, userID, step, hashes)
+	if errors.Is(err, repository.ErrTOTPAlreadyEnabled) {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// Disable adalah implementasi fungsi Disable dari MFAService
+func (service *mfaService) Disable(ctx context.Context, userID uint64, code string) error {
+	user, err := service.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.TOTPEnabled {
+		return ErrMFANotEnabled
+	}
+	if err := service.useCode(ctx, user, code); err != nil {
+		return err
+	}
+	return service.mfaRepository.Disable(ctx, userID)
+}
+
+// Reset adalah implementasi fungsi Reset dari MFAService, dipakai admin ketika user kehilangan authenticator dan kode pemulihan
+func (service *mfaService) Reset(ctx context.Context, userID uint64) error {
+	if _, err := service.userRepository.FindByID(ctx, userID); err != nil {
+		return err
+	}
+	return service.mfaRepository.Disable(ctx, userID)
+}
+
+// IsEnabled adalah implementasi fungsi IsEnabled dari MFAService
+func (service *mfaService) IsEnabled(ctx context.Context, userID uint64) (bool, error) {
+	user, err := service.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return user.TOTPEnabled, nil
+}
+
+// IssueChallenge adalah implementasi fungsi IssueChallenge dari MFAService
+func (service *mfaService) IssueChallenge(user entity.User) dto.MFAChallengeDTO {
+	expires := time.Now().Add(service.pendingTTL)
+	token := service.signer.sign(challengeBinding(user), strconv.FormatUint(user.ID, 10), strconv.FormatInt(expires.Unix(), 10))
+	return dto.MFAChallengeDTO{MFARequired: true, MFAToken: token, ExpiresIn: int64(service.pendingTTL.Seconds())}
+}
+
+// CompleteLogin adalah implementasi fungsi CompleteLogin dari MFAService.
+// Kode yang salah dihitung sebagai login gagal, sehingga tebakan kode dibatasi oleh kebijakan penguncian akun yang sama dengan password.
+func (service *mfaService) CompleteLogin(ctx context.Context, token string, code string) (entity.User, error) {
+	parts, ok := service.signer.fields(token, 2) // Format token: <user ID>.<waktu kedaluwarsa>.<tanda tangan>
+	if !ok {
+		return entity.User{}, ErrInvalidMFAToken
+	}
+	userID, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return entity.User{}, ErrInvalidMFAToken
+	}
+	expires, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return entity.User{}, ErrInvalidMFAToken
+	}
+	user, err := service.userRepository.FindByID(ctx, userID)
+	if errors.Is(err, apperror.ErrNotFound) {
+		return entity.User{}, ErrInvalidMFAToken
+	}
+	if err != nil {
+		return entity.User{}, err
+	}
+	if !user.TOTPEnabled || !service.signer.valid(token, challengeBinding(user)) {
+		return entity.User{}, ErrInvalidMFAToken
+	}
+	now := time.Now()
+	if user.LockedUntil != nil && now.Before(*user.LockedUntil) { // Akun masih dikunci
+		return entity.User{}, ErrAccountLocked.WithRetryAfter(user.LockedUntil.Sub(now))
+	}
+	if err := service.useCode(ctx, user, code); err != nil {
+		if errors.Is(err, ErrInvalidMFACode) {
+			return entity.User{}, recordFailedLogin(ctx, service.userRepository, service.lockout, user.ID, now, ErrInvalidMFACode)
+		}
+		return entity.User{}, err
+	}
+	if user.FailedLogins > 0 || user.LockedUntil != nil {
+		if err := service.userRepository.ResetFailedLogins(ctx, user.ID); err != nil {
+			return entity.User{}, err
+		}
+		user.FailedLogins, user.LockedUntil = 0, nil
+	}
+	return user, nil
+}
+
+// useCode memeriksa kode TOTP atau kode pemulihan milik user dan menandainya sudah dipakai.
+// Kode pemulihan dikenali dari panjangnya, kode TOTP selalu 6 digit.
+func (service *mfaService) useCode(ctx context.Context, user entity.User, code string) error {
+	normalized := normalizeRecoveryCode(code)
+	if len(normalized) == 2*recoveryCodeBytes {
+		err := service.mfaRepository.UseRecoveryCode(ctx, user.ID, helper.HashToken(normalized))
+		if errors.Is(err, repository.ErrRecoveryCodeUsed) {
+			return ErrInvalidMFACode
+		}
+		return err
+	}
+	step, ok := matchTOTP(user.TOTPSecret, code, time.Now())
+	if !ok {
+		return ErrInvalidMFACode
+	}
+	err := service.mfaRepository.AdvanceStep(ctx, user.ID, step)
+	if errors.Is(err, repository.ErrTOTPCodeReused) { // Kode yang sudah dipakai tidak bisa dipakai lagi, misalnya oleh orang yang mengintip layar
+		return ErrInvalidMFACode
+	}
+	return err
+}
+
+// challengeBinding mengembalikan data user yang ikut ditandatangani pada token login 2FA
+func challengeBinding(user entity.User) string {
+	return user.Password + "." + user.TOTPSecret
+}
+
+// matchTOTP memeriksa code terhadap secret pada waktu t dengan toleransi totpSkew time step,
+// lalu mengembalikan time step yang cocok agar kode yang sama bisa ditolak jika dipakai lagi
+func matchTOTP(secret string, code string, t time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if secret == "" || len(code) != int(totpOpts.Digits) {
+		return 0, false
+	}
+	for skew := -totpSkew; skew <= totpSkew; skew++ {
+		at := t.Add(time.Duration(skew*totpPeriod) * time.Second)
+		expected, err := totp.GenerateCodeCustom(secret, at, totpOpts)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return at.Unix() / totpPeriod, true
+		}
+	}
+	return 0, false
+}
+
+// generateRecoveryCodes membuat kode pemulihan baru dengan format xxxx-xxxx-xxxx-xxxx beserta hash-nya
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	b := make([]byte, recoveryCodeBytes)
+	for i := range codes {
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, apperror.Internal("failed to generate recovery code", err)
+		}
+		raw := hex.EncodeToString(b)
+		groups := make([]string, 0, len(raw)/recoveryCodeGroupSize)
+		for j := 0; j < len(raw); j += recoveryCodeGroupSize {
+			groups = append(groups, raw[j:j+recoveryCodeGroupSize])
+		}
+		codes[i] = strings.Join(groups, "-")
+		hashes[i] = helper.HashToken(raw)
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode menghapus pemisah dan spasi agar kode pemulihan bisa diketik dengan atau tanpa tanda hubung
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
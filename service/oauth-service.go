@@ -0,0 +1,581 @@
+package service
+
+import (
+	"context"         // Mengimport package context untuk pembatalan dan tenggat waktu request
+	"crypto/sha256"   // Mengimport package sha256 untuk memeriksa PKCE code challenge
+	"crypto/subtle"   // Mengimport package subtle untuk membandingkan secret tanpa kebocoran waktu
+	"encoding/base64" // Mengimport package base64 untuk encoding PKCE code challenge
+	"errors"          // Mengimport package errors untuk membandingkan error
+	"net/url"         // Mengimport package url untuk memeriksa dan menyusun redirect URI
+	"slices"          // Mengimport package slices untuk mencari redirect URI yang terdaftar
+	"strconv"         // Mengimport package strconv untuk menulis user ID
+	"strings"         // Mengimport package strings untuk menyimpan daftar scope dan redirect URI
+	"time"            // Mengimport package time untuk masa berlaku code dan token
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror"   // Mengimport package apperror untuk error domain
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/dto"        // Mengimport package dto untuk DTO (Data Transfer Object)
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity"     // Mengimport package entity untuk model entitas
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/helper"     // Mengimport package helper untuk pembuatan dan hashing secret, code, dan token
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/repository" // Mengimport package repository untuk interaksi dengan database
+	"github.com/golang-jwt/jwt/v4"                              // Mengimport package golang-jwt untuk membaca claims access token
+)
+
+// Nilai parameter OAuth2 yang didukung
+const (
+	oauthResponseTypeCode     = "code"               // Satu-satunya response_type yang didukung, implicit grant tidak didukung
+	oauthChallengeMethodS256  = "S256"               // Satu-satunya code_challenge_method yang didukung, metode plain tidak aman
+	oauthGrantAuthorization   = "authorization_code" // grant_type untuk menukar authorization code
+	oauthGrantRefresh         = "refresh_token"      // grant_type untuk memperbarui access token
+	oauthHintRefreshToken     = "refresh_token"      // token_type_hint untuk refresh token
+	oauthVerifierMinLength    = 43                   // Panjang minimum PKCE code verifier (RFC 7636 bagian 4.1)
+	oauthVerifierMaxLength    = 128                  // Panjang maksimum PKCE code verifier
+	oauthChallengeS256Length  = 43                   // Panjang code challenge S256, yaitu SHA-256 dalam base64 URL-safe tanpa padding
+	oauthClientIDBytes        = 16                   // Panjang client ID dalam byte
+	oauthClientSecretBytes    = 32                   // Panjang client secret dalam byte
+	oauthCodeBytes            = 32                   // Panjang authorization code dalam byte
+	oauthRefreshTokenBytes    = 32                   // Panjang refresh token dalam byte
+	oauthRedirectURIMaxLength = 2000                 // Panjang maksimum satu redirect URI
+)
+
+var (
+	// ErrInvalidClient dikembalikan ketika client tidak dikenal atau client secret salah
+	ErrInvalidClient = apperror.Unauthorized("client authentication failed").WithCode("invalid_client")
+	// ErrInvalidGrant dikembalikan ketika authorization code atau refresh token tidak valid, kedaluwarsa, sudah dipakai, atau milik client lain
+	ErrInvalidGrant = apperror.Invalid("the authorization grant is invalid, expired, or revoked").WithCode("invalid_grant")
+	// ErrUnsupportedGrantType dikembalikan ketika grant_type tidak didukung
+	ErrUnsupportedGrantType = apperror.Invalid("grant_type must be authorization_code or refresh_token").WithCode("unsupported_grant_type")
+	// ErrUnsupportedResponseType dikembalikan ketika response_type bukan "code"
+	ErrUnsupportedResponseType = apperror.Invalid(`response_type must be "code"`).WithCode("unsupported_response_type")
+	// ErrInvalidRedirectURI dikembalikan ketika redirect_uri tidak terdaftar untuk client
+	ErrInvalidRedirectURI = apperror.Invalid("redirect_uri is not registered for this client").WithCode("invalid_redirect_uri")
+	// ErrPKCERequired dikembalikan ketika authorization request tidak memakai PKCE S256
+	ErrPKCERequired = apperror.Invalid("a code_challenge with code_challenge_method S256 is required").WithCode("invalid_request")
+	// ErrOAuthGrantNotFound dikembalikan ketika user mencabut akses aplikasi yang tidak memiliki akses aktif
+	ErrOAuthGrantNotFound = apperror.NotFound("the application has no active access to this account").WithCode("oauth_grant_not_found")
+)
+
+// OAuthService adalah interface yang mendefinisikan fungsi yang harus diimplementasikan oleh service authorization server OAuth2
+type OAuthService interface {
+	RegisterClient(ctx context.Context, adminID uint64, client dto.OAuthClientCreateDTO) (dto.OAuthClientCreatedDTO, error) // Fungsi untuk mendaftarkan client baru
+	Clients(ctx context.Context) ([]entity.OAuthClient, error)                                                              // Fungsi untuk mendapatkan semua client
+	DeleteClient(ctx context.Context, clientID string) error                                                                // Fungsi untuk menghapus client beserta semua token miliknya
+	Consent(ctx context.Context, request dto.OAuthAuthorizeDTO) (dto.OAuthConsentDTO, error)                                // Fungsi untuk memeriksa authorization request dan menyiapkan halaman persetujuan
+	Authorize(ctx context.Context, userID uint64, decision dto.OAuthDecisionDTO) (string, error)                            // Fungsi untuk mencatat keputusan user, mengembalikan URL callback client
+	Token(ctx context.Context, request dto.OAuthTokenDTO) (dto.OAuthTokenResponseDTO, error)                                // Fungsi untuk menukar authorization code atau refresh token dengan token
+	Introspect(ctx context.Context, request dto.OAuthTokenActionDTO) (dto.OAuthIntrospectionDTO, error)                     // Fungsi untuk memeriksa status token milik client (RFC 7662)
+	Revoke(ctx context.Context, request dto.OAuthTokenActionDTO) error                                                      // Fungsi untuk mencabut token milik client (RFC 7009)
+	Grants(ctx context.Context, userID uint64) ([]repository.OAuthGrant, error)                                             // Fungsi untuk mendapatkan aplikasi yang memiliki akses ke akun user
+	RevokeGrant(ctx context.Context, userID uint64, clientID string) error                                                  // Fungsi untuk mencabut akses aplikasi ke akun user
+}
+
+// oauthService adalah implementasi dari OAuthService.
+// Access token adalah JWT biasa dari jwtService dengan claim client_id dan scope, sehingga bisa diperiksa tanpa database
+// dan dicabut lewat daftar pencabutan yang sama dengan token login. Refresh token disimpan di tabel terpisah dan dirotasi
+// dengan deteksi pemakaian ulang seperti refresh token login.
+type oauthService struct {
+	oauthRepository   repository.OAuthRepository // Menggunakan repository untuk interaksi dengan database OAuth2
+	userRepository    repository.UserRepository  // Menggunakan repository untuk membaca user yang memberi akses
+	jwtService        JWTService                 // Service untuk menerbitkan dan memeriksa access token
+	revocationService TokenRevocationService     // Service untuk mencabut access token
+	codeTTL           time.Duration              // Masa berlaku authorization code
+	refreshTTL        time.Duration              // Masa berlaku refresh token
+}
+
+// NewOAuthService adalah constructor untuk oauthService
+func NewOAuthService(oauthRepo repository.OAuthRepository, userRepo repository.UserRepository, jwtService JWTService, revocationService TokenRevocationService, codeTTL time.Duration, refreshTTL time.Duration) OAuthService {
+	return &oauthService{
+		oauthRepository:   oauthRepo,
+		userRepository:    userRepo,
+		jwtService:        jwtService,
+		revocationService: revocationService,
+		codeTTL:           codeTTL,
+		refreshTTL:        refreshTTL,
+	}
+}
+
+// RegisterClient adalah implementasi fungsi RegisterClient dari OAuthService. Secret asli hanya dikembalikan di sini.
+func (service *oauthService) RegisterClient(ctx context.Context, adminID uint64, client dto.OAuthClientCreateDTO) (dto.OAuthClientCreatedDTO, error) {
+	scopes, err := normalizeScopes(client.Scopes)
+	if err != nil {
+		return dto.OAuthClientCreatedDTO{}, apperror.Invalid(err.Error()).WithCode("invalid_scope")
+	}
+	for _, uri := range client.RedirectURIs {
+		if err := validateRedirectURI(uri); err != nil {
+			return dto.OAuthClientCreatedDTO{}, err
+		}
+	}
+	clientID, err := helper.GenerateRandomToken(oauthClientIDBytes)
+	if err != nil {
+		return dto.OAuthClientCreatedDTO{}, err
+	}
+	record := entity.OAuthClient{
+		ClientID:     clientID,
+		Name:         client.Name,
+		RedirectURIs: strings.Join(client.RedirectURIs, " "),
+		Scopes:       strings.Join(scopes, " "),
+		CreatedBy:    adminID,
+	}
+	secret := ""
+	if !client.Public {
+		if secret, err = helper.GenerateRandomToken(oauthClientSecretBytes); err != nil {
+			return dto.OAuthClientCreatedDTO{}, err
+		}
+		record.SecretHash = helper.HashToken(secret) // Hanya hash secret yang disimpan
+	}
+	record, err = service.oauthRepository.InsertClient(ctx, record)
+	if err != nil {
+		return dto.OAuthClientCreatedDTO{}, err
+	}
+	return dto.OAuthClientCreatedDTO{Client: record, ClientSecret: secret}, nil
+}
+
+// Clients adalah implementasi fungsi Clients dari OAuthService
+func (service *oauthService) Clients(ctx context.Context) ([]entity.OAuthClient, error) {
+	return service.oauthRepository.AllClients(ctx) // Memanggil repository untuk mendapatkan semua client
+}
+
+// DeleteClient adalah implementasi fungsi DeleteClient dari OAuthService.
+// Access token yang sudah terbit tetap berlaku sampai kedaluwarsa, tetapi tidak bisa diperbarui dan tidak lagi aktif saat diintrospeksi.
+func (service *oauthService) DeleteClient(ctx context.Context, clientID string) error {
+	return service.oauthRepository.DeleteClient(ctx, clientID) // Memanggil repository untuk menghapus client
+}
+
+// Consent adalah implementasi fungsi Consent dari OAuthService
+func (service *oauthService) Consent(ctx context.Context, request dto.OAuthAuthorizeDTO) (dto.OAuthConsentDTO, error) {
+	client, scopes, err := service.checkAuthorizeRequest(ctx, request)
+	if err != nil {
+		return dto.OAuthConsentDTO{}, err
+	}
+	consent := dto.OAuthConsentDTO{ClientID: client.ClientID, ClientName: client.Name, Scopes: []dto.OAuthScopeDTO{}, RedirectURI: request.RedirectURI}
+	for _, scope := range scopes {
+		consent.Scopes = append(consent.Scopes, dto.OAuthScopeDTO{Scope: scope, Description: ScopeDescription(scope)})
+	}
+	return consent, nil
+}
+
+// Authorize adalah implementasi fungsi Authorize dari OAuthService.
+// Jika user menyetujui, authorization code sekali pakai diterbitkan; jika menolak, client menerima error access_denied.
+func (service *oauthService) Authorize(ctx context.Context, userID uint64, decision dto.OAuthDecisionDTO) (string, error) {
+	client, scopes, err := service.checkAuthorizeRequest(ctx, decision.OAuthAuthorizeDTO)
+	if err != nil {
+		return "", err
+	}
+	if !*decision.Approve {
+		return withQuery(decision.RedirectURI, "error", "access_denied", "state", decision.State), nil
+	}
+	code, err := helper.GenerateRandomToken(oauthCodeBytes)
+	if err != nil {
+		return "", err
+	}
+	familyID, err := helper.GenerateRandomToken(24) // Family refresh token yang akan diterbitkan dari code ini
+	if err != nil {
+		return "", err
+	}
+	err = service.oauthRepository.InsertCode(ctx, entity.OAuthAuthorizationCode{
+		CodeHash:      helper.HashToken(code), // Hanya hash code yang disimpan
+		ClientID:      client.ID,
+		UserID:        userID,
+		FamilyID:      familyID,
+		RedirectURI:   decision.RedirectURI,
+		Scopes:        strings.Join(scopes, " "),
+		CodeChallenge: decision.CodeChallenge,
+		ExpiresAt:     time.Now().Add(service.codeTTL),
+	})
+	if err != nil {
+		return "", err
+	}
+	return withQuery(decision.RedirectURI, "code", code, "state", decision.State), nil
+}
+
+// Token adalah implementasi fungsi Token dari OAuthService
+func (service *oauthService) Token(ctx context.Context, request dto.OAuthTokenDTO) (dto.OAuthTokenResponseDTO, error) {
+	client, err := service.authenticateClient(ctx, request.ClientID, request.ClientSecret)
+	if err != nil {
+		return dto.OAuthTokenResponseDTO{}, err
+	}
+	switch request.GrantType {
+	case oauthGrantAuthorization:
+		return service.exchangeCode(ctx, client, request)
+	case oauthGrantRefresh:
+		return service.refresh(ctx, client, request)
+	}
+	return dto.OAuthTokenResponseDTO{}, ErrUnsupportedGrantType
+}
+
+// Introspect adalah implementasi fungsi Introspect dari OAuthService.
+// Client hanya bisa memeriksa token miliknya sendiri, token milik client lain dilaporkan tidak aktif.
+func (service *oauthService) Introspect(ctx context.Context, request dto.OAuthTokenActionDTO) (dto.OAuthIntrospectionDTO, error) {
+	client, err := service.authenticateClient(ctx, request.ClientID, request.ClientSecret)
+	if err != nil {
+		return dto.OAuthIntrospectionDTO{}, err
+	}
+	if request.TokenTypeHint != oauthHintRefreshToken {
+		claims, ok, err := service.accessTokenClaims(ctx, client, request.Token)
+		if err != nil {
+			return dto.OAuthIntrospectionDTO{}, err
+		}
+		if ok {
+			scope, _ := claims["scope"].(string)
+			exp, _ := claims["exp"].(float64)
+			iat, _ := claims["iat"].(float64)
+			sub, _ := claims["user_id"].(string)
+			return dto.OAuthIntrospectionDTO{Active: true, Scope: scope, ClientID: client.ClientID, Sub: sub, TokenType: "Bearer", Exp: int64(exp), Iat: int64(iat)}, nil
+		}
+	}
+	token, ok, err := service.refreshToken(ctx, client, request.Token)
+	if err != nil || !ok {
+		return dto.OAuthIntrospectionDTO{Active: false}, err
+	}
+	return dto.OAuthIntrospectionDTO{
+		Active:   true,
+		Scope:    token.Scopes,
+		ClientID: client.ClientID,
+		Sub:      strconv.FormatUint(token.UserID, 10),
+		Exp:      token.ExpiresAt.Unix(),
+		Iat:      token.CreatedAt.Unix(),
+	}, nil
+}
+
+// Revoke adalah implementasi fungsi Revoke dari OAuthService.
+// Token yang tidak dikenal atau milik client lain tidak menghasilkan error (RFC 7009 bagian 2.2).
+// Mencabut refresh token juga mencabut semua refresh token hasil rotasinya.
+func (service *oauthService) Revoke(ctx context.Context, request dto.OAuthTokenActionDTO) error {
+	client, err := service.authenticateClient(ctx, request.ClientID, request.ClientSecret)
+	if err != nil {
+		return err
+	}
+	if request.TokenTypeHint != oauthHintRefreshToken {
+		token, err := service.jwtService.ValidateToken(request.Token)
+		if err == nil && token.Valid {
+			if claims, ok := token.Claims.(jwt.MapClaims); ok && claims["client_id"] == client.ClientID {
+				return service.revocationService.Revoke(ctx, token)
+			}
+		}
+	}
+	token, ok, err := service.refreshToken(ctx, client, request.Token)
+	if err != nil || !ok {
+		return err
+	}
+	return service.oauthRepository.RevokeFamily(ctx, token.FamilyID)
+}
+
+// Grants adalah implementasi fungsi Grants dari OAuthService
+func (service *oauthService) Grants(ctx context.Context, userID uint64) ([]repository.OAuthGrant, error) {
+	return service.oauthRepository.ActiveGrants(ctx, userID) // Memanggil repository untuk mendapatkan aplikasi dengan akses aktif
+}
+
+// RevokeGrant adalah implementasi fungsi RevokeGrant dari OAuthService.
+// Semua refresh token aplikasi untuk user dicabut, access token yang sudah terbit berakhir sendiri dalam waktu singkat.
+func (service *oauthService) RevokeGrant(ctx context.Context, userID uint64, clientID string) error {
+	client, err := service.oauthRepository.FindClient(ctx, clientID)
+	if errors.Is(err, apperror.ErrNotFound) {
+		return ErrOAuthGrantNotFound
+	}
+	if err != nil {
+		return err
+	}
+	revoked, err := service.oauthRepository.RevokeGrant(ctx, userID, client.ID)
+	if err != nil {
+		return err
+	}
+	if revoked == 0 {
+		return ErrOAuthGrantNotFound
+	}
+	return nil
+}
+
+// checkAuthorizeRequest memeriksa authorization request dan mengembalikan client serta scope yang diminta.
+// Scope kosong berarti semua scope yang diizinkan untuk client.
+func (service *oauthService) checkAuthorizeRequest(ctx context.Context, request dto.OAuthAuthorizeDTO) (entity.OAuthClient, []string, error) {
+	client, err := service.oauthRepository.FindClient(ctx, request.ClientID)
+	if err != nil {
+		return entity.OAuthClient{}, nil, err
+	}
+	if !slices.Contains(strings.Fields(client.RedirectURIs), request.RedirectURI) { // redirect_uri harus sama persis agar code tidak bisa dikirim ke tempat lain
+		return entity.OAuthClient{}, nil, ErrInvalidRedirectURI
+	}
+	if request.ResponseType != oauthResponseTypeCode {
+		return entity.OAuthClient{}, nil, ErrUnsupportedResponseType
+	}
+	if request.CodeChallengeMethod != oauthChallengeMethodS256 || len(request.CodeChallenge) != oauthChallengeS256Length {
+		return entity.OAuthClient{}, nil, ErrPKCERequired
+	}
+	scopes, err := service.allowedScopes(strings.Fields(request.Scope), ParseScopes(client.Scopes))
+	if err != nil {
+		return entity.OAuthClient{}, nil, err
+	}
+	return client, scopes, nil
+}
+
+// allowedScopes memeriksa bahwa requested adalah bagian dari allowed, requested kosong berarti semua scope allowed
+func (service *oauthService) allowedScopes(requested []string, allowed []string) ([]string, error) {
+	if len(requested) == 0 {
+		return allowed, nil
+	}
+	scopes, err := normalizeScopes(requested)
+	if err != nil {
+		return nil, apperror.Invalid(err.Error()).WithCode("invalid_scope")
+	}
+	for _, scope := range scopes {
+		if !HasScope(allowed, scope) {
+			return nil, apperror.Invalid("scope " + scope + " is not allowed").WithCode("invalid_scope")
+		}
+	}
+	return scopes, nil
+}
+
+// authenticateClient memeriksa client ID dan secret. Public client tidak memiliki secret dan tidak boleh mengirimnya.
+func (service *oauthService) authenticateClient(ctx context.Context, clientID string, secret string) (entity.OAuthClient, error) {
+	if clientID == "" {
+		return entity.OAuthClient{}, ErrInvalidClient
+	}
+	client, err := service.oauthRepository.FindClient(ctx, clientID)
+	if errors.Is(err, apperror.ErrNotFound) {
+		return entity.OAuthClient{}, ErrInvalidClient
+	}
+	if err != nil {
+		return entity.OAuthClient{}, err
+	}
+	if subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(client.SecretHash)) != 1 {
+		return entity.OAuthClient{}, ErrInvalidClient
+	}
+	return client, nil
+}
+
+// exchangeCode menukar authorization code dengan token (RFC 6749 bagian 4.1.3).
+// Code yang dipakai ulang mencabut token yang pernah diterbitkan dari code tersebut (RFC 6749 bagian 4.1.2).
+func (service *oauthService) exchangeCode(ctx context.Context, client entity.OAuthClient, request dto.OAuthTokenDTO) (dto.OAuthTokenResponseDTO, error) {
+	code, err := service.oauthRepository.FindCodeByHash(ctx, helper.HashToken(request.Code))
+	if errors.Is(err, apperror.ErrNotFound) {
+		return dto.OAuthTokenResponseDTO{}, ErrInvalidGrant
+	}
+	if err != nil {
+		return dto.OAuthTokenResponseDTO{}, err
+	}
+	if code.ClientID != client.ID {
+		return dto.OAuthTokenResponseDTO{}, ErrInvalidGrant
+	}
+	if code.UsedAt != nil {
+		return dto.OAuthTokenResponseDTO{}, service.revokeReusedFamily(ctx, code.FamilyID)
+	}
+	if time.Now().After(code.ExpiresAt) || code.RedirectURI != request.RedirectURI || !verifyCodeChallenge(request.CodeVerifier, code.CodeChallenge) {
+		return dto.OAuthTokenResponseDTO{}, ErrInvalidGrant
+	}
+	err = service.oauthRepository.MarkCodeUsed(ctx, code.ID)
+	if errors.Is(err, repository.ErrAuthorizationCodeUsed) { // Request lain sudah menukar code ini lebih dulu
+		return dto.OAuthTokenResponseDTO{}, service.revokeReusedFamily(ctx, code.FamilyID)
+	}
+	if err != nil {
+		return dto.OAuthTokenResponseDTO{}, err
+	}
+	user, err := service.activeUser(ctx, code.UserID)
+	if err != nil {
+		return dto.OAuthTokenResponseDTO{}, err
+	}
+	raw, record, err := service.newRefreshToken(client, code.UserID, code.FamilyID, code.Scopes)
+	if err != nil {
+		return dto.OAuthTokenResponseDTO{}, err
+	}
+	if err := service.oauthRepository.InsertToken(ctx, record); err != nil {
+		return dto.OAuthTokenResponseDTO{}, err
+	}
+	return service.tokenResponse(ctx, client, user, code.FamilyID, ParseScopes(code.Scopes), raw)
+}
+
+// refresh menukar refresh token dengan access token baru dan merotasi refresh token (RFC 6749 bagian 6).
+// Scope yang diminta boleh lebih sempit untuk access token baru, refresh token baru tetap memiliki scope semula.
+func (service *oauthService) refresh(ctx context.Context, client entity.OAuthClient, request dto.OAuthTokenDTO) (dto.OAuthTokenResponseDTO, error) {
+	current, err := service.oauthRepository.FindTokenByHash(ctx, helper.HashToken(request.RefreshToken))
+	if errors.Is(err, apperror.ErrNotFound) {
+		return dto.OAuthTokenResponseDTO{}, ErrInvalidGrant
+	}
+	if err != nil {
+		return dto.OAuthTokenResponseDTO{}, err
+	}
+	if current.ClientID != client.ID {
+		return dto.OAuthTokenResponseDTO{}, ErrInvalidGrant
+	}
+	if current.RevokedAt != nil { // Token lama dipakai ulang
+		return dto.OAuthTokenResponseDTO{}, service.revokeReusedFamily(ctx, current.FamilyID)
+	}
+	if time.Now().After(current.ExpiresAt) {
+		return dto.OAuthTokenResponseDTO{}, ErrInvalidGrant
+	}
+	scopes, err := service.allowedScopes(strings.Fields(request.Scope), ParseScopes(current.Scopes))
+	if err != nil {
+		return dto.OAuthTokenResponseDTO{}, err
+	}
+	user, err := service.activeUser(ctx, current.UserID)
+	if err != nil {
+		return dto.OAuthTokenResponseDTO{}, err
+	}
+	raw, next, err := service.newRefreshToken(client, current.UserID, current.FamilyID, current.Scopes)
+	if err != nil {
+		return dto.OAuthTokenResponseDTO{}, err
+	}
+	err = service.oauthRepository.RotateToken(ctx, current, next)
+	if errors.Is(err, repository.ErrOAuthTokenAlreadyRotated) { // Request lain sudah merotasi token ini lebih dulu
+		return dto.OAuthTokenResponseDTO{}, service.revokeReusedFamily(ctx, current.FamilyID)
+	}
+	if err != nil {
+		return dto.OAuthTokenResponseDTO{}, err
+	}
+	return service.tokenResponse(ctx, client, user, current.FamilyID, scopes, raw)
+}
+
+// activeUser mengambil user yang memberi akses, user yang sudah dihapus atau diblokir tidak bisa lagi diwakili client
+func (service *oauthService) activeUser(ctx context.Context, userID uint64) (entity.User, error) {
+	user, err := service.userRepository.FindByID(ctx, userID)
+	if errors.Is(err, apperror.ErrNotFound) || (err == nil && user.Suspended) {
+		return entity.User{}, ErrInvalidGrant
+	}
+	return user, err
+}
+
+// tokenResponse menerbitkan access token, mencatatnya pada family agar bisa dicabut, lalu menyusun respons endpoint token
+func (service *oauthService) tokenResponse(ctx context.Context, client entity.OAuthClient, user entity.User, familyID string, scopes []string, refreshToken string) (dto.OAuthTokenResponseDTO, error) {
+	accessToken, err := service.jwtService.GenerateDelegatedToken(strconv.FormatUint(user.ID, 10), user.Role, client.ClientID, scopes)
+	if err != nil {
+		return dto.OAuthTokenResponseDTO{}, err
+	}
+	err = service.oauthRepository.InsertAccessToken(ctx, entity.OAuthAccessToken{
+		ClientID:  client.ID,
+		FamilyID:  familyID,
+		JTI:       accessToken.ID,
+		ExpiresAt: accessToken.ExpiresAt,
+	})
+	if err != nil {
+		return dto.OAuthTokenResponseDTO{}, err
+	}
+	return dto.OAuthTokenResponseDTO{
+		AccessToken:  accessToken.Token,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(service.jwtService.TokenTTL().Seconds()),
+		RefreshToken: refreshToken,
+		Scope:        strings.Join(scopes, " "),
+	}, nil
+}
+
+// accessTokenClaims memeriksa access token milik client, ok bernilai false jika token tidak valid, sudah dicabut, atau milik client lain
+func (service *oauthService) accessTokenClaims(ctx context.Context, client entity.OAuthClient, raw string) (jwt.MapClaims, bool, error) {
+	token, err := service.jwtService.ValidateToken(raw)
+	if err != nil || !token.Valid {
+		return nil, false, nil
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["client_id"] != client.ClientID {
+		return nil, false, nil
+	}
+	revoked, err := service.revocationService.IsRevoked(ctx, token)
+	if err != nil || revoked {
+		return nil, false, err
+	}
+	return claims, true, nil
+}
+
+// refreshToken mencari refresh token milik client, ok bernilai false jika token tidak dikenal, sudah dicabut, kedaluwarsa, atau milik client lain
+func (service *oauthService) refreshToken(ctx context.Context, client entity.OAuthClient, raw string) (entity.OAuthRefreshToken, bool, error) {
+	token, err := service.oauthRepository.FindTokenByHash(ctx, helper.HashToken(raw))
+	if errors.Is(err, apperror.ErrNotFound) {
+		return entity.OAuthRefreshToken{}, false, nil
+	}
+	if err != nil {
+		return entity.OAuthRefreshToken{}, false, err
+	}
+	if token.ClientID != client.ID || token.RevokedAt != nil || time.Now().After(token.ExpiresAt) {
+		return entity.OAuthRefreshToken{}, false, nil
+	}
+	return token, true, nil
+}
+
+// revokeReusedFamily mencabut seluruh refresh token dan access token yang sudah terbit dalam family, lalu mengembalikan ErrInvalidGrant
+func (service *oauthService) revokeReusedFamily(ctx context.Context, familyID string) error {
+	if err := service.oauthRepository.RevokeFamily(ctx, familyID); err != nil {
+		return err
+	}
+	tokens, err := service.oauthRepository.FamilyAccessTokens(ctx, familyID, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		if err := service.revocationService.RevokeID(ctx, token.JTI, token.ExpiresAt); err != nil {
+			return err
+		}
+	}
+	return ErrInvalidGrant
+}
+
+// newRefreshToken membuat refresh token acak beserta record yang akan disimpan ke database
+func (service *oauthService) newRefreshToken(client entity.OAuthClient, userID uint64, familyID string, scopes string) (string, entity.OAuthRefreshToken, error) {
+	raw, err := helper.GenerateRandomToken(oauthRefreshTokenBytes)
+	if err != nil {
+		return "", entity.OAuthRefreshToken{}, err
+	}
+	record := entity.OAuthRefreshToken{
+		ClientID:  client.ID,
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: helper.HashToken(raw), // Hanya hash token yang disimpan
+		Scopes:    scopes,
+		ExpiresAt: time.Now().Add(service.refreshTTL),
+	}
+	return raw, record, nil
+}
+
+// hashSecret mengembalikan hash client secret, secret kosong (public client) menghasilkan hash kosong
+func hashSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return helper.HashToken(secret)
+}
+
+// verifyCodeChallenge memeriksa PKCE code verifier terhadap code challenge S256 (RFC 7636 bagian 4.6)
+func verifyCodeChallenge(verifier string, challenge string) bool {
+	if len(verifier) < oauthVerifierMinLength || len(verifier) > oauthVerifierMaxLength {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	return subtle.ConstantTimeCompare([]byte(base64.RawURLEncoding.EncodeToString(sum[:])), []byte(challenge)) == 1
+}
+
+// validateRedirectURI memeriksa redirect URI client baru. Selain https, hanya http ke loopback untuk pengembangan
+// dan custom scheme berbentuk reverse domain untuk aplikasi native (RFC 8252) yang diterima.
+func validateRedirectURI(uri string) error {
+	u, err := url.Parse(uri)
+	invalid := func(reason string) error {
+		return apperror.Invalid("redirect uri " + strconv.Quote(uri) + " " + reason).WithCode("invalid_redirect_uri")
+	}
+	switch {
+	case err != nil || !u.IsAbs() || strings.ContainsAny(uri, " \t\r\n") || len(uri) > oauthRedirectURIMaxLength:
+		return invalid("must be an absolute URL")
+	case u.Fragment != "":
+		return invalid("must not contain a fragment")
+	case u.Scheme == "https":
+		return nil
+	case u.Scheme == "http":
+		if host := u.Hostname(); host == "localhost" || host == "127.0.0.1" || host == "::1" {
+			return nil
+		}
+		return invalid("must use https unless it points to localhost")
+	case strings.Contains(u.Scheme, "."):
+		return nil
+	}
+	return invalid("must use https or a reverse-domain custom scheme")
+}
+
+// withQuery menambahkan parameter ke query URL, parameter dengan nilai kosong dilewati
+func withQuery(rawURL string, pairs ...string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL // Redirect URI sudah divalidasi saat client didaftarkan
+	}
+	q := u.Query()
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] != "" {
+			q.Set(pairs[i], pairs[i+1])
+		}
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
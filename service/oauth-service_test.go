@@ -0,0 +1,97 @@
+package service_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/dto"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/password"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/repository"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const testRedirectURI = "https://partner.example.com/callback"
+
+func TestOAuthCodeReuseRevokesIssuedAccessTokens(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	users := repository.NewUserRepository(db, password.NewHasher(password.BcryptHasher{Cost: bcrypt.MinCost}))
+	revocation := service.NewTokenRevocationService(repository.NewRevocationStore(db), repository.NewRefreshTokenRepository(db))
+	oauth := service.NewOAuthService(repository.NewOAuthRepository(db), users, service.NewJWTService(time.Minute, service.NewHMACKey("test-secret")), revocation, time.Minute, time.Hour)
+
+	user, err := users.InsertUser(ctx, entity.User{Name: "Ana", Email: "ana@example.com", Password: "Str0ng-Passw0rd!", Role: entity.RoleReader})
+	if err != nil {
+		t.Fatalf("insert user: %v", err)
+	}
+	client, err := oauth.RegisterClient(ctx, user.ID, dto.OAuthClientCreateDTO{Name: "Partner", RedirectURIs: []string{testRedirectURI}, Scopes: []string{service.ScopeBooksRead}})
+	if err != nil {
+		t.Fatalf("RegisterClient: %v", err)
+	}
+	verifier := "test-verifier-with-enough-entropy-0123456789"
+	sum := sha256.Sum256([]byte(verifier))
+	approve := true
+	redirect, err := oauth.Authorize(ctx, user.ID, dto.OAuthDecisionDTO{
+		OAuthAuthorizeDTO: dto.OAuthAuthorizeDTO{
+			ResponseType:        "code",
+			ClientID:            client.Client.ClientID,
+			RedirectURI:         testRedirectURI,
+			CodeChallenge:       base64.RawURLEncoding.EncodeToString(sum[:]),
+			CodeChallengeMethod: "S256",
+		},
+		Approve: &approve,
+	})
+	if err != nil {
+		t.Fatalf("Authorize: %v", err)
+	}
+	location, err := url.Parse(redirect)
+	if err != nil {
+		t.Fatalf("parse redirect: %v", err)
+	}
+	exchange := dto.OAuthTokenDTO{
+		GrantType:    "authorization_code",
+		Code:         location.Query().Get("code"),
+		RedirectURI:  testRedirectURI,
+		CodeVerifier: verifier,
+		ClientID:     client.Client.ClientID,
+		ClientSecret: client.ClientSecret,
+	}
+	first, err := oauth.Token(ctx, exchange)
+	if err != nil {
+		t.Fatalf("first exchange: %v", err)
+	}
+	refreshed, err := oauth.Token(ctx, dto.OAuthTokenDTO{GrantType: "refresh_token", RefreshToken: first.RefreshToken, ClientID: client.Client.ClientID, ClientSecret: client.ClientSecret})
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	active := func(token string) bool {
+		t.Helper()
+		res, err := oauth.Introspect(ctx, dto.OAuthTokenActionDTO{Token: token, ClientID: client.Client.ClientID, ClientSecret: client.ClientSecret})
+		if err != nil {
+			t.Fatalf("Introspect: %v", err)
+		}
+		return res.Active
+	}
+	if !active(first.AccessToken) || !active(refreshed.AccessToken) {
+		t.Fatal("access tokens are not active before the code is reused")
+	}
+
+	if _, err := oauth.Token(ctx, exchange); !errors.Is(err, service.ErrInvalidGrant) {
+		t.Fatalf("reused code: err = %v, want ErrInvalidGrant", err)
+	}
+	if active(first.AccessToken) {
+		t.Fatal("access token from the first exchange is still active after the code was reused")
+	}
+	if active(refreshed.AccessToken) {
+		t.Fatal("access token from a refresh in the same family is still active after the code was reused")
+	}
+	if active(refreshed.RefreshToken) {
+		t.Fatal("refresh token is still active after the code was reused")
+	}
+}
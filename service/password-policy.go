@@ -0,0 +1,69 @@
+package service
+
+import (
+	"fmt"          // Mengimport package fmt untuk pesan pelanggaran kebijakan
+	"strings"      // Mengimport package strings untuk menggabungkan pesan pelanggaran
+	"unicode"      // Mengimport package unicode untuk mengenali jenis karakter
+	"unicode/utf8" // Mengimport package utf8 untuk menghitung panjang password dalam karakter
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror" // Mengimport package apperror untuk error domain
+)
+
+// PasswordPolicy menentukan password yang boleh dipakai saat registrasi, update profil, dan reset password.
+// Password yang sudah tersimpan tidak diperiksa ulang, sehingga user lama tetap bisa login setelah kebijakan diperketat.
+type PasswordPolicy struct {
+	MinLength  int             // Panjang minimum dalam karakter
+	MaxLength  int             // Panjang maksimum dalam karakter
+	MaxBytes   int             // Panjang maksimum dalam byte, 0 berarti tidak dibatasi; dipakai untuk batas 72 byte bcrypt
+	MinClasses int             // Jumlah minimum jenis karakter yang dipakai: huruf kecil, huruf besar, angka, dan simbol
+	breached   map[string]bool // Password yang pernah bocor atau terlalu umum, dalam huruf kecil
+}
+
+// NewPasswordPolicy membuat PasswordPolicy yang juga menolak password pada breached tanpa membedakan huruf besar dan kecil
+func NewPasswordPolicy(minLength int, maxLength int, maxBytes int, minClasses int, breached []string) PasswordPolicy {
+	policy := PasswordPolicy{MinLength: minLength, MaxLength: maxLength, MaxBytes: maxBytes, MinClasses: minClasses, breached: map[string]bool{}}
+	for _, password := range breached {
+		policy.breached[strings.ToLower(password)] = true
+	}
+	return policy
+}
+
+// Validate memeriksa password terhadap kebijakan dan mengembalikan error weak_password berisi semua pelanggaran
+func (p PasswordPolicy) Validate(password string) error {
+	var violations []string
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		violations = append(violations, fmt.Sprintf("password must be at least %d characters", p.MinLength))
+	}
+	if length > p.MaxLength || (p.MaxBytes > 0 && len(password) > p.MaxBytes) {
+		violations = append(violations, fmt.Sprintf("password must be at most %d characters", p.MaxLength))
+	}
+	if classes := characterClasses(password); classes < p.MinClasses {
+		violations = append(violations, fmt.Sprintf("password must contain at least %d of: lowercase letters, uppercase letters, digits, symbols", p.MinClasses))
+	}
+	if p.breached[strings.ToLower(password)] {
+		violations = append(violations, "password is too common or has appeared in a data breach")
+	}
+	if len(violations) > 0 {
+		return apperror.Invalid(strings.Join(violations, "; ")).WithCode("weak_password")
+	}
+	return nil
+}
+
+// characterClasses menghitung jenis karakter yang dipakai password
+func characterClasses(password string) int {
+	var lower, upper, digit, symbol int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+	return lower + upper + digit + symbol
+}
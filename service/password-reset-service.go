@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context" // Mengimport package context untuk pembatalan dan tenggat waktu request
+	"errors"  // Mengimport package errors untuk membandingkan error
+	"fmt"     // Mengimport package fmt untuk menyusun isi email
+	"time"    // Mengimport package time untuk mengelola waktu
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror"   // Mengimport package apperror untuk error domain
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity"     // Mengimport package entity untuk model entitas
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/helper"     // Mengimport package helper untuk pembuatan token acak
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/mail"       // Mengimport package mail untuk mengirim email
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/repository" // Mengimport package repository untuk interaksi dengan database
+)
+
+// ErrInvalidResetToken dikembalikan ketika token reset password tidak dikenal, sudah dipakai, atau sudah kedaluwarsa
+var ErrInvalidResetToken = apperror.Invalid("invalid or expired password reset token").WithCode("reset_token_invalid")
+
+// PasswordResetService adalah interface yang mendefinisikan fungsi yang harus diimplementasikan oleh service reset password
+type PasswordResetService interface {
+	RequestReset(ctx context.Context, email string) error                   // Fungsi untuk mengirim token reset password ke email user
+	ResetPassword(ctx context.Context, token string, password string) error // Fungsi untuk mengganti password dengan token reset password
+}
+
+// passwordResetService adalah implementasi dari PasswordResetService
+type passwordResetService struct {
+	userRepository          repository.UserRepository          // Menggunakan repository untuk mencari user berdasarkan email
+	passwordResetRepository repository.PasswordResetRepository // Menggunakan repository untuk interaksi dengan database token reset password
+	userService             UserService                        // Menggunakan service user untuk mengganti password dan mencabut semua sesi
+	policy                  PasswordPolicy                     // Kebijakan password baru, diperiksa sebelum token dipakai
+	mailer                  mail.Mailer                        // Pengirim email
+	ttl                     time.Duration                      // Masa berlaku token reset password
+	resetURL                string                             // Halaman reset password di frontend, kosong berarti email hanya berisi token
+}
+
+// NewPasswordResetService adalah constructor untuk passwordResetService
+func NewPasswordResetService(userRepo repository.UserRepository, resetRepo repository.PasswordResetRepository, userService UserService, policy PasswordPolicy, mailer mail.Mailer, ttl time.Duration, resetURL string) PasswordResetService {
+	return &passwordResetService{
+		userRepository:          userRepo,
+		passwordResetRepository: resetRepo,
+		userService:             userService,
+		policy:                  policy,
+		mailer:                  mailer,
+		ttl:                     ttl,
+		resetURL:                resetURL,
+	}
+}
+
+// RequestReset adalah implementasi fungsi RequestReset dari PasswordResetService.
+// Email yang tidak terdaftar dan user yang diblokir tidak menghasilkan error agar client tidak bisa
+// menebak email mana yang terdaftar. Email dikirim di background karena alasan yang sama,
+// sehingga lama respons tidak bergantung pada server email.
+func (service *passwordResetService) RequestReset(ctx context.Context, email string) error {
+	user, err := service.userRepository.FindByEmail(ctx, email) // Memanggil repository untuk mencari user berdasarkan email
+	if errors.Is(err, apperror.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if user.Suspended { // User yang diblokir admin tidak boleh memulihkan akunnya sendiri
+		return nil
+	}
+
+	raw, err := helper.GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+	record := entity.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: helper.HashToken(raw), // Hanya hash token yang disimpan
+		ExpiresAt: time.Now().Add(service.ttl),
+	}
+	if _, err := service.passwordResetRepository.InsertToken(ctx, record); err != nil {
+		return err
+	}
+	sendInBackground(ctx, service.mailer, service.resetMessage(user, raw))
+	return nil
+}
+
+// ResetPassword adalah implementasi fungsi ResetPassword dari PasswordResetService.
+// Token ditandai terpakai sebelum password diganti, lalu semua sesi dan token reset lain milik user dicabut
+// dan penguncian akun karena login gagal dibuka. Password yang ditolak kebijakan tidak menghabiskan token.
+func (service *passwordResetService) ResetPassword(ctx context.Context, token string, password string) error {
+	if err := service.policy.Validate(password); err != nil {
+		return err
+	}
+	record, err := service.passwordResetRepository.FindByHash(ctx, helper.HashToken(token))
+	if errors.Is(err, apperror.ErrNotFound) {
+		return ErrInvalidResetToken
+	}
+	if err != nil {
+		return err
+	}
+	if record.UsedAt != nil || time.Now().After(record.ExpiresAt) {
+		return ErrInvalidResetToken
+	}
+	err = service.passwordResetRepository.MarkUsed(ctx, record.ID)
+	if errors.Is(err, repository.ErrPasswordResetTokenUsed) { // Request lain sudah memakai token ini lebih dulu
+		return ErrInvalidResetToken
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := service.userService.ResetPassword(ctx, record.UserID, password); err != nil { // Mengganti password dan mencabut semua sesi
+		return err
+	}
+	if err := service.passwordResetRepository.InvalidateAllForUser(ctx, record.UserID); err != nil { // Link reset lain yang masih beredar tidak berlaku lagi
+		return err
+	}
+	return service.userRepository.ResetFailedLogins(ctx, record.UserID) // Pemilik email sudah terbukti, kunci akun dibuka
+}
+
+// resetMessage menyusun email berisi token reset password
+func (service *passwordResetService) resetMessage(user entity.User, token string) mail.Message {
+	action := "Use this token to choose a new password:\n\n" + token
+	if service.resetURL != "" {
+		action = "Open this link to choose a new password:\n\n" + withToken(service.resetURL, token)
+	}
+	return mail.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nSomeone asked to reset the password of your account. %s\n\nThe token expires in %s and can be used once. "+
+			"If you did not ask for this, you can ignore this email.\n", user.Name, action, service.ttl),
+	}
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context" // Mengimport package context untuk pembatalan dan tenggat waktu request
+	"errors"  // Mengimport package errors untuk membandingkan error
+	"time"    // Mengimport package time untuk mengelola waktu
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror"   // Mengimport package apperror untuk error domain
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity"     // Mengimport package entity untuk model entitas
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/helper"     // Mengimport package helper untuk pembuatan token acak
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/repository" // Mengimport package repository untuk interaksi dengan database
+)
+
+var (
+	// ErrInvalidRefreshToken dikembalikan ketika refresh token tidak dikenal atau sudah kedaluwarsa
+	ErrInvalidRefreshToken = apperror.Unauthorized("invalid refresh token").WithCode("refresh_token_invalid")
+	// ErrRefreshTokenReused dikembalikan ketika refresh token yang sudah dirotasi dipakai lagi
+	ErrRefreshTokenReused = apperror.Unauthorized("refresh token reuse detected").WithCode("refresh_token_reused")
+)
+
+// RefreshTokenService adalah interface yang mendefinisikan fungsi yang harus diimplementasikan oleh service refresh token
+type RefreshTokenService interface {
+	Issue(ctx context.Context, userID uint64) (string, entity.RefreshToken, error) // Fungsi untuk menerbitkan refresh token baru (family baru)
+	Rotate(ctx context.Context, token string) (string, entity.RefreshToken, error) // Fungsi untuk menukar refresh token lama dengan yang baru
+	Revoke(ctx context.Context, token string) error                                // Fungsi untuk mencabut refresh token beserta family-nya
+}
+
+// refreshTokenService adalah implementasi dari RefreshTokenService
+type refreshTokenService struct {
+	refreshTokenRepository repository.RefreshTokenRepository // Menggunakan repository untuk interaksi dengan database refresh token
+	ttl                    time.Duration                     // Masa berlaku refresh token
+}
+
+// NewRefreshTokenService adalah constructor untuk refreshTokenService, ttl adalah masa berlaku refresh token
+func NewRefreshTokenService(refreshTokenRepo repository.RefreshTokenRepository, ttl time.Duration) RefreshTokenService {
+	return &refreshTokenService{
+		refreshTokenRepository: refreshTokenRepo,
+		ttl:                    ttl,
+	}
+}
+
+// Issue adalah implementasi fungsi Issue dari RefreshTokenService.
+// Selain token asli, record yang tersimpan dikembalikan agar pemanggil bisa mencatat sesi untuk family-nya.
+func (service *refreshTokenService) Issue(ctx context.Context, userID uint64) (string, entity.RefreshToken, error) {
+	familyID, err := helper.GenerateRandomToken(24) // Setiap login memulai family token baru
+	if err != nil {
+		return "", entity.RefreshToken{}, err
+	}
+	raw, record, err := service.newToken(userID, familyID)
+	if err != nil {
+		return "", entity.RefreshToken{}, err
+	}
+	record, err = service.refreshTokenRepository.InsertToken(ctx, record)
+	if err != nil {
+		return "", entity.RefreshToken{}, err
+	}
+	return raw, record, nil
+}
+
+// Rotate adalah implementasi fungsi Rotate dari RefreshTokenService.
+// Jika token yang sudah pernah dirotasi dipakai lagi, seluruh family dicabut karena
+// kemungkinan besar token tersebut sudah dicuri. Record yang dikembalikan adalah token pengganti.
+func (service *refreshTokenService) Rotate(ctx context.Context, token string) (string, entity.RefreshToken, error) {
+	current, err := service.refreshTokenRepository.FindByHash(ctx, helper.HashToken(token))
+	if errors.Is(err, apperror.ErrNotFound) {
+		return "", entity.RefreshToken{}, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return "", entity.RefreshToken{}, err
+	}
+	if current.RevokedAt != nil { // Token lama dipakai ulang
+		return "", entity.RefreshToken{}, service.revokeReusedFamily(ctx, current.FamilyID)
+	}
+	if time.Now().After(current.ExpiresAt) {
+		return "", entity.RefreshToken{}, ErrInvalidRefreshToken
+	}
+
+	raw, next, err := service.newToken(current.UserID, current.FamilyID)
+	if err != nil {
+		return "", entity.RefreshToken{}, err
+	}
+	next, err = service.refreshTokenRepository.RotateToken(ctx, current, next)
+	if errors.Is(err, repository.ErrRefreshTokenAlreadyRotated) { // Request lain sudah merotasi token ini lebih dulu
+		return "", entity.RefreshToken{}, service.revokeReusedFamily(ctx, current.FamilyID)
+	}
+	if err != nil {
+		return "", entity.RefreshToken{}, err
+	}
+	return raw, next, nil
+}
+
+// Revoke adalah implementasi fungsi Revoke dari RefreshTokenService
+func (service *refreshTokenService) Revoke(ctx context.Context, token string) error {
+	current, err := service.refreshTokenRepository.FindByHash(ctx, helper.HashToken(token))
+	if errors.Is(err, apperror.ErrNotFound) {
+		return ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return err
+	}
+	return service.refreshTokenRepository.RevokeFamily(ctx, current.FamilyID) // Seluruh family dicabut agar token hasil rotasi juga tidak berlaku
+}
+
+// revokeReusedFamily mencabut seluruh token dalam family dan mengembalikan ErrRefreshTokenReused
+func (service *refreshTokenService) revokeReusedFamily(ctx context.Context, familyID string) error {
+	if err := service.refreshTokenRepository.RevokeFamily(ctx, familyID); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+// newToken membuat refresh token acak beserta record yang akan disimpan ke database
+func (service *refreshTokenService) newToken(userID uint64, familyID string) (string, entity.RefreshToken, error) {
+	raw, err := helper.GenerateRandomToken(32)
+	if err != nil {
+		return "", entity.RefreshToken{}, err
+	}
+	record := entity.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: helper.HashToken(raw), // Hanya hash token yang disimpan
+		ExpiresAt: time.Now().Add(service.ttl),
+	}
+	return raw, record, nil
+}
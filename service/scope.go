@@ -0,0 +1,70 @@
+package service
+
+import (
+	"fmt"     // Mengimport package fmt untuk pesan error
+	"sort"    // Mengimport package sort untuk mengurutkan daftar scope
+	"strings" // Mengimport package strings untuk menyusun daftar scope
+)
+
+// Scope yang bisa diberikan kepada credential selain login user, misalnya API key atau client OAuth2
+const (
+	ScopeBooksRead    = "books:read"    // ScopeBooksRead adalah membaca dan mencari buku
+	ScopeBooksWrite   = "books:write"   // ScopeBooksWrite adalah membuat, mengubah, dan menghapus buku
+	ScopeProfileRead  = "profile:read"  // ScopeProfileRead adalah membaca profil user
+	ScopeProfileWrite = "profile:write" // ScopeProfileWrite adalah mengubah profil user
+)
+
+// knownScopes adalah semua scope yang dikenal beserta penjelasannya untuk halaman persetujuan OAuth2
+var knownScopes = map[string]string{
+	ScopeBooksRead:    "Read and search your books",
+	ScopeBooksWrite:   "Create, change, and delete your books",
+	ScopeProfileRead:  "Read your name and email address",
+	ScopeProfileWrite: "Change your name",
+}
+
+// KnownScopes mengembalikan semua scope yang dikenal, urut berdasarkan nama
+func KnownScopes() []string {
+	scopes := make([]string, 0, len(knownScopes))
+	for scope := range knownScopes {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+// normalizeScopes memeriksa bahwa semua scope dikenal, lalu mengembalikannya tanpa duplikat dan urut berdasarkan nama
+func normalizeScopes(scopes []string) ([]string, error) {
+	seen := map[string]bool{}
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if _, ok := knownScopes[scope]; !ok {
+			return nil, fmt.Errorf("unknown scope %q, expected one of %s", scope, strings.Join(KnownScopes(), ", "))
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			result = append(result, scope)
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// ScopeDescription mengembalikan penjelasan scope untuk ditampilkan kepada user
+func ScopeDescription(scope string) string {
+	return knownScopes[scope]
+}
+
+// ParseScopes memecah daftar scope yang dipisahkan spasi, format yang dipakai untuk menyimpan scope di database
+func ParseScopes(scopes string) []string {
+	return strings.Fields(scopes)
+}
+
+// HasScope memeriksa apakah scope ada di dalam daftar scopes
+func HasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
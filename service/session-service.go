@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context" // Mengimport package context untuk pembatalan dan tenggat waktu request
+	"errors"  // Mengimport package errors untuk membandingkan error
+	"strings" // Mengimport package strings untuk membaca user agent
+	"time"    // Mengimport package time untuk mengelola waktu
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror"   // Mengimport package apperror untuk error domain
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/dto"        // Mengimport package dto untuk informasi perangkat
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity"     // Mengimport package entity untuk model entitas
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/repository" // Mengimport package repository untuk interaksi dengan database
+)
+
+const (
+	sessionTouchInterval = time.Minute // Jarak minimum antara dua pencatatan LastSeenAt, agar setiap request tidak menulis ke database
+	maxUserAgentLength   = 255         // Panjang maksimum user agent yang disimpan
+	maxDeviceNameLength  = 100         // Panjang maksimum nama perangkat yang disimpan
+)
+
+var (
+	// ErrSessionRevoked dikembalikan ketika access token milik sesi yang sudah berakhir atau dicabut
+	ErrSessionRevoked = apperror.Unauthorized("session has ended").WithCode("session_revoked")
+	// ErrSessionNotFound dikembalikan ketika sesi yang akan dicabut tidak ada, sudah berakhir, atau milik user lain
+	ErrSessionNotFound = apperror.NotFound("session not found").WithCode("session_not_found")
+)
+
+// SessionService adalah interface yang mendefinisikan fungsi yang harus diimplementasikan oleh service sesi login
+type SessionService interface {
+	Track(ctx context.Context, userID uint64, familyID string, device dto.SessionDeviceDTO) (entity.Session, error) // Fungsi untuk mendapatkan sesi milik family refresh token, sesi baru dibuat jika belum ada
+	Check(ctx context.Context, sessionID uint64) error                                                              // Fungsi untuk memeriksa sesi masih aktif dan mencatat waktu terakhir dipakai
+	List(ctx context.Context, userID uint64, currentID uint64) ([]entity.Session, error)                            // Fungsi untuk mendapatkan sesi aktif milik user
+	Revoke(ctx context.Context, userID uint64, sessionID uint64) error                                              // Fungsi untuk mengakhiri sesi milik user
+}
+
+// sessionService adalah implementasi dari SessionService
+type sessionService struct {
+	sessionRepository      repository.SessionRepository      // Menggunakan repository untuk interaksi dengan database sesi
+	refreshTokenRepository repository.RefreshTokenRepository // Menggunakan repository refresh token untuk mengakhiri sesi
+}
+
+// NewSessionService adalah constructor untuk sessionService
+func NewSessionService(sessionRepo repository.SessionRepository, refreshTokenRepo repository.RefreshTokenRepository) SessionService {
+	return &sessionService{
+		sessionRepository:      sessionRepo,
+		refreshTokenRepository: refreshTokenRepo,
+	}
+}
+
+// Track adalah implementasi fungsi Track dari SessionService.
+// Login selalu memulai family baru sehingga sesi baru dibuat; refresh memakai sesi yang sama dan mencatat waktu terakhir dipakai.
+// Family dari sebelum sesi dicatat mendapatkan sesi baru saat pertama kali di-refresh.
+func (service *sessionService) Track(ctx context.Context, userID uint64, familyID string, device dto.SessionDeviceDTO) (entity.Session, error) {
+	now := time.Now()
+	session, err := service.sessionRepository.FindByFamily(ctx, familyID)
+	if err == nil {
+		if err := service.touch(ctx, session, now); err != nil {
+			return entity.Session{}, err
+		}
+		return session, nil
+	}
+	if !errors.Is(err, apperror.ErrNotFound) {
+		return entity.Session{}, err
+	}
+	deviceName := strings.TrimSpace(device.DeviceName)
+	if deviceName == "" {
+		deviceName = describeDevice(device.UserAgent) // Client yang tidak mengirim nama perangkat tetap mudah dikenali di daftar sesi
+	}
+	return service.sessionRepository.InsertSession(ctx, entity.Session{
+		UserID:     userID,
+		FamilyID:   familyID,
+		DeviceName: truncate(deviceName, maxDeviceNameLength),
+		UserAgent:  truncate(device.UserAgent, maxUserAgentLength),
+		IPAddress:  device.IPAddress,
+		LastSeenAt: now,
+	})
+}
+
+// Check adalah implementasi fungsi Check dari SessionService
+func (service *sessionService) Check(ctx context.Context, sessionID uint64) error {
+	session, err := service.sessionRepository.FindActive(ctx, sessionID)
+	if errors.Is(err, apperror.ErrNotFound) {
+		return ErrSessionRevoked
+	}
+	if err != nil {
+		return err
+	}
+	return service.touch(ctx, session, time.Now())
+}
+
+// List adalah implementasi fungsi List dari SessionService, currentID adalah sesi dari token yang dipakai untuk request ini
+func (service *sessionService) List(ctx context.Context, userID uint64, currentID uint64) ([]entity.Session, error) {
+	sessions, err := service.sessionRepository.ActiveForUser(ctx, userID) // Memanggil repository untuk mendapatkan sesi aktif
+	if err != nil {
+		return nil, err
+	}
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == currentID
+	}
+	return sessions, nil
+}
+
+// Revoke adalah implementasi fungsi Revoke dari SessionService.
+// Refresh token sesi dicabut sehingga sesi tidak bisa diperpanjang, dan access token dengan sesi ini langsung ditolak oleh Check.
+func (service *sessionService) Revoke(ctx context.Context, userID uint64, sessionID uint64) error {
+	session, err := service.sessionRepository.FindActive(ctx, sessionID)
+	if errors.Is(err, apperror.ErrNotFound) || (err == nil && session.UserID != userID) { // Sesi milik user lain dianggap tidak ada agar ID sesi orang lain tidak bisa ditebak
+		return ErrSessionNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return service.refreshTokenRepository.RevokeFamily(ctx, session.FamilyID)
+}
+
+// touch mencatat waktu terakhir sesi dipakai paling sering sekali setiap sessionTouchInterval
+func (service *sessionService) touch(ctx context.Context, session entity.Session, now time.Time) error {
+	if now.Sub(session.LastSeenAt) < sessionTouchInterval {
+		return nil
+	}
+	return service.sessionRepository.TouchLastSeen(ctx, session.ID, now, now.Add(-sessionTouchInterval))
+}
+
+// truncate memotong s menjadi paling banyak max byte tanpa memotong karakter UTF-8 di tengah
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return strings.ToValidUTF8(s[:max], "")
+}
+
+// userAgentBrowsers dan userAgentPlatforms dicocokkan berurutan, token yang lebih spesifik harus didahulukan
+// (misalnya Edge dan Opera juga mengirim "Chrome", dan Android juga mengirim "Linux")
+var (
+	userAgentBrowsers = [][2]string{
+		{"Edg/", "Edge"}, {"OPR/", "Opera"}, {"Firefox/", "Firefox"}, {"Chrome/", "Chrome"},
+		{"Safari/", "Safari"}, {"curl/", "curl"}, {"PostmanRuntime/", "Postman"}, {"okhttp/", "Android app"},
+	}
+	userAgentPlatforms = [][2]string{
+		{"Windows", "Windows"}, {"Android", "Android"}, {"iPhone", "iPhone"}, {"iPad", "iPad"},
+		{"Mac OS X", "macOS"}, {"CrOS", "ChromeOS"}, {"Linux", "Linux"},
+	}
+)
+
+// describeDevice membuat nama perangkat sederhana seperti "Firefox on Windows" dari user agent
+func describeDevice(userAgent string) string {
+	browser, platform := "", ""
+	for _, b := range userAgentBrowsers {
+		if strings.Contains(userAgent, b[0]) {
+			browser = b[1]
+			break
+		}
+	}
+	for _, p := range userAgentPlatforms {
+		if strings.Contains(userAgent, p[0]) {
+			platform = p[1]
+			break
+		}
+	}
+	switch {
+	case browser != "" && platform != "":
+		return browser + " on " + platform
+	case browser != "":
+		return browser
+	case platform != "":
+		return platform
+	default:
+		return "Unknown device"
+	}
+}
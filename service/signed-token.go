@@ -0,0 +1,54 @@
+package service
+
+import (
+	"crypto/hmac"     // Mengimport package hmac untuk menandatangani token
+	"crypto/sha256"   // Mengimport package sha256 sebagai fungsi hash HMAC
+	"encoding/base64" // Mengimport package base64 untuk encoding tanda tangan
+	"strings"         // Mengimport package strings untuk menyusun dan memecah token
+)
+
+// tokenSigner membuat token pendek tanpa penyimpanan dengan format <field>.<field>...<tanda tangan>.
+// Kunci HMAC diturunkan dari secret dan purpose, sehingga token untuk satu keperluan tidak bisa dipakai
+// untuk keperluan lain walaupun secret-nya sama, misalnya JWT_SECRET.
+type tokenSigner struct {
+	key []byte // Kunci HMAC
+}
+
+// newTokenSigner adalah constructor untuk tokenSigner
+func newTokenSigner(secret string, purpose string) tokenSigner {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(purpose))
+	return tokenSigner{key: mac.Sum(nil)}
+}
+
+// sign membuat token dari fields. bound ikut ditandatangani tetapi tidak dimasukkan ke token, sehingga token
+// otomatis tidak berlaku jika nilai bound berubah, misalnya email atau hash password user.
+func (s tokenSigner) sign(bound string, fields ...string) string {
+	payload := strings.Join(fields, ".")
+	return payload + "." + s.signature(payload, bound)
+}
+
+// fields memecah token menjadi n field tanpa memeriksa tanda tangan, untuk mencari data yang dibutuhkan oleh valid
+func (s tokenSigner) fields(token string, n int) ([]string, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != n+1 {
+		return nil, false
+	}
+	return parts[:n], true
+}
+
+// valid memeriksa tanda tangan token terhadap bound
+func (s tokenSigner) valid(token string, bound string) bool {
+	i := strings.LastIndex(token, ".")
+	if i < 0 {
+		return false
+	}
+	return hmac.Equal([]byte(token[i+1:]), []byte(s.signature(token[:i], bound)))
+}
+
+// signature menghitung tanda tangan payload dan bound
+func (s tokenSigner) signature(payload string, bound string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(payload + "." + bound))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
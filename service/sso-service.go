@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"       // Mengimport package context untuk pembatalan dan tenggat waktu request
+	"crypto/subtle" // Mengimport package subtle untuk membandingkan state tanpa kebocoran waktu
+	"errors"        // Mengimport package errors untuk membandingkan error
+	"sort"          // Mengimport package sort untuk mengurutkan daftar provider
+	"strconv"       // Mengimport package strconv untuk membaca isi cookie state
+	"time"          // Mengimport package time untuk masa berlaku cookie state
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror"   // Mengimport package apperror untuk error domain
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/dto"        // Mengimport package dto untuk DTO (Data Transfer Object)
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity"     // Mengimport package entity untuk model entitas
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/helper"     // Mengimport package helper untuk membuat nilai acak
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/identity"   // Mengimport package identity untuk identity provider luar
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/logging"    // Mengimport package logging untuk mencatat penolakan dari provider
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/repository" // Mengimport package repository untuk interaksi dengan database
+	"golang.org/x/oauth2"                                       // Mengimport library OAuth2 untuk membuat PKCE code verifier
+)
+
+var (
+	// ErrUnknownProvider dikembalikan ketika nama identity provider tidak dikonfigurasi
+	ErrUnknownProvider = apperror.NotFound("identity provider not found").WithCode("sso_provider_not_found")
+	// ErrInvalidSSOState dikembalikan ketika cookie state hilang, rusak, kedaluwarsa, dibuat untuk provider lain, atau tidak sama dengan state dari provider
+	ErrInvalidSSOState = apperror.Invalid("invalid or expired login state, please start the login again").WithCode("sso_state_invalid")
+	// ErrSSODenied dikembalikan ketika provider mengembalikan error, misalnya user menolak memberi izin
+	ErrSSODenied = apperror.Unauthorized("login was cancelled or rejected by the identity provider").WithCode("sso_denied")
+	// ErrSSOEmailNotVerified dikembalikan ketika provider tidak menyatakan email user sudah terverifikasi
+	ErrSSOEmailNotVerified = apperror.Forbidden("the identity provider did not return a verified email address").WithCode("sso_email_not_verified")
+	// ErrSSOExchangeFailed dikembalikan ketika provider menolak authorization code atau ID token tidak valid
+	ErrSSOExchangeFailed = apperror.Unauthorized("the identity provider rejected the login").WithCode("sso_exchange_failed")
+	// ErrSSOAccountUnverified dikembalikan ketika email dari provider milik user lokal yang emailnya belum terverifikasi.
+	// Akun seperti itu bisa saja didaftarkan orang lain dengan password miliknya, sehingga tidak boleh dihubungkan.
+	ErrSSOAccountUnverified = apperror.Conflict("an account with this email exists but its email is not verified; verify it or reset its password, then sign in again").WithCode("sso_account_unverified")
+	// ErrSSOSignupDisabled dikembalikan ketika identitas belum terhubung ke user mana pun dan pendaftaran lewat provider dimatikan
+	ErrSSOSignupDisabled = apperror.Forbidden("no account is linked to this identity").WithCode("sso_signup_disabled")
+)
+
+// SSOService adalah interface yang mendefinisikan fungsi yang harus diimplementasikan oleh service login dengan identity provider luar
+type SSOService interface {
+	Providers() []string                                                                                                 // Fungsi untuk mendapatkan nama provider yang dikonfigurasi
+	Begin(ctx context.Context, provider string) (authURL string, state string, err error)                                // Fungsi untuk memulai login, mengembalikan URL provider dan nilai cookie state
+	Complete(ctx context.Context, provider string, state string, callback dto.SSOCallbackDTO) (entity.User, bool, error) // Fungsi untuk menyelesaikan login, mengembalikan user dan apakah user baru dibuat
+}
+
+// ssoService adalah implementasi dari SSOService.
+// State, nonce, dan PKCE code verifier tidak disimpan di server, melainkan di cookie yang ditandatangani dengan HMAC
+// dan terikat ke nama provider, sehingga callback hanya diterima dari browser yang memulai login.
+type ssoService struct {
+	providers          map[string]identity.Provider  // Identity provider berdasarkan nama
+	userRepository     repository.UserRepository     // Menggunakan repository untuk interaksi dengan database user
+	identityRepository repository.IdentityRepository // Menggunakan repository untuk interaksi dengan database identitas
+	signer             tokenSigner                   // Penanda tangan cookie state
+	stateTTL           time.Duration                 // Masa berlaku cookie state
+	allowSignup        bool                          // Apakah identitas baru boleh membuat user baru
+}
+
+// NewSSOService adalah constructor untuk ssoService, secret dipakai untuk menandatangani cookie state
+func NewSSOService(providers map[string]identity.Provider, userRepo repository.UserRepository, identityRepo repository.IdentityRepository, secret string, stateTTL time.Duration, allowSignup bool) SSOService {
+	return &ssoService{
+		providers:          providers,
+		userRepository:     userRepo,
+		identityRepository: identityRepo,
+		signer:             newTokenSigner(secret, "sso-state"),
+		stateTTL:           stateTTL,
+		allowSignup:        allowSignup,
+	}
+}
+
+// Providers adalah implementasi fungsi Providers dari SSOService
+func (service *ssoService) Providers() []string {
+	names := make([]string, 0, len(service.providers))
+	for name := range service.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Begin adalah implementasi fungsi Begin dari SSOService
+func (service *ssoService) Begin(ctx context.Context, provider string) (string, string, error) {
+	p, ok := service.providers[provider]
+	if !ok {
+		return "", "", ErrUnknownProvider
+	}
+	state, err := helper.GenerateRandomToken(24)
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := helper.GenerateRandomToken(24)
+	if err != nil {
+		return "", "", err
+	}
+	verifier := oauth2.GenerateVerifier()
+	authURL, err := p.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		return "", "", err // Discovery provider OIDC gagal, dianggap error internal
+	}
+	expires := time.Now().Add(service.stateTTL)
+	return authURL, service.signer.sign(provider, state, nonce, verifier, strconv.FormatInt(expires.Unix(), 10)), nil
+}
+
+// Complete adalah implementasi fungsi Complete dari SSOService.
+// Identitas yang sudah terhubung langsung dipakai. Identitas baru dihubungkan ke user dengan email yang sama jika provider
+// menyatakan email tersebut terverifikasi dan user sudah memverifikasi emailnya, atau membuat user baru jika belum ada dan pendaftaran diizinkan.
+func (service *ssoService) Complete(ctx context.Context, provider string, state string, callback dto.SSOCallbackDTO) (entity.User, bool, error) {
+	p, ok := service.providers[provider]
+	if !ok {
+		return entity.User{}, false, ErrUnknownProvider
+	}
+	parts, ok := service.signer.fields(state, 4) // Format cookie: <state>.<nonce>.<verifier>.<waktu kedaluwarsa>.<tanda tangan>
+	if !ok || !service.signer.valid(state, provider) {
+		return entity.User{}, false, ErrInvalidSSOState
+	}
+	expires, err := strconv.ParseInt(parts[3], 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return entity.User{}, false, ErrInvalidSSOState
+	}
+	if subtle.ConstantTimeCompare([]byte(parts[0]), []byte(callback.State)) != 1 { // Mencegah login CSRF dengan callback milik browser lain
+		return entity.User{}, false, ErrInvalidSSOState
+	}
+	if callback.Error != "" {
+		return entity.User{}, false, ErrSSODenied
+	}
+	if callback.Code == "" {
+		return entity.User{}, false, apperror.Invalid("missing authorization code").WithCode("sso_code_missing")
+	}
+	id, err := p.Exchange(ctx, callback.Code, parts[1], parts[2])
+	if errors.Is(err, identity.ErrExchangeFailed) { // Detail dari provider hanya dicatat di log, tidak dikirim ke client
+		logging.FromContext(ctx).Warn("sso exchange failed", "provider", provider, "error", err)
+		return entity.User{}, false, ErrSSOExchangeFailed
+	}
+	if err != nil {
+		return entity.User{}, false, err
+	}
+	return service.resolve(ctx, provider, id)
+}
+
+// resolve mencari atau membuat user untuk identitas dari provider
+func (service *ssoService) resolve(ctx context.Context, provider string, id identity.Identity) (entity.User, bool, error) {
+	linked, err := service.identityRepository.FindBySubject(ctx, provider, id.Subject)
+	if err == nil {
+		user, err := service.userRepository.FindByID(ctx, linked.UserID)
+		return user, false, err
+	}
+	if !errors.Is(err, apperror.ErrNotFound) {
+		return entity.User{}, false, err
+	}
+	if id.Email == "" || !id.EmailVerified { // Tanpa email terverifikasi, identitas bisa dipakai untuk mengambil alih akun orang lain
+		return entity.User{}, false, ErrSSOEmailNotVerified
+	}
+	created := false
+	user, err := service.userRepository.FindByEmail(ctx, id.Email)
+	switch {
+	case errors.Is(err, apperror.ErrNotFound):
+		if !service.allowSignup {
+			return entity.User{}, false, ErrSSOSignupDisabled
+		}
+		if user, err = service.createUser(ctx, id); err != nil {
+			return entity.User{}, false, err
+		}
+		created = true
+	case err != nil:
+		return entity.User{}, false, err
+	case user.EmailVerifiedAt == nil: // Password akun ini belum tentu milik pemilik email, menghubungkannya membuka jalan pengambilalihan akun
+		return entity.User{}, false, ErrSSOAccountUnverified
+	}
+	_, err = service.identityRepository.InsertIdentity(ctx, entity.UserIdentity{UserID: user.ID, Provider: provider, Subject: id.Subject, Email: id.Email})
+	if err != nil {
+		return entity.User{}, false, err // Conflict jika callback yang sama diproses bersamaan
+	}
+	return user, created, nil
+}
+
+// createUser membuat user baru dengan email terverifikasi dan password acak, user bisa memasang password lewat reset password
+func (service *ssoService) createUser(ctx context.Context, id identity.Identity) (entity.User, error) {
+	password, err := helper.GenerateRandomToken(32)
+	if err != nil {
+		return entity.User{}, err
+	}
+	name := id.Name
+	if name == "" {
+		name = id.Email
+	}
+	now := time.Now()
+	return service.userRepository.InsertUser(ctx, entity.User{
+		Name:            name,
+		Email:           id.Email,
+		Password:        password,
+		Role:            entity.RoleReader, // User baru selalu mendapat role reader
+		EmailVerifiedAt: &now,
+	})
+}
@@ -0,0 +1,169 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/dto"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/identity"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/identity/identitytest"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/password"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/repository"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ssoTest menyatukan provider palsu, SSOService, dan repository user di atas database SQLite di memori
+type ssoTest struct {
+	server     *identitytest.OIDCServer
+	sso        service.SSOService
+	users      repository.UserRepository
+	identities repository.IdentityRepository
+}
+
+func newSSOTest(t *testing.T) *ssoTest {
+	t.Helper()
+	db := newTestDB(t)
+	server := identitytest.NewOIDCServer("bookstore", "client-secret")
+	t.Cleanup(server.Close)
+	provider := identity.NewOIDCProvider(identity.OIDCConfig{
+		Issuer:       server.URL,
+		ClientID:     "bookstore",
+		ClientSecret: "client-secret",
+		RedirectURL:  "http://localhost:9090/api/auth/sso/sso/callback",
+	})
+	users := repository.NewUserRepository(db, password.NewHasher(password.BcryptHasher{Cost: bcrypt.MinCost}))
+	identities := repository.NewIdentityRepository(db)
+	sso := service.NewSSOService(map[string]identity.Provider{"sso": provider}, users, identities, "state-secret", time.Minute, true)
+	return &ssoTest{server: server, sso: sso, users: users, identities: identities}
+}
+
+// login menjalankan login lengkap: Begin, persetujuan di provider palsu, lalu callback ke Complete
+func (st *ssoTest) login(t *testing.T, user identitytest.User) (entity.User, bool, error) {
+	t.Helper()
+	st.server.SetUser(user)
+	ctx := context.Background()
+	authURL, cookie, err := st.sso.Begin(ctx, "sso")
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	code, state, err := st.server.Authorize(authURL)
+	if err != nil {
+		t.Fatalf("Authorize: %v", err)
+	}
+	return st.sso.Complete(ctx, "sso", cookie, dto.SSOCallbackDTO{Code: code, State: state})
+}
+
+// verifiedUser mengembalikan user di provider dengan email terverifikasi
+func verifiedUser(subject string, email string) identitytest.User {
+	verified := true
+	return identitytest.User{Subject: subject, Email: email, EmailVerified: &verified, Name: "Ana"}
+}
+
+func TestSSOCompleteCreatesUserAndReusesIdentity(t *testing.T) {
+	st := newSSOTest(t)
+
+	user, created, err := st.login(t, verifiedUser("sub-1", "ana@example.com"))
+	if err != nil {
+		t.Fatalf("first login: %v", err)
+	}
+	if !created || user.Email != "ana@example.com" || user.EmailVerifiedAt == nil || user.Role != entity.RoleReader {
+		t.Fatalf("first login: created = %v, user = %+v", created, user)
+	}
+
+	// Login berikutnya dikenali dari subject walaupun email di provider sudah berubah
+	again, created, err := st.login(t, verifiedUser("sub-1", "ana@new.example.com"))
+	if err != nil {
+		t.Fatalf("second login: %v", err)
+	}
+	if created || again.ID != user.ID {
+		t.Fatalf("second login: created = %v, user ID = %d, want existing user %d", created, again.ID, user.ID)
+	}
+}
+
+func TestSSOCompleteLinksVerifiedLocalAccount(t *testing.T) {
+	st := newSSOTest(t)
+	now := time.Now()
+	local, err := st.users.InsertUser(context.Background(), entity.User{Name: "Ana", Email: "ana@example.com", Password: "Local-Passw0rd", Role: entity.RoleEditor, EmailVerifiedAt: &now})
+	if err != nil {
+		t.Fatalf("insert user: %v", err)
+	}
+
+	user, created, err := st.login(t, verifiedUser("sub-1", "ana@example.com"))
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	if created || user.ID != local.ID || user.Role != entity.RoleEditor {
+		t.Fatalf("login: created = %v, user = %+v, want local user %d", created, user, local.ID)
+	}
+	linked, err := st.identities.FindBySubject(context.Background(), "sso", "sub-1")
+	if err != nil || linked.UserID != local.ID {
+		t.Fatalf("identity link = %+v, err = %v, want user %d", linked, err, local.ID)
+	}
+}
+
+func TestSSOCompleteRejectsUnverifiedLocalAccount(t *testing.T) {
+	st := newSSOTest(t)
+	// Orang lain mendaftarkan email korban dengan password miliknya dan tidak pernah memverifikasinya
+	squatter, err := st.users.InsertUser(context.Background(), entity.User{Name: "Mallory", Email: "ana@example.com", Password: "Mallory-Passw0rd", Role: entity.RoleReader})
+	if err != nil {
+		t.Fatalf("insert user: %v", err)
+	}
+
+	_, _, err = st.login(t, verifiedUser("sub-1", "ana@example.com"))
+	if !errors.Is(err, service.ErrSSOAccountUnverified) {
+		t.Fatalf("login: err = %v, want ErrSSOAccountUnverified", err)
+	}
+	if _, err := st.identities.FindBySubject(context.Background(), "sso", "sub-1"); err == nil {
+		t.Fatal("identity was linked to the unverified account")
+	}
+	user, err := st.users.FindByID(context.Background(), squatter.ID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	if user.EmailVerifiedAt != nil {
+		t.Fatal("unverified account was marked verified")
+	}
+}
+
+func TestSSOCompleteRejectsUnverifiedProviderEmail(t *testing.T) {
+	st := newSSOTest(t)
+	unverified := false
+
+	_, _, err := st.login(t, identitytest.User{Subject: "sub-1", Email: "ana@example.com", EmailVerified: &unverified})
+	if !errors.Is(err, service.ErrSSOEmailNotVerified) {
+		t.Fatalf("email_verified false: err = %v, want ErrSSOEmailNotVerified", err)
+	}
+	_, _, err = st.login(t, identitytest.User{Subject: "sub-2", Email: "ana@example.com"})
+	if !errors.Is(err, service.ErrSSOEmailNotVerified) {
+		t.Fatalf("email_verified missing: err = %v, want ErrSSOEmailNotVerified", err)
+	}
+}
+
+func TestSSOCompleteRejectsForeignState(t *testing.T) {
+	st := newSSOTest(t)
+	st.server.SetUser(verifiedUser("sub-1", "ana@example.com"))
+	ctx := context.Background()
+	authURL, _, err := st.sso.Begin(ctx, "sso")
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	code, state, err := st.server.Authorize(authURL)
+	if err != nil {
+		t.Fatalf("Authorize: %v", err)
+	}
+	_, otherCookie, err := st.sso.Begin(ctx, "sso") // Cookie state dari browser lain
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+
+	if _, _, err := st.sso.Complete(ctx, "sso", otherCookie, dto.SSOCallbackDTO{Code: code, State: state}); !errors.Is(err, service.ErrInvalidSSOState) {
+		t.Fatalf("foreign state: err = %v, want ErrInvalidSSOState", err)
+	}
+	if _, _, err := st.sso.Complete(ctx, "sso", "", dto.SSOCallbackDTO{Code: code, State: state}); !errors.Is(err, service.ErrInvalidSSOState) {
+		t.Fatalf("missing cookie: err = %v, want ErrInvalidSSOState", err)
+	}
+}
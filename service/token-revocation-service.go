@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context" // Mengimport package context untuk pembatalan dan tenggat waktu request
+	"fmt"     // Mengimport package fmt untuk formatting
+	"strconv" // Mengimport package strconv untuk konversi user ID
+	"time"    // Mengimport package time untuk mengelola waktu
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror"   // Mengimport package apperror untuk error domain
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/repository" // Mengimport package repository untuk penyimpanan daftar pencabutan
+	"github.com/golang-jwt/jwt/v4"                              // Mengimport package golang-jwt untuk membaca claims token
+)
+
+// ErrTokenMissingClaims dikembalikan ketika token tidak memiliki claim yang dibutuhkan untuk pencabutan
+var ErrTokenMissingClaims = apperror.Unauthorized("token is missing required claims").WithCode("token_invalid")
+
+// TokenRevocationService adalah interface yang mendefinisikan fungsi yang harus diimplementasikan oleh service pencabutan token
+type TokenRevocationService interface {
+	Revoke(ctx context.Context, token *jwt.Token) error                  // Fungsi untuk mencabut satu access token (logout)
+	RevokeID(ctx context.Context, jti string, expiresAt time.Time) error // Fungsi untuk mencabut satu access token berdasarkan jti, tanpa token utuhnya
+	RevokeAllForUser(ctx context.Context, userID uint64) error           // Fungsi untuk mencabut semua access token dan refresh token milik user
+	IsRevoked(ctx context.Context, token *jwt.Token) (bool, error)       // Fungsi untuk memeriksa apakah access token sudah dicabut
+}
+
+// tokenRevocationService adalah implementasi dari TokenRevocationService
+type tokenRevocationService struct {
+	revocationStore        repository.RevocationStore        // Penyimpanan daftar token yang dicabut
+	refreshTokenRepository repository.RefreshTokenRepository // Repository refresh token untuk mencabut sesi yang bisa diperpanjang
+}
+
+// NewTokenRevocationService adalah constructor untuk tokenRevocationService
+func NewTokenRevocationService(store repository.RevocationStore, refreshTokenRepo repository.RefreshTokenRepository) TokenRevocationService {
+	return &tokenRevocationService{
+		revocationStore:        store,
+		refreshTokenRepository: refreshTokenRepo,
+	}
+}
+
+// Revoke adalah implementasi fungsi Revoke dari TokenRevocationService
+func (service *tokenRevocationService) Revoke(ctx context.Context, token *jwt.Token) error {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return ErrTokenMissingClaims
+	}
+	jti, _ := claims["jti"].(string)
+	exp, ok := claims["exp"].(float64)
+	if jti == "" || !ok {
+		return ErrTokenMissingClaims
+	}
+	return service.revocationStore.RevokeToken(ctx, jti, time.Unix(int64(exp), 0)) // Token cukup disimpan sampai waktu kedaluwarsanya
+}
+
+// RevokeID adalah implementasi fungsi RevokeID dari TokenRevocationService
+func (service *tokenRevocationService) RevokeID(ctx context.Context, jti string, expiresAt time.Time) error {
+	return service.revocationStore.RevokeToken(ctx, jti, expiresAt)
+}
+
+// RevokeAllForUser adalah implementasi fungsi RevokeAllForUser dari TokenRevocationService
+func (service *tokenRevocationService) RevokeAllForUser(ctx context.Context, userID uint64) error {
+	if err := service.refreshTokenRepository.RevokeAllForUser(ctx, userID); err != nil { // Refresh token dicabut agar sesi tidak bisa diperpanjang
+		return err
+	}
+	// Access token yang sudah terbit sebelum saat ini ditolak. Batas dibulatkan ke bawah ke milidetik, presisi claim iat_ms,
+	// agar database yang membulatkan pecahan detik (MySQL datetime(3)) tidak menggeser batas melewati token yang terbit sesudahnya.
+	return service.revocationStore.RevokeAllForUser(ctx, userID, time.Now().Truncate(time.Millisecond))
+}
+
+// IsRevoked adalah implementasi fungsi IsRevoked dari TokenRevocationService
+func (service *tokenRevocationService) IsRevoked(ctx context.Context, token *jwt.Token) (bool, error) {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return false, ErrTokenMissingClaims
+	}
+	if jti, _ := claims["jti"].(string); jti != "" {
+		revoked, err := service.revocationStore.IsTokenRevoked(ctx, jti)
+		if err != nil || revoked {
+			return revoked, err
+		}
+	}
+	userID, err := strconv.ParseUint(fmt.Sprintf("%v", claims["user_id"]), 10, 64)
+	if err != nil {
+		return false, ErrTokenMissingClaims
+	}
+	before, err := service.revocationStore.RevokedBefore(ctx, userID)
+	if err != nil || before.IsZero() {
+		return false, err
+	}
+	// Token yang terbit pada milidetik yang sama dengan pencabutan tetap diterima,
+	// agar token dari login tepat setelah logout semua sesi dan reset password tidak ikut ditolak.
+	if iatMs, ok := claims["iat_ms"].(float64); ok {
+		return int64(iatMs) < before.UnixMilli(), nil
+	}
+	// Token tanpa iat_ms hanya punya iat berpresisi detik, token dari detik yang sama dengan pencabutan ikut ditolak
+	iat, _ := claims["iat"].(float64)
+	return int64(iat) <= before.Unix(), nil
+}
@@ -0,0 +1,83 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/repository"
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+func TestIsRevokedComparesIssuedAtInMilliseconds(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	store := repository.NewRevocationStore(db)
+	revocation := service.NewTokenRevocationService(store, repository.NewRefreshTokenRepository(db))
+	if err := revocation.RevokeAllForUser(ctx, 1); err != nil {
+		t.Fatalf("RevokeAllForUser: %v", err)
+	}
+	before, err := store.RevokedBefore(ctx, 1)
+	if err != nil {
+		t.Fatalf("RevokedBefore: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		claims  jwt.MapClaims
+		revoked bool
+	}{
+		{"one millisecond before", jwt.MapClaims{"iat_ms": float64(before.UnixMilli() - 1), "iat": float64(before.Unix())}, true},
+		{"same millisecond", jwt.MapClaims{"iat_ms": float64(before.UnixMilli()), "iat": float64(before.Unix())}, false},
+		{"after", jwt.MapClaims{"iat_ms": float64(before.UnixMilli() + 1), "iat": float64(before.Unix())}, false},
+		{"without iat_ms in the same second", jwt.MapClaims{"iat": float64(before.Unix())}, true},
+		{"without iat_ms in the next second", jwt.MapClaims{"iat": float64(before.Unix() + 1)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.claims["user_id"] = "1"
+			revoked, err := revocation.IsRevoked(ctx, &jwt.Token{Claims: tt.claims})
+			if err != nil {
+				t.Fatalf("IsRevoked: %v", err)
+			}
+			if revoked != tt.revoked {
+				t.Fatalf("revoked = %v, want %v (revoked_before %s)", revoked, tt.revoked, before.Format(time.RFC3339Nano))
+			}
+		})
+	}
+}
+
+func TestIsRevokedRejectsTokenIssuedBeforeRevokeAll(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	revocation := service.NewTokenRevocationService(repository.NewRevocationStore(db), repository.NewRefreshTokenRepository(db))
+	jwtService := service.NewJWTService(time.Minute, service.NewHMACKey("test-secret"))
+	issue := func() *jwt.Token {
+		t.Helper()
+		signed, err := jwtService.GenerateToken("1", "reader")
+		if err != nil {
+			t.Fatalf("GenerateToken: %v", err)
+		}
+		token, err := jwtService.ValidateToken(signed)
+		if err != nil {
+			t.Fatalf("ValidateToken: %v", err)
+		}
+		return token
+	}
+
+	old := issue()
+	time.Sleep(2 * time.Millisecond) // Token lama dan pencabutan berada pada milidetik berbeda, biasanya tetap pada detik yang sama
+	if err := revocation.RevokeAllForUser(ctx, 1); err != nil {
+		t.Fatalf("RevokeAllForUser: %v", err)
+	}
+	time.Sleep(2 * time.Millisecond)
+	fresh := issue()
+
+	if revoked, err := revocation.IsRevoked(ctx, old); err != nil || !revoked {
+		t.Fatalf("token issued before revoke-all: revoked = %v, err = %v", revoked, err)
+	}
+	if revoked, err := revocation.IsRevoked(ctx, fresh); err != nil || revoked {
+		t.Fatalf("token issued after revoke-all: revoked = %v, err = %v", revoked, err)
+	}
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context" // Mengimport package context untuk membawa span
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/dto"     // Mengimport package dto untuk DTO (Data Transfer Object)
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity"  // Mengimport package entity untuk model entitas
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/helper"  // Mengimport package helper untuk metadata pagination
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/tracing" // Mengimport package tracing untuk membuat span
+	"go.opentelemetry.io/otel/attribute"                     // Mengimport attribute span
+)
+
+// tracedBookService adalah BookService yang membuat span untuk setiap pemanggilan service di dalamnya
+type tracedBookService struct {
+	next BookService // BookService yang dibungkus
+}
+
+// NewTracedBookService membungkus next agar setiap pemanggilan tercatat sebagai span
+func NewTracedBookService(next BookService) BookService {
+	return &tracedBookService{next: next}
+}
+
+func (s *tracedBookService) Insert(ctx context.Context, b dto.BookCreateDTO) (book entity.Book, err error) {
+	ctx, span := tracing.Start(ctx, "BookService.Insert", tracing.UserID(b.UserID))
+	defer func() { span.SetAttributes(tracing.BookID(book.ID)); tracing.End(span, err) }()
+	return s.next.Insert(ctx, b)
+}
+
+func (s *tracedBookService) Update(ctx context.Context, b dto.BookUpdateDTO) (book entity.Book, err error) {
+	ctx, span := tracing.Start(ctx, "BookService.Update", tracing.BookID(b.ID), tracing.UserID(b.UserID))
+	defer func() { tracing.End(span, err) }()
+	return s.next.Update(ctx, b)
+}
+
+func (s *tracedBookService) Delete(ctx context.Context, b entity.Book) (err error) {
+	ctx, span := tracing.Start(ctx, "BookService.Delete", tracing.BookID(b.ID))
+	defer func() { tracing.End(span, err) }()
+	return s.next.Delete(ctx, b)
+}
+
+func (s *tracedBookService) Page(ctx context.Context, q dto.BookQueryDTO) (books []entity.Book, meta helper.PageMeta, err error) {
+	ctx, span := tracing.Start(ctx, "BookService.Page", attribute.Int("page.number", q.Page), attribute.Int("page.per_page", q.PerPage))
+	defer func() { span.SetAttributes(attribute.Int("page.results", len(books))); tracing.End(span, err) }()
+	return s.next.Page(ctx, q)
+}
+
+func (s *tracedBookService) Search(ctx context.Context, q dto.BookSearchDTO) (results []dto.BookSearchResultDTO, meta helper.PageMeta, err error) {
+	ctx, span := tracing.Start(ctx, "BookService.Search")
+	defer func() { span.SetAttributes(attribute.Int("page.results", len(results))); tracing.End(span, err) }()
+	return s.next.Search(ctx, q)
+}
+
+func (s *tracedBookService) FindByID(ctx context.Context, bookID uint64) (book entity.Book, err error) {
+	ctx, span := tracing.Start(ctx, "BookService.FindByID", tracing.BookID(bookID))
+	defer func() { tracing.End(span, err) }()
+	return s.next.FindByID(ctx, bookID)
+}
+
+func (s *tracedBookService) AuthorizeEdit(ctx context.Context, userID uint64, role string, bookID uint64) (book entity.Book, err error) {
+	ctx, span := tracing.Start(ctx, "BookService.AuthorizeEdit", tracing.UserID(userID), tracing.BookID(bookID), attribute.String("user.role", role))
+	defer func() { tracing.End(span, err) }()
+	return s.next.AuthorizeEdit(ctx, userID, role, bookID)
+}
+
+// tracedUserService adalah UserService yang membuat span untuk setiap pemanggilan service di dalamnya
+type tracedUserService struct {
+	next UserService // UserService yang dibungkus
+}
+
+// NewTracedUserService membungkus next agar setiap pemanggilan tercatat sebagai span
+func NewTracedUserService(next UserService) UserService {
+	return &tracedUserService{next: next}
+}
+
+func (s *tracedUserService) Update(ctx context.Context, user dto.UserUpdateDTO) (res entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.Update", tracing.UserID(user.ID))
+	defer func() { tracing.End(span, err) }()
+	return s.next.Update(ctx, user)
+}
+
+func (s *tracedUserService) ChangePassword(ctx context.Context, userID uint64, current string, password string) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.ChangePassword", tracing.UserID(userID))
+	defer func() { tracing.End(span, err) }()
+	return s.next.ChangePassword(ctx, userID, current, password)
+}
+
+func (s *tracedUserService) ChangeEmail(ctx context.Context, userID uint64, current string, email string) (res entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.ChangeEmail", tracing.UserID(userID))
+	defer func() { tracing.End(span, err) }()
+	return s.next.ChangeEmail(ctx, userID, current, email)
+}
+
+func (s *tracedUserService) Profile(ctx context.Context, userID uint64) (res entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.Profile", tracing.UserID(userID))
+	defer func() { tracing.End(span, err) }()
+	return s.next.Profile(ctx, userID)
+}
+
+func (s *tracedUserService) All(ctx context.Context) (users []entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.All")
+	defer func() { tracing.End(span, err) }()
+	return s.next.All(ctx)
+}
+
+func (s *tracedUserService) SetRole(ctx context.Context, userID uint64, role string) (res entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.SetRole", tracing.UserID(userID), attribute.String("user.role", role))
+	defer func() { tracing.End(span, err) }()
+	return s.next.SetRole(ctx, userID, role)
+}
+
+func (s *tracedUserService) SetSuspended(ctx context.Context, userID uint64, suspended bool) (res entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.SetSuspended", tracing.UserID(userID), attribute.Bool("user.suspended", suspended))
+	defer func() { tracing.End(span, err) }()
+	return s.next.SetSuspended(ctx, userID, suspended)
+}
+
+func (s *tracedUserService) ResetPassword(ctx context.Context, userID uint64, password string) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.ResetPassword", tracing.UserID(userID))
+	defer func() { tracing.End(span, err) }()
+	return s.next.ResetPassword(ctx, userID, password)
+}
+
+func (s *tracedUserService) Unlock(ctx context.Context, userID uint64) (res entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.Unlock", tracing.UserID(userID))
+	defer func() { tracing.End(span, err) }()
+	return s.next.Unlock(ctx, userID)
+}
+
+// tracedAuthService adalah AuthService yang membuat span untuk setiap pemanggilan service di dalamnya.
+// Email dan password tidak dijadikan attribute span.
+type tracedAuthService struct {
+	next AuthService // AuthService yang dibungkus
+}
+
+// NewTracedAuthService membungkus next agar setiap pemanggilan tercatat sebagai span
+func NewTracedAuthService(next AuthService) AuthService {
+	return &tracedAuthService{next: next}
+}
+
+func (s *tracedAuthService) VerifyCredential(ctx context.Context, email string, password string) (user entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.VerifyCredential")
+	defer func() { span.SetAttributes(tracing.UserID(user.ID)); tracing.End(span, err) }()
+	return s.next.VerifyCredential(ctx, email, password)
+}
+
+func (s *tracedAuthService) CreateUser(ctx context.Context, register dto.RegisterDTO) (user entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.CreateUser")
+	defer func() { span.SetAttributes(tracing.UserID(user.ID)); tracing.End(span, err) }()
+	return s.next.CreateUser(ctx, register)
+}
+
+func (s *tracedAuthService) FindByEmail(ctx context.Context, email string) (user entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.FindByEmail")
+	defer func() { span.SetAttributes(tracing.UserID(user.ID)); tracing.End(span, err) }()
+	return s.next.FindByEmail(ctx, email)
+}
+
+func (s *tracedAuthService) IsDuplicateEmail(ctx context.Context, email string) (duplicate bool, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.IsDuplicateEmail")
+	defer func() { tracing.End(span, err) }()
+	return s.next.IsDuplicateEmail(ctx, email)
+}
+
+func (s *tracedAuthService) FindByID(ctx context.Context, userID uint64) (user entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.FindByID", tracing.UserID(userID))
+	defer func() { tracing.End(span, err) }()
+	return s.next.FindByID(ctx, userID)
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"fmt"     // Mengimport package fmt untuk pesan error
+	"sort"    // Mengimport package sort untuk mengurutkan daftar aksi
+	"strings" // Mengimport package strings untuk menggabungkan daftar aksi
+)
+
+// Aksi yang bisa dibatasi untuk user yang belum memverifikasi email
+const (
+	ActionBookCreate    = "book:create"    // ActionBookCreate adalah membuat buku baru
+	ActionBookUpdate    = "book:update"    // ActionBookUpdate adalah mengubah buku
+	ActionBookDelete    = "book:delete"    // ActionBookDelete adalah menghapus buku
+	ActionProfileUpdate = "profile:update" // ActionProfileUpdate adalah mengubah profil
+)
+
+// knownActions adalah semua aksi yang bisa dibatasi
+var knownActions = map[string]bool{
+	ActionBookCreate:    true,
+	ActionBookUpdate:    true,
+	ActionBookDelete:    true,
+	ActionProfileUpdate: true,
+}
+
+// UnverifiedPolicy adalah daftar aksi yang tidak boleh dilakukan oleh user yang belum memverifikasi email
+type UnverifiedPolicy struct {
+	denied map[string]bool // Aksi yang dilarang
+}
+
+// NewUnverifiedPolicy membuat UnverifiedPolicy yang melarang aksi pada denied. Aksi yang tidak dikenal menghasilkan error.
+func NewUnverifiedPolicy(denied []string) (UnverifiedPolicy, error) {
+	policy := UnverifiedPolicy{denied: map[string]bool{}}
+	for _, action := range denied {
+		if !knownActions[action] {
+			return UnverifiedPolicy{}, fmt.Errorf("unknown action %q, expected one of %s", action, strings.Join(KnownActions(), ", "))
+		}
+		policy.denied[action] = true
+	}
+	return policy, nil
+}
+
+// Allows memeriksa apakah user yang belum memverifikasi email boleh melakukan action
+func (p UnverifiedPolicy) Allows(action string) bool {
+	return !p.denied[action]
+}
+
+// KnownActions mengembalikan semua aksi yang bisa dibatasi, urut berdasarkan nama
+func KnownActions() []string {
+	actions := make([]string, 0, len(knownActions))
+	for action := range knownActions {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	return actions
+}
@@ -1,44 +1,172 @@
 package service
 
 import (
-	"log" // Mengimport package log untuk logging
+	"context" // Mengimport package context untuk pembatalan dan tenggat waktu request
+	"time"    // Mengimport package time untuk memeriksa penguncian akun
 
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror"   // Mengimport package apperror untuk error domain
 	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/dto"        // Mengimport package dto untuk DTO (Data Transfer Object)
 	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity"     // Mengimport package entity untuk model entitas
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/password"   // Mengimport package password untuk memeriksa password saat ini
 	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/repository" // Mengimport package repository untuk interaksi dengan database
 	"github.com/mashingan/smapping"                             // Mengimport package smapping untuk mapping struct
 )
 
+// ErrInvalidRole dikembalikan ketika role yang diminta tidak dikenal
+var ErrInvalidRole = apperror.Invalid("invalid role").WithCode("invalid_role")
+
+// ErrWrongCurrentPassword dikembalikan ketika password saat ini yang dikirim untuk mengganti email atau password salah
+var ErrWrongCurrentPassword = apperror.Forbidden("current password is incorrect").WithCode("wrong_current_password")
+
 // UserService adalah interface yang mendefinisikan fungsi yang harus diimplementasikan oleh service user
 type UserService interface {
-	Update(user dto.UserUpdateDTO) entity.User // Fungsi untuk mengupdate user
-	Profile(userID string) entity.User         // Fungsi untuk mendapatkan profil user berdasarkan ID
+	Update(ctx context.Context, user dto.UserUpdateDTO) (entity.User, error)                           // Fungsi untuk mengupdate profil user
+	ChangePassword(ctx context.Context, userID uint64, current string, password string) error          // Fungsi untuk mengganti password setelah memeriksa password saat ini dan mencabut semua sesi
+	ChangeEmail(ctx context.Context, userID uint64, current string, email string) (entity.User, error) // Fungsi untuk mengganti email setelah memeriksa password saat ini dan mencabut semua sesi
+	Profile(ctx context.Context, userID uint64) (entity.User, error)                                   // Fungsi untuk mendapatkan profil user berdasarkan ID
+	All(ctx context.Context) ([]entity.User, error)                                                    // Fungsi untuk mendapatkan semua user (khusus admin)
+	SetRole(ctx context.Context, userID uint64, role string) (entity.User, error)                      // Fungsi untuk mengubah role user (khusus admin)
+	SetSuspended(ctx context.Context, userID uint64, suspended bool) (entity.User, error)              // Fungsi untuk memblokir atau membuka blokir user (khusus admin)
+	ResetPassword(ctx context.Context, userID uint64, password string) error                           // Fungsi untuk mengganti password user dan mencabut semua sesinya
+	Unlock(ctx context.Context, userID uint64) (entity.User, error)                                    // Fungsi untuk membuka kunci akun yang terkunci karena login gagal (khusus admin)
 }
 
 // userService adalah implementasi dari UserService
 type userService struct {
-	userRepository repository.UserRepository // Menggunakan repository untuk interaksi dengan database user
+	userRepository    repository.UserRepository // Menggunakan repository untuk interaksi dengan database user
+	revocationService TokenRevocationService    // Menggunakan service pencabutan token agar perubahan hak akses langsung berlaku
+	verification      EmailVerificationService  // Menggunakan service verifikasi email ketika user mengganti email
+	hasher            password.Hasher           // Hasher untuk memeriksa password saat ini
+	policy            PasswordPolicy            // Kebijakan password baru saat mengganti dan reset password
+	lockout           LockoutPolicy             // Kebijakan penguncian akun, password saat ini yang salah dihitung sebagai login gagal
 }
 
 // NewUserService adalah constructor untuk userService
-func NewUserService(userRepo repository.UserRepository) UserService {
+func NewUserService(userRepo repository.UserRepository, revocationService TokenRevocationService, verification EmailVerificationService, hasher password.Hasher, policy PasswordPolicy, lockout LockoutPolicy) UserService {
 	return &userService{
-		userRepository: userRepo,
+		userRepository:    userRepo,
+		revocationService: revocationService,
+		verification:      verification,
+		hasher:            hasher,
+		policy:            policy,
+		lockout:           lockout,
 	}
 }
 
 // Update adalah implementasi fungsi Update dari UserService
-func (service *userService) Update(user dto.UserUpdateDTO) entity.User {
+func (service *userService) Update(ctx context.Context, user dto.UserUpdateDTO) (entity.User, error) {
 	userToUpdate := entity.User{}                                        // Mendeklarasikan variabel untuk menyimpan data user yang akan diupdate
 	err := smapping.FillStruct(&userToUpdate, smapping.MapFields(&user)) // Mengisi struct userToUpdate dengan data dari DTO
 	if err != nil {
-		log.Fatalf("Failed map %v:", err) // Jika terjadi error pada mapping, program akan berhenti dan menampilkan pesan error
+		return entity.User{}, apperror.Internal("failed to map user", err) // Error mapping dikembalikan, tidak menghentikan server
+	}
+	if _, err := service.userRepository.FindByID(ctx, user.ID); err != nil {
+		return entity.User{}, err
+	}
+	return service.userRepository.UpdateUser(ctx, userToUpdate) // Memanggil repository untuk melakukan update data user
+}
+
+// ChangePassword adalah implementasi fungsi ChangePassword dari UserService.
+// Semua sesi dicabut agar pihak yang mungkin mengetahui password lama tidak tetap login.
+func (service *userService) ChangePassword(ctx context.Context, userID uint64, current string, password string) error {
+	if _, err := service.checkCurrentPassword(ctx, userID, current); err != nil {
+		return err
 	}
-	updateUser := service.userRepository.UpdateUser(userToUpdate) // Memanggil repository untuk melakukan update data user
-	return updateUser                                             // Mengembalikan data user yang telah diupdate
+	return service.ResetPassword(ctx, userID, password) // Memeriksa kebijakan password, mengganti password, dan mencabut semua sesi
+}
+
+// ChangeEmail adalah implementasi fungsi ChangeEmail dari UserService.
+// Email baru harus diverifikasi ulang, sehingga link verifikasi dikirim ke email baru, lalu semua sesi dicabut.
+func (service *userService) ChangeEmail(ctx context.Context, userID uint64, current string, email string) (entity.User, error) {
+	user, err := service.checkCurrentPassword(ctx, userID, current)
+	if err != nil || user.Email == email {
+		return user, err
+	}
+	if err := service.userRepository.UpdateEmail(ctx, userID, email); err != nil { // Conflict jika email sudah dipakai user lain
+		return entity.User{}, err
+	}
+	if err := service.revocationService.RevokeAllForUser(ctx, userID); err != nil {
+		return entity.User{}, err
+	}
+	user.Email, user.EmailVerifiedAt = email, nil
+	return user, service.verification.SendVerification(ctx, user)
+}
+
+// checkCurrentPassword memeriksa password saat ini sebelum email atau password diganti.
+// Password yang salah dihitung sebagai login gagal, sehingga token yang dicuri tidak bisa dipakai untuk menebak password tanpa batas.
+func (service *userService) checkCurrentPassword(ctx context.Context, userID uint64, current string) (entity.User, error) {
+	user, err := service.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return entity.User{}, err
+	}
+	now := time.Now()
+	if user.LockedUntil != nil && now.Before(*user.LockedUntil) { // Akun masih dikunci
+		return entity.User{}, ErrAccountLocked.WithRetryAfter(user.LockedUntil.Sub(now))
+	}
+	if ok, _ := service.hasher.Verify(user.Password, current); !ok {
+		return entity.User{}, recordFailedLogin(ctx, service.userRepository, service.lockout, user.ID, now, ErrWrongCurrentPassword)
+	}
+	return user, nil
 }
 
 // Profile adalah implementasi fungsi Profile dari UserService
-func (service *userService) Profile(userID string) entity.User {
-	return service.userRepository.ProfileUser(userID) // Memanggil repository untuk mendapatkan profil user berdasarkan ID
+func (service *userService) Profile(ctx context.Context, userID uint64) (entity.User, error) {
+	return service.userRepository.ProfileUser(ctx, userID) // Memanggil repository untuk mendapatkan profil user berdasarkan ID
+}
+
+// All adalah implementasi fungsi All dari UserService
+func (service *userService) All(ctx context.Context) ([]entity.User, error) {
+	return service.userRepository.AllUser(ctx) // Memanggil repository untuk mendapatkan semua user
+}
+
+// SetRole adalah implementasi fungsi SetRole dari UserService.
+// Semua sesi user dicabut agar token dengan role lama tidak bisa dipakai lagi.
+func (service *userService) SetRole(ctx context.Context, userID uint64, role string) (entity.User, error) {
+	if !entity.IsValidRole(role) {
+		return entity.User{}, ErrInvalidRole
+	}
+	if _, err := service.userRepository.FindByID(ctx, userID); err != nil {
+		return entity.User{}, err
+	}
+	user, err := service.userRepository.UpdateRole(ctx, userID, role) // Memanggil repository untuk mengubah role
+	if err != nil {
+		return entity.User{}, err
+	}
+	return user, service.revocationService.RevokeAllForUser(ctx, userID)
+}
+
+// SetSuspended adalah implementasi fungsi SetSuspended dari UserService.
+// User yang diblokir langsung kehilangan semua sesi aktifnya.
+func (service *userService) SetSuspended(ctx context.Context, userID uint64, suspended bool) (entity.User, error) {
+	if _, err := service.userRepository.FindByID(ctx, userID); err != nil {
+		return entity.User{}, err
+	}
+	user, err := service.userRepository.UpdateSuspended(ctx, userID, suspended) // Memanggil repository untuk mengubah status blokir
+	if err != nil || !suspended {
+		return user, err
+	}
+	return user, service.revocationService.RevokeAllForUser(ctx, userID)
+}
+
+// ResetPassword adalah implementasi fungsi ResetPassword dari UserService.
+// Semua sesi user dicabut agar orang yang mengetahui password lama tidak bisa memakai sesinya lagi.
+func (service *userService) ResetPassword(ctx context.Context, userID uint64, password string) error {
+	if err := service.policy.Validate(password); err != nil {
+		return err
+	}
+	if err := service.userRepository.UpdatePassword(ctx, userID, password); err != nil { // Memanggil repository untuk mengganti password
+		return err
+	}
+	return service.revocationService.RevokeAllForUser(ctx, userID)
+}
+
+// Unlock adalah implementasi fungsi Unlock dari UserService
+func (service *userService) Unlock(ctx context.Context, userID uint64) (entity.User, error) {
+	if _, err := service.userRepository.FindByID(ctx, userID); err != nil {
+		return entity.User{}, err
+	}
+	if err := service.userRepository.ResetFailedLogins(ctx, userID); err != nil { // Memanggil repository untuk membuka kunci akun
+		return entity.User{}, err
+	}
+	return service.userRepository.FindByID(ctx, userID) // Mengembalikan data user terbaru
 }
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"       // Mengimport package context untuk request ke service
+	"encoding/json" // Mengimport package json untuk mencetak pasangan token
+	"errors"        // Mengimport package errors untuk membuat error validasi
+	"fmt"           // Mengimport package fmt untuk mencetak token
+	"os"            // Mengimport package os untuk output JSON
+	"strconv"       // Mengimport package strconv untuk konversi ID user
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/app" // Mengimport container dependency aplikasi
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/dto" // Mengimport DTO untuk respons token
+)
+
+// runTokenIssue menjalankan perintah "token issue" yang mencetak access token untuk script,
+// atau JSON berisi access token dan refresh token jika -refresh diberikan
+func runTokenIssue(args []string) error {
+	flags := newFlagSet("token issue")
+	email := flags.String("email", "", "email of the user")
+	refresh := flags.Bool("refresh", false, "also issue a refresh token and print both as JSON")
+	cfg, err := loadConfig("token issue", flags, args, false)
+	if err != nil {
+		return err
+	}
+	if *email == "" {
+		return errors.New("-email is required")
+	}
+
+	a := app.New(cfg)
+	defer a.Close()
+	ctx := context.Background()
+	user, err := a.AuthService.FindByEmail(ctx, *email)
+	if err != nil {
+		return err
+	}
+	if user.Suspended {
+		return errors.New("user is suspended")
+	}
+	token, err := a.JWTService.GenerateToken(strconv.FormatUint(user.ID, 10), user.Role)
+	if err != nil {
+		return err
+	}
+	if !*refresh {
+		fmt.Println(token)
+		return nil
+	}
+	refreshToken, _, err := a.RefreshTokenService.Issue(ctx, user.ID) // Sesi dicatat saat refresh token pertama kali dipakai
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(os.Stdout).Encode(dto.TokenResponseDTO{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(a.JWTService.TokenTTL().Seconds()),
+	})
+}
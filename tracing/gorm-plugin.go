@@ -0,0 +1,79 @@
+package tracing
+
+import (
+	"errors" // Mengimport package errors untuk menggabungkan error dan mengenali record yang tidak ditemukan
+
+	"go.opentelemetry.io/otel/attribute" // Mengimport attribute span
+	"go.opentelemetry.io/otel/codes"     // Mengimport status span
+	"go.opentelemetry.io/otel/trace"     // Mengimport API trace
+	"gorm.io/gorm"                       // Mengimport package gorm untuk callback query
+)
+
+// spanKey adalah key instance GORM untuk menyimpan span query yang sedang berjalan
+const spanKey = "tracing:span"
+
+// InstrumentDB memasang plugin GORM yang membuat span untuk setiap query sebagai anak
+// dari span pada context query (db.WithContext)
+func InstrumentDB(db *gorm.DB) error {
+	return db.Use(&gormPlugin{})
+}
+
+// gormPlugin adalah plugin GORM yang membuat span untuk setiap query
+type gormPlugin struct{}
+
+// Name adalah implementasi fungsi Name dari gorm.Plugin
+func (p *gormPlugin) Name() string {
+	return "tracing"
+}
+
+// Initialize adalah implementasi fungsi Initialize dari gorm.Plugin, memasang callback
+// sebelum dan sesudah setiap jenis operasi
+func (p *gormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("tracing:before_create", p.before("create")),
+		cb.Create().After("gorm:create").Register("tracing:after_create", p.after),
+		cb.Query().Before("gorm:query").Register("tracing:before_query", p.before("query")),
+		cb.Query().After("gorm:query").Register("tracing:after_query", p.after),
+		cb.Update().Before("gorm:update").Register("tracing:before_update", p.before("update")),
+		cb.Update().After("gorm:update").Register("tracing:after_update", p.after),
+		cb.Delete().Before("gorm:delete").Register("tracing:before_delete", p.before("delete")),
+		cb.Delete().After("gorm:delete").Register("tracing:after_delete", p.after),
+		cb.Row().Before("gorm:row").Register("tracing:before_row", p.before("row")),
+		cb.Row().After("gorm:row").Register("tracing:after_row", p.after),
+		cb.Raw().Before("gorm:raw").Register("tracing:before_raw", p.before("raw")),
+		cb.Raw().After("gorm:raw").Register("tracing:after_raw", p.after),
+	)
+}
+
+// before membuat span query
+func (p *gormPlugin) before(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		_, span := Tracer().Start(db.Statement.Context, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system", db.Dialector.Name()),
+				attribute.String("db.operation", operation),
+			))
+		db.InstanceSet(spanKey, span)
+	}
+}
+
+// after menutup span query beserta SQL tanpa nilai parameter, tabel, jumlah baris, dan error
+func (p *gormPlugin) after(db *gorm.DB) {
+	value, ok := db.InstanceGet(spanKey)
+	if !ok {
+		return
+	}
+	span := value.(trace.Span)
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.statement", db.Statement.SQL.String()),
+		attribute.String("db.sql.table", db.Statement.Table),
+		attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+	)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
@@ -0,0 +1,52 @@
+package tracing
+
+import (
+	"context" // Mengimport package context untuk membawa span
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/apperror" // Mengimport package apperror untuk membedakan error client dan server
+	"go.opentelemetry.io/otel"                                // Mengimport API OpenTelemetry global
+	"go.opentelemetry.io/otel/attribute"                      // Mengimport attribute span
+	"go.opentelemetry.io/otel/codes"                          // Mengimport status span
+	"go.opentelemetry.io/otel/trace"                          // Mengimport API trace
+)
+
+// InstrumentationName adalah nama tracer untuk semua span aplikasi
+const InstrumentationName = "github.com/ImmanuelPardede/golang_gin_gorm_GWT"
+
+// Nama attribute span untuk data aplikasi
+const (
+	UserIDKey = attribute.Key("user.id") // UserIDKey adalah ID user yang melakukan atau menjadi target operasi
+	BookIDKey = attribute.Key("book.id") // BookIDKey adalah ID buku yang diproses
+)
+
+// Tracer mengembalikan tracer aplikasi dari TracerProvider global
+func Tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
+
+// Start membuat span baru sebagai anak dari span pada ctx
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End menutup span dan mencatat err jika ada. Hanya error internal yang menandai span gagal,
+// error dari client seperti data tidak ditemukan tetap dicatat sebagai event.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+	span.End()
+}
+
+// UserID membuat attribute user.id
+func UserID(id uint64) attribute.KeyValue {
+	return UserIDKey.Int64(int64(id))
+}
+
+// BookID membuat attribute book.id
+func BookID(id uint64) attribute.KeyValue {
+	return BookIDKey.Int64(int64(id))
+}
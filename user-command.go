@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bufio"   // Mengimport package bufio untuk membaca password dari stdin
+	"context" // Mengimport package context untuk request ke service
+	"errors"  // Mengimport package errors untuk membuat error validasi
+	"fmt"     // Mengimport package fmt untuk mencetak hasil perintah
+	"os"      // Mengimport package os untuk stdin dan stderr
+	"strings" // Mengimport package strings untuk merapikan input
+
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/app"     // Mengimport container dependency aplikasi
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/dto"     // Mengimport DTO untuk membuat user
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/entity"  // Mengimport entitas aplikasi untuk daftar role
+	"github.com/ImmanuelPardede/golang_gin_gorm_GWT/service" // Mengimport service aplikasi untuk error role
+)
+
+// runUserCreate menjalankan perintah "user create", misalnya untuk membuat admin pertama
+func runUserCreate(args []string) error {
+	flags := newFlagSet("user create")
+	name := flags.String("name", "", "name of the user")
+	email := flags.String("email", "", "email of the user")
+	password := flags.String("password", "", "password of the user, read from stdin when empty")
+	admin := flags.Bool("admin", false, "give the user the admin role")
+	role := flags.String("role", entity.RoleReader, "role of the user (reader, editor or admin)")
+	cfg, err := loadConfig("user create", flags, args, false)
+	if err != nil {
+		return err
+	}
+	if *admin {
+		*role = entity.RoleAdmin
+	}
+	if *name == "" || *email == "" {
+		return errors.New("-name and -email are required")
+	}
+	if !entity.IsValidRole(*role) {
+		return service.ErrInvalidRole
+	}
+	if *password == "" {
+		if *password, err = readPassword(); err != nil {
+			return err
+		}
+	}
+
+	a := app.New(cfg)
+	defer a.Close()
+	user, err := createUser(context.Background(), a, dto.RegisterDTO{Name: *name, Email: *email, Password: *password}, *role)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("created user %d %s (%s)\n", user.ID, user.Email, user.Role)
+	return nil
+}
+
+// runUserResetPassword menjalankan perintah "user reset-password". Semua sesi user ikut dicabut.
+func runUserResetPassword(args []string) error {
+	flags := newFlagSet("user reset-password")
+	email := flags.String("email", "", "email of the user")
+	password := flags.String("password", "", "new password, read from stdin when empty")
+	cfg, err := loadConfig("user reset-password", flags, args, false)
+	if err != nil {
+		return err
+	}
+	if *email == "" {
+		return errors.New("-email is required")
+	}
+	if *password == "" {
+		if *password, err = readPassword(); err != nil {
+			return err
+		}
+	}
+
+	a := app.New(cfg)
+	defer a.Close()
+	ctx := context.Background()
+	user, err := a.AuthService.FindByEmail(ctx, *email)
+	if err != nil {
+		return err
+	}
+	if err := a.UserService.ResetPassword(ctx, user.ID, *password); err != nil {
+		return err
+	}
+	fmt.Printf("password of %s has been reset, all sessions were revoked\n", user.Email)
+	return nil
+}
+
+// runUserUnlock menjalankan perintah "user unlock" untuk membuka kunci akun setelah login gagal berulang kali
+func runUserUnlock(args []string) error {
+	flags := newFlagSet("user unlock")
+	email := flags.String("email", "", "email of the user")
+	cfg, err := loadConfig("user unlock", flags, args, false)
+	if err != nil {
+		return err
+	}
+	if *email == "" {
+		return errors.New("-email is required")
+	}
+
+	a := app.New(cfg)
+	defer a.Close()
+	ctx := context.Background()
+	user, err := a.AuthService.FindByEmail(ctx, *email)
+	if err != nil {
+		return err
+	}
+	if _, err := a.UserService.Unlock(ctx, user.ID); err != nil {
+		return err
+	}
+	fmt.Printf("%s has been unlocked\n", user.Email)
+	return nil
+}
+
+// runUserResetMFA menjalankan perintah "user reset-mfa" untuk mematikan 2FA, misalnya ketika satu-satunya admin kehilangan authenticator
+func runUserResetMFA(args []string) error {
+	flags := newFlagSet("user reset-mfa")
+	email := flags.String("email", "", "email of the user")
+	cfg, err := loadConfig("user reset-mfa", flags, args, false)
+	if err != nil {
+		return err
+	}
+	if *email == "" {
+		return errors.New("-email is required")
+	}
+
+	a := app.New(cfg)
+	defer a.Close()
+	ctx := context.Background()
+	user, err := a.AuthService.FindByEmail(ctx, *email)
+	if err != nil {
+		return err
+	}
+	if err := a.MFAService.Reset(ctx, user.ID); err != nil {
+		return err
+	}
+	fmt.Printf("two-factor authentication of %s has been disabled\n", user.Email)
+	return nil
+}
+
+// createUser membuat user baru lalu memberikan role jika bukan role default.
+// Email user yang dibuat operator dianggap sudah terverifikasi.
+func createUser(ctx context.Context, a *app.App, register dto.RegisterDTO, role string) (entity.User, error) {
+	user, err := a.AuthService.CreateUser(ctx, register)
+	if err != nil {
+		return user, err
+	}
+	if err := a.VerificationService.MarkVerified(ctx, user.ID); err != nil {
+		return user, err
+	}
+	if role == user.Role {
+		return user, nil
+	}
+	return a.UserService.SetRole(ctx, user.ID, role)
+}
+
+// readPassword membaca password dari baris pertama stdin agar tidak tersimpan di riwayat shell
+func readPassword() (string, error) {
+	fmt.Fprint(os.Stderr, "Password: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("read password: %w", err)
+	}
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", errors.New("password must not be empty")
+	}
+	return password, nil
+}